@@ -0,0 +1,72 @@
+package mathx
+
+import "math"
+
+// AnomalyEvent reports the start or the end of a breach.
+type AnomalyEvent struct {
+	Value  float64
+	Score  float64 // z-score of Value
+	Breach bool    // true when a breach starts, false when it ends
+}
+
+// AnomalyDetector flags values whose z-score against the running
+// mean and standard deviation of previous values is too large.
+// It uses hysteresis to avoid flapping: a breach starts when |z| >= enter
+// and ends when |z| < exit, exit <= enter.
+// Every value is added to the running stats after it is scored.
+type AnomalyDetector struct {
+	stats       OnlineStats
+	enter, exit float64
+	warmup      uint64
+	breach      bool
+}
+
+// NewAnomalyDetector returns new AnomalyDetector with the given z-score thresholds.
+// No value is flagged until warmup values were observed.
+// It panics if exit > enter or exit is negative.
+func NewAnomalyDetector(enter, exit float64, warmup int) *AnomalyDetector {
+	if !(exit >= 0 && exit <= enter) {
+		panic("mathx: anomaly thresholds must satisfy 0 <= exit <= enter")
+	}
+	if warmup < 0 {
+		warmup = 0
+	}
+	return &AnomalyDetector{enter: enter, exit: exit, warmup: uint64(warmup)}
+}
+
+// Stats returns the running stats of observed values.
+func (d *AnomalyDetector) Stats() *OnlineStats { return &d.stats }
+
+// InBreach reports whether a breach is in progress.
+func (d *AnomalyDetector) InBreach() bool { return d.breach }
+
+// Reset resets the detector.
+func (d *AnomalyDetector) Reset() {
+	d.stats.Reset()
+	d.breach = false
+}
+
+// Update adds v and returns an event if a breach starts or ends on it.
+func (d *AnomalyDetector) Update(v float64) (AnomalyEvent, bool) {
+	if v != v {
+		return AnomalyEvent{}, false
+	}
+	var ev AnomalyEvent
+	var ok bool
+	if d.stats.Count() >= d.warmup && d.stats.Count() > 0 {
+		z := d.stats.ZScore(v)
+		switch abs := math.Abs(z); {
+		case !d.breach && abs >= d.enter:
+			d.breach = true
+			ev, ok = AnomalyEvent{Value: v, Score: z, Breach: true}, true
+		case d.breach && abs < d.exit:
+			d.breach = false
+			ev, ok = AnomalyEvent{Value: v, Score: z, Breach: false}, true
+		}
+	}
+	d.stats.Add(v)
+	return ev, ok
+}
+
+// Observe is like Update without the event.
+func (d *AnomalyDetector) Observe(v float64) { d.Update(v) }
@@ -0,0 +1,34 @@
+package mathx
+
+import "testing"
+
+func TestAnomalyDetector(t *testing.T) {
+	d := NewAnomalyDetector(4, 2, 10)
+	for i := 0; i < 100; i++ {
+		if _, ok := d.Update(float64(10 + i%3)); ok {
+			t.Fatalf("unexpected event on steady value %d", i)
+		}
+	}
+
+	ev, ok := d.Update(100)
+	if !ok || !ev.Breach || ev.Value != 100 || ev.Score < 4 || !d.InBreach() {
+		t.Fatalf("expected breach start; got %+v, %v", ev, ok)
+	}
+	if _, ok := d.Update(100); ok {
+		t.Fatal("no new event while the breach lasts")
+	}
+	// Between exit and enter thresholds: still in breach.
+	sd := d.Stats().StdDev()
+	if _, ok := d.Update(d.Stats().Mean() + 3*sd); ok || !d.InBreach() {
+		t.Fatal("hysteresis must keep the breach")
+	}
+	ev, ok = d.Update(d.Stats().Mean())
+	if !ok || ev.Breach || d.InBreach() {
+		t.Fatalf("expected breach end; got %+v, %v", ev, ok)
+	}
+
+	d.Reset()
+	if _, ok := d.Update(1e9); ok {
+		t.Fatal("no events during warmup")
+	}
+}
@@ -0,0 +1,69 @@
+package mathx
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// AtomicUint128 is a Uint128 value safe for concurrent use.
+// Go has no portable 128-bit atomic instructions, so operations
+// are serialized by a spinlock, which is cheap for short critical sections.
+// The zero value is 0. An AtomicUint128 must not be copied after first use.
+type AtomicUint128 struct {
+	lock uint32
+	v    Uint128
+}
+
+// Load returns the value of a.
+func (a *AtomicUint128) Load() Uint128 {
+	a.acquire()
+	v := a.v
+	a.release()
+	return v
+}
+
+// Store sets the value of a to v.
+func (a *AtomicUint128) Store(v Uint128) {
+	a.acquire()
+	a.v = v
+	a.release()
+}
+
+// Swap sets the value of a to v and returns the old value.
+func (a *AtomicUint128) Swap(v Uint128) Uint128 {
+	a.acquire()
+	old := a.v
+	a.v = v
+	a.release()
+	return old
+}
+
+// Add adds delta to a, wrapping around at 2^128, and returns the new value.
+func (a *AtomicUint128) Add(delta Uint128) Uint128 {
+	a.acquire()
+	v := a.v.Add(delta)
+	a.v = v
+	a.release()
+	return v
+}
+
+// CompareAndSwap sets the value of a to new if it is old and reports whether it did.
+func (a *AtomicUint128) CompareAndSwap(old, new Uint128) bool {
+	a.acquire()
+	swapped := a.v == old
+	if swapped {
+		a.v = new
+	}
+	a.release()
+	return swapped
+}
+
+func (a *AtomicUint128) acquire() {
+	for i := 0; !atomic.CompareAndSwapUint32(&a.lock, 0, 1); i++ {
+		if i >= 16 {
+			runtime.Gosched()
+		}
+	}
+}
+
+func (a *AtomicUint128) release() { atomic.StoreUint32(&a.lock, 0) }
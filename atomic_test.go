@@ -0,0 +1,56 @@
+package mathx
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicUint128(t *testing.T) {
+	var a AtomicUint128
+	if got := a.Load(); !got.IsZero() {
+		t.Fatalf("unexpected zero value; got %v", got)
+	}
+
+	a.Store(MaxUint128)
+	if got := a.Add(NewUint128(0, 2)); got != NewUint128(0, 1) {
+		t.Fatalf("unexpected Add; got %v; want %v", got, 1)
+	}
+	if got := a.Swap(NewUint128(1, 0)); got != NewUint128(0, 1) {
+		t.Fatalf("unexpected Swap; got %v; want %v", got, 1)
+	}
+	if a.CompareAndSwap(NewUint128(0, 1), Uint128{}) {
+		t.Fatalf("unexpected CompareAndSwap with stale old value")
+	}
+	if !a.CompareAndSwap(NewUint128(1, 0), NewUint128(2, 0)) || a.Load() != NewUint128(2, 0) {
+		t.Fatalf("unexpected CompareAndSwap; got %v", a.Load())
+	}
+}
+
+func TestAtomicUint128Concurrent(t *testing.T) {
+	const workers, n = 8, 1000
+
+	var a AtomicUint128
+	a.Store(NewUint128(0, ^uint64(0)-workers*n/2))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				a.Add(NewUint128(0, 1))
+				for {
+					old := a.Load()
+					if a.CompareAndSwap(old, old) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := NewUint128(1, workers*n/2-1)
+	if got := a.Load(); got != want {
+		t.Fatalf("unexpected value; got %v; want %v", got, want)
+	}
+}
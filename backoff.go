@@ -0,0 +1,136 @@
+package mathx
+
+import (
+	"math"
+	"time"
+
+	"github.com/valyala/fastrand"
+)
+
+// JitterMode specifies how a backoff delay is randomized.
+//
+// See: https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+type JitterMode uint8
+
+const (
+	// JitterNone uses the delay as is.
+	JitterNone JitterMode = iota
+	// JitterFull picks a uniform delay in [0, d].
+	JitterFull
+	// JitterEqual picks a uniform delay in [d/2, d].
+	JitterEqual
+	// JitterDecorrelated picks a uniform delay in [base, 3*previous],
+	// the growth factor and step are not used.
+	JitterDecorrelated
+)
+
+// Backoff generates retry delays growing exponentially or linearly up to a cap.
+// Jitter uses a deterministic RNG, see Seed, or a source set by SetRand.
+type Backoff struct {
+	base, step, cap time.Duration
+	factor          float64
+	jitter          JitterMode
+
+	attempt int
+	prev    time.Duration
+	seed    uint32
+	rng     fastrand.RNG
+	rand    func() uint32
+}
+
+// NewBackoff returns new Backoff with delays base * factor^n capped at cap.
+// It panics if base <= 0, cap < base or factor < 1.
+func NewBackoff(base, cap time.Duration, factor float64, jitter JitterMode) *Backoff {
+	if !(factor >= 1) || math.IsInf(factor, 0) {
+		panic("mathx: backoff factor must be at least 1")
+	}
+	return newBackoff(base, 0, cap, factor, jitter)
+}
+
+// NewLinearBackoff returns new Backoff with delays base + n * step capped at cap.
+// It panics if base <= 0, step < 0 or cap < base.
+func NewLinearBackoff(base, step, cap time.Duration, jitter JitterMode) *Backoff {
+	if step < 0 {
+		panic("mathx: backoff step must be non-negative")
+	}
+	return newBackoff(base, step, cap, 1, jitter)
+}
+
+func newBackoff(base, step, cap time.Duration, factor float64, jitter JitterMode) *Backoff {
+	if base <= 0 || cap < base {
+		panic("mathx: backoff base must be positive and not above cap")
+	}
+	b := &Backoff{
+		base:   base,
+		step:   step,
+		cap:    cap,
+		factor: factor,
+		jitter: jitter,
+		seed:   newSeed(),
+	}
+	b.Reset()
+	return b
+}
+
+// Seed sets the seed of the backoff RNG and resets the backoff.
+func (b *Backoff) Seed(seed uint32) {
+	b.seed = seed
+	b.Reset()
+}
+
+// SetRand sets the source of random numbers for jitter, e.g. a seeded RNG of the caller
+// to make delays deterministic in tests. A nil source restores the backoff RNG.
+// Reset does not reset the state of the source.
+func (b *Backoff) SetRand(rand func() uint32) { b.rand = rand }
+
+// Reset starts the sequence over, including the RNG state.
+func (b *Backoff) Reset() {
+	b.attempt = 0
+	b.prev = b.base
+	b.rng.Seed(rngSeed(b.seed))
+}
+
+// Attempt returns the number of delays returned by Next since the last Reset.
+func (b *Backoff) Attempt() int { return b.attempt }
+
+// Next returns the next delay.
+func (b *Backoff) Next() time.Duration {
+	d := b.delay(b.attempt)
+	b.attempt++
+
+	switch b.jitter {
+	case JitterFull:
+		d = b.uniform(0, d)
+	case JitterEqual:
+		d = b.uniform(d-d/2, d)
+	case JitterDecorrelated:
+		hi := b.cap
+		if b.prev <= b.cap/3 {
+			hi = 3 * b.prev
+		}
+		d = b.uniform(b.base, hi)
+		b.prev = d
+	}
+	return d
+}
+
+// delay returns the delay without jitter for attempt n.
+func (b *Backoff) delay(n int) time.Duration {
+	d := float64(b.base) * math.Pow(b.factor, float64(n))
+	d += float64(b.step) * float64(n)
+	if d >= float64(b.cap) {
+		return b.cap
+	}
+	return time.Duration(d)
+}
+
+// uniform returns a uniform random duration in [lo, hi].
+func (b *Backoff) uniform(lo, hi time.Duration) time.Duration {
+	next := b.rng.Uint32
+	if b.rand != nil {
+		next = b.rand
+	}
+	r := uint64(next())<<32 | uint64(next())
+	span := float64(hi - lo)
+	return lo + time.Duration(span*(float64(r>>11)/(1<<53)))
+}
@@ -0,0 +1,95 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	b := NewBackoff(100*time.Millisecond, 2*time.Second, 2, JitterNone)
+	want := []time.Duration{100, 200, 400, 800, 1600, 2000, 2000}
+	for i, w := range want {
+		if got := b.Next(); got != w*time.Millisecond {
+			t.Fatalf("unexpected delay %d; got %v; want %v", i, got, w*time.Millisecond)
+		}
+	}
+	if b.Attempt() != len(want) {
+		t.Fatalf("unexpected attempt; got %v; want %v", b.Attempt(), len(want))
+	}
+	for i := 0; i < 2000; i++ {
+		if got := b.Next(); got != 2*time.Second {
+			t.Fatalf("delay must stay at cap; got %v", got)
+		}
+	}
+	b.Reset()
+	if got := b.Next(); got != 100*time.Millisecond {
+		t.Fatalf("unexpected delay after Reset; got %v", got)
+	}
+
+	l := NewLinearBackoff(time.Second, 500*time.Millisecond, 2*time.Second, JitterNone)
+	want = []time.Duration{1000, 1500, 2000, 2000}
+	for i, w := range want {
+		if got := l.Next(); got != w*time.Millisecond {
+			t.Fatalf("unexpected linear delay %d; got %v; want %v", i, got, w*time.Millisecond)
+		}
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	base, cap := 10*time.Millisecond, time.Second
+	for _, mode := range []JitterMode{JitterFull, JitterEqual, JitterDecorrelated} {
+		b := NewBackoff(base, cap, 2, mode)
+		b.Seed(42)
+		var got []time.Duration
+		for i := 0; i < 50; i++ {
+			d := NewBackoff(base, cap, 2, JitterNone).delay(i)
+			v := b.Next()
+			got = append(got, v)
+
+			var lo, hi time.Duration
+			switch mode {
+			case JitterFull:
+				lo, hi = 0, d
+			case JitterEqual:
+				lo, hi = d/2, d
+			case JitterDecorrelated:
+				lo, hi = base, cap
+			}
+			if v < lo || v > hi {
+				t.Fatalf("mode %d: delay %d out of range; got %v; want [%v, %v]", mode, i, v, lo, hi)
+			}
+		}
+
+		b.Seed(42)
+		for i, w := range got {
+			if v := b.Next(); v != w {
+				t.Fatalf("mode %d: delays must be reproducible, %d; got %v; want %v", mode, i, v, w)
+			}
+		}
+	}
+}
+
+func TestBackoffSetRand(t *testing.T) {
+	b := NewBackoff(10*time.Millisecond, time.Second, 2, JitterFull)
+	b.SetRand(func() uint32 { return 0 })
+	for i := 0; i < 5; i++ {
+		if d := b.Next(); d != 0 {
+			t.Fatalf("unexpected delay %d with zero source; got %v; want 0", i, d)
+		}
+	}
+
+	b.Reset()
+	b.SetRand(func() uint32 { return math.MaxUint32 })
+	if d, want := b.Next(), 10*time.Millisecond; d < want-time.Microsecond || d > want {
+		t.Fatalf("unexpected delay with max source; got %v; want about %v", d, want)
+	}
+
+	b.SetRand(nil)
+	b.Seed(42)
+	want := b.Next()
+	b.Seed(42)
+	if got := b.Next(); got != want {
+		t.Fatalf("unexpected delay after restoring the RNG; got %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,146 @@
+package mathx
+
+// BaseCodec encodes Uint128 and Uint256 values as big-endian digits of an alphabet,
+// most significant first, without leading zero digits. Zero is the first symbol of the alphabet.
+type BaseCodec struct {
+	alphabet string
+	decode   [256]uint8
+}
+
+// Predefined codecs for compact IDs.
+var (
+	// Base58 uses the Bitcoin alphabet.
+	Base58 = NewBaseCodec("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// Base62 uses digits, then uppercase and lowercase letters.
+	Base62 = NewBaseCodec("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+
+	// Base32Crockford uses the Crockford alphabet. Decoding is case-insensitive
+	// and accepts I and L for 1 and O for 0.
+	Base32Crockford = newCrockford()
+)
+
+const baseInvalid = 0xff
+
+// NewBaseCodec returns new BaseCodec with the given alphabet of unique ASCII symbols,
+// the base is the length of the alphabet. It panics if the length is not in [2, 128].
+func NewBaseCodec(alphabet string) *BaseCodec {
+	if len(alphabet) < 2 || len(alphabet) > 128 {
+		panic("mathx: base codec alphabet length must be in [2, 128]")
+	}
+	c := &BaseCodec{alphabet: alphabet}
+	for i := range c.decode {
+		c.decode[i] = baseInvalid
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if alphabet[i] >= 0x80 || c.decode[alphabet[i]] != baseInvalid {
+			panic("mathx: base codec alphabet must have unique ASCII symbols")
+		}
+		c.decode[alphabet[i]] = uint8(i)
+	}
+	return c
+}
+
+func newCrockford() *BaseCodec {
+	c := NewBaseCodec("0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+	for i := 'A'; i <= 'Z'; i++ {
+		c.decode[i+'a'-'A'] = c.decode[i]
+	}
+	for _, alias := range "IiLl" {
+		c.decode[alias] = 1
+	}
+	c.decode['O'], c.decode['o'] = 0, 0
+	return c
+}
+
+// Base returns the number of symbols in the alphabet.
+func (c *BaseCodec) Base() int { return len(c.alphabet) }
+
+// Alphabet returns the symbols of the codec.
+func (c *BaseCodec) Alphabet() string { return c.alphabet }
+
+// EncodeUint128 returns the encoding of u.
+func (c *BaseCodec) EncodeUint128(u Uint128) string {
+	return string(c.AppendUint128(nil, u))
+}
+
+// AppendUint128 appends the encoding of u to dst.
+func (c *BaseCodec) AppendUint128(dst []byte, u Uint128) []byte {
+	var buf [128]byte
+	i := len(buf)
+	for {
+		var r uint64
+		u, r = u.QuoRem64(uint64(len(c.alphabet)))
+		i--
+		buf[i] = c.alphabet[r]
+		if u.IsZero() {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// DecodeUint128 returns the value of s.
+// It returns a *SyntaxError if s is empty or has a symbol not in the alphabet
+// and ErrOverflow if the value does not fit.
+func (c *BaseCodec) DecodeUint128(s string) (Uint128, error) {
+	if s == "" {
+		return Uint128{}, &SyntaxError{Offset: 0}
+	}
+	var u Uint128
+	for i := 0; i < len(s); i++ {
+		d := c.decode[s[i]]
+		if d == baseInvalid {
+			return Uint128{}, &SyntaxError{Offset: i}
+		}
+		var carry uint64
+		u, carry = u.mulAdd64(uint64(len(c.alphabet)), uint64(d))
+		if carry != 0 {
+			return Uint128{}, ErrOverflow
+		}
+	}
+	return u, nil
+}
+
+// EncodeUint256 returns the encoding of u.
+func (c *BaseCodec) EncodeUint256(u Uint256) string {
+	return string(c.AppendUint256(nil, u))
+}
+
+// AppendUint256 appends the encoding of u to dst.
+func (c *BaseCodec) AppendUint256(dst []byte, u Uint256) []byte {
+	var buf [256]byte
+	i := len(buf)
+	for {
+		var r uint64
+		u, r = u.QuoRem64(uint64(len(c.alphabet)))
+		i--
+		buf[i] = c.alphabet[r]
+		if u.IsZero() {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// DecodeUint256 returns the value of s.
+// It returns a *SyntaxError if s is empty or has a symbol not in the alphabet
+// and ErrOverflow if the value does not fit.
+func (c *BaseCodec) DecodeUint256(s string) (Uint256, error) {
+	if s == "" {
+		return Uint256{}, &SyntaxError{Offset: 0}
+	}
+	var u Uint256
+	for i := 0; i < len(s); i++ {
+		d := c.decode[s[i]]
+		if d == baseInvalid {
+			return Uint256{}, &SyntaxError{Offset: i}
+		}
+		var carry uint64
+		u, carry = u.mulAdd64(uint64(len(c.alphabet)), uint64(d))
+		if carry != 0 {
+			return Uint256{}, ErrOverflow
+		}
+	}
+	return u, nil
+}
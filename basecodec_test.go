@@ -0,0 +1,133 @@
+package mathx
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/valyala/fastrand"
+)
+
+func TestBaseCodec(t *testing.T) {
+	testCases := []struct {
+		c    *BaseCodec
+		v    uint64
+		want string
+	}{
+		{Base58, 0, "1"},
+		{Base58, 57, "z"},
+		{Base58, 58, "21"},
+		{Base62, 61, "z"},
+		{Base62, 62, "10"},
+		{Base32Crockford, 31, "Z"},
+		{Base32Crockford, 1234, "16J"},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.c.EncodeUint128(Uint128FromUint64(tc.v)); got != tc.want {
+			t.Fatalf("unexpected encoding of %d in base %d; got %q; want %q", tc.v, tc.c.Base(), got, tc.want)
+		}
+		if got := tc.c.EncodeUint256(Uint256FromUint64(tc.v)); got != tc.want {
+			t.Fatalf("unexpected Uint256 encoding of %d in base %d; got %q; want %q", tc.v, tc.c.Base(), got, tc.want)
+		}
+		if got, err := tc.c.DecodeUint128(tc.want); err != nil || got != Uint128FromUint64(tc.v) {
+			t.Fatalf("unexpected decoding of %q; got %v, %v; want %v", tc.want, got, err, tc.v)
+		}
+	}
+}
+
+func TestBaseCodecRoundtrip(t *testing.T) {
+	var r fastrand.RNG
+	r.Seed(1)
+	r64 := func() uint64 { return uint64(r.Uint32())<<32 | uint64(r.Uint32()) }
+	for _, c := range []*BaseCodec{Base58, Base62, Base32Crockford} {
+		for i := 0; i < 200; i++ {
+			u := NewUint256(NewUint128(r64(), r64()), NewUint128(r64(), r64()))
+			u = u.Rsh(uint(r.Uint32n(256)))
+
+			s := c.EncodeUint256(u)
+			if want := baseEncodeBig(c, u.Big()); s != want {
+				t.Fatalf("unexpected encoding of %v in base %d; got %q; want %q", u, c.Base(), s, want)
+			}
+			if got, err := c.DecodeUint256(s); err != nil || got != u {
+				t.Fatalf("unexpected roundtrip of %v; got %v, %v", u, got, err)
+			}
+
+			if u.hi.IsZero() {
+				if got := c.EncodeUint128(u.lo); got != s {
+					t.Fatalf("unexpected Uint128 encoding of %v; got %q; want %q", u.lo, got, s)
+				}
+				if got, err := c.DecodeUint128(s); err != nil || got != u.lo {
+					t.Fatalf("unexpected Uint128 roundtrip of %v; got %v, %v", u.lo, got, err)
+				}
+			} else if _, err := c.DecodeUint128(s); !errors.Is(err, ErrOverflow) {
+				t.Fatalf("unexpected error for %q; got %v; want %v", s, err, ErrOverflow)
+			}
+		}
+	}
+}
+
+func TestBaseCodecErrors(t *testing.T) {
+	for _, s := range []string{"", "0", "l", "1 2", "é"} {
+		if _, err := Base58.DecodeUint128(s); !errors.Is(err, ErrSyntax) {
+			t.Fatalf("unexpected error for %q; got %v; want %v", s, err, ErrSyntax)
+		}
+	}
+
+	over := Base62.EncodeUint256(NewUint256(Uint128{}, MaxUint128).Inc())
+	if _, err := Base62.DecodeUint128(over); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("unexpected error for %q; got %v; want %v", over, err, ErrOverflow)
+	}
+	over = Base62.EncodeUint256(MaxUint256) + "0"
+	if _, err := Base62.DecodeUint256(over); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("unexpected error for %q; got %v; want %v", over, err, ErrOverflow)
+	}
+
+	got, err := Base32Crockford.DecodeUint128("1o-")
+	if !errors.Is(err, ErrSyntax) {
+		t.Fatalf("unexpected error for hyphen; got %v, %v; want %v", got, err, ErrSyntax)
+	}
+	if got, err := Base32Crockford.DecodeUint128("iLoO1z"); err != nil || got != Uint128FromUint64(0x210003f) {
+		t.Fatalf("unexpected Crockford aliases; got %#x, %v", got, err)
+	}
+}
+
+func TestNewBaseCodecPanics(t *testing.T) {
+	for _, alphabet := range []string{"", "a", "abca", "ab\x80"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected panic for alphabet %q", alphabet)
+				}
+			}()
+			NewBaseCodec(alphabet)
+		}()
+	}
+}
+
+func TestBaseCodecASCII(t *testing.T) {
+	alphabet := make([]byte, 128)
+	for i := range alphabet {
+		alphabet[i] = byte(i)
+	}
+	c := NewBaseCodec(string(alphabet))
+	if c.Base() != 128 {
+		t.Fatalf("unexpected base; got %v; want %v", c.Base(), 128)
+	}
+	if got, err := c.DecodeUint128(c.EncodeUint128(MaxUint128)); err != nil || got != MaxUint128 {
+		t.Fatalf("unexpected roundtrip; got %v, %v", got, err)
+	}
+}
+
+func baseEncodeBig(c *BaseCodec, b *big.Int) string {
+	base := big.NewInt(int64(c.Base()))
+	var out []byte
+	for {
+		m := new(big.Int)
+		b, m = new(big.Int).QuoRem(b, base, m)
+		out = append([]byte{c.Alphabet()[m.Int64()]}, out...)
+		if b.Sign() == 0 {
+			return string(out)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package mathx
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// SetBig sets dst to u and returns dst.
+// It reuses the storage of dst, so it does not allocate when dst is large enough.
+func (u Uint128) SetBig(dst *big.Int) *big.Int {
+	return setBigLimbs(dst, []uint64{u.lo, u.hi}, false)
+}
+
+// SetBig sets dst to u and returns dst.
+// It reuses the storage of dst, so it does not allocate when dst is large enough.
+func (u Uint256) SetBig(dst *big.Int) *big.Int {
+	return setBigLimbs(dst, []uint64{u.lo.lo, u.lo.hi, u.hi.lo, u.hi.hi}, false)
+}
+
+// SetBig sets dst to i and returns dst.
+// It reuses the storage of dst, so it does not allocate when dst is large enough.
+func (i Int128) SetBig(dst *big.Int) *big.Int {
+	a := i.Abs()
+	return setBigLimbs(dst, []uint64{a.lo, a.hi}, i.IsNeg())
+}
+
+// SetBig sets dst to i and returns dst.
+// It reuses the storage of dst, so it does not allocate when dst is large enough.
+func (i Int256) SetBig(dst *big.Int) *big.Int {
+	a := i.Abs()
+	return setBigLimbs(dst, []uint64{a.lo.lo, a.lo.hi, a.hi.lo, a.hi.hi}, i.IsNeg())
+}
+
+// setBigLimbs sets dst to ±limbs given least significant first.
+func setBigLimbs(dst *big.Int, limbs []uint64, neg bool) *big.Int {
+	ws := dst.Bits()[:0]
+	for _, l := range limbs {
+		if bits.UintSize == 32 {
+			ws = append(ws, big.Word(l), big.Word(l>>32))
+		} else {
+			ws = append(ws, big.Word(l))
+		}
+	}
+	dst.SetBits(ws)
+	if neg {
+		dst.Neg(dst)
+	}
+	return dst
+}
+
+// Uint128FromBig returns b as Uint128.
+// It returns ErrNegative for negative b and ErrOverflow if b does not fit into 128 bits.
+func Uint128FromBig(b *big.Int) (Uint128, error) {
+	var limbs [2]uint64
+	if err := bigLimbs(limbs[:], b); err != nil {
+		return Uint128{}, err
+	}
+	return Uint128{hi: limbs[1], lo: limbs[0]}, nil
+}
+
+// Uint256FromBig returns b as Uint256.
+// It returns ErrNegative for negative b and ErrOverflow if b does not fit into 256 bits.
+func Uint256FromBig(b *big.Int) (Uint256, error) {
+	var limbs [4]uint64
+	if err := bigLimbs(limbs[:], b); err != nil {
+		return Uint256{}, err
+	}
+	return Uint256{hi: Uint128{hi: limbs[3], lo: limbs[2]}, lo: Uint128{hi: limbs[1], lo: limbs[0]}}, nil
+}
+
+// bigLimbs sets limbs to the 64-bit limbs of non-negative b, least significant first.
+func bigLimbs(limbs []uint64, b *big.Int) error {
+	switch {
+	case b.Sign() < 0:
+		return ErrNegative
+	case b.BitLen() > 64*len(limbs):
+		return ErrOverflow
+	}
+	for i, w := range b.Bits() {
+		if bits.UintSize == 32 {
+			limbs[i/2] |= uint64(w) << (32 * (i % 2))
+		} else {
+			limbs[i] = uint64(w)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package mathx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSetBig(t *testing.T) {
+	dst := new(big.Int)
+
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	want, _ := new(big.Int).SetString("0123456789abcdeffedcba9876543210", 16)
+	if got := u.SetBig(dst); got != dst || got.Cmp(want) != 0 {
+		t.Fatalf("unexpected SetBig; got %v; want %v", got, want)
+	}
+
+	u256 := NewUint256(u, u)
+	want.Lsh(want, 128).Or(want, u.Big())
+	if got := u256.SetBig(dst); got.Cmp(want) != 0 {
+		t.Fatalf("unexpected SetBig; got %v; want %v", got, want)
+	}
+
+	i := Int128FromInt64(-42)
+	if got := i.SetBig(dst); got.Int64() != -42 {
+		t.Fatalf("unexpected SetBig; got %v; want %v", got, -42)
+	}
+	if got := (Int256{}).SetBig(dst); got.Sign() != 0 {
+		t.Fatalf("unexpected SetBig; got %v; want %v", got, 0)
+	}
+}
+
+func TestSetBigAllocs(t *testing.T) {
+	dst := NewUint256(MaxUint128, MaxUint128).Big()
+	u := NewUint256(MaxUint128, Uint128{})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		u.SetBig(dst)
+		Int128FromInt64(-1).SetBig(dst)
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected allocs; got %v; want %v", allocs, 0)
+	}
+}
+
+func TestUint128FromBig(t *testing.T) {
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	testCases := []struct {
+		b    *big.Int
+		want Uint128
+		err  error
+	}{
+		{big.NewInt(0), Uint128{}, nil},
+		{big.NewInt(42), Uint128{lo: 42}, nil},
+		{new(big.Int).Lsh(big.NewInt(3), 64), Uint128{hi: 3}, nil},
+		{max, MaxUint128, nil},
+		{new(big.Int).Add(max, big.NewInt(1)), Uint128{}, ErrOverflow},
+		{big.NewInt(-1), Uint128{}, ErrNegative},
+	}
+	for _, tc := range testCases {
+		if got, err := Uint128FromBig(tc.b); got != tc.want || err != tc.err {
+			t.Fatalf("unexpected Uint128FromBig(%v); got %v, %v; want %v, %v", tc.b, got, err, tc.want, tc.err)
+		}
+	}
+
+	u := NewUint256(NewUint128(1, 2), NewUint128(3, 4))
+	if got, err := Uint256FromBig(u.Big()); got != u || err != nil {
+		t.Fatalf("unexpected Uint256FromBig; got %v, %v; want %v", got, err, u)
+	}
+	if _, err := Uint256FromBig(new(big.Int).Lsh(big.NewInt(1), 256)); err != ErrOverflow {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrOverflow)
+	}
+	if _, err := Uint256FromBig(new(big.Int).Neg(u.Big())); err != ErrNegative {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrNegative)
+	}
+}
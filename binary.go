@@ -0,0 +1,117 @@
+package mathx
+
+import "encoding/binary"
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is 16 bytes big-endian.
+func (u Uint128) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 16))
+}
+
+// AppendBinary appends the 16-byte big-endian encoding of u to b.
+func (u Uint128) AppendBinary(b []byte) ([]byte, error) {
+	var buf [16]byte
+	u.PutBytes(buf[:])
+	return append(b, buf[:]...), nil
+}
+
+// PutBytes writes the 16-byte big-endian encoding of u into b.
+// It panics if len(b) < 16.
+func (u Uint128) PutBytes(b []byte) {
+	_ = b[15] // early bounds check
+	binary.BigEndian.PutUint64(b[:8], u.hi)
+	binary.BigEndian.PutUint64(b[8:], u.lo)
+}
+
+// Uint128FromBytes returns the value of the 16-byte big-endian encoding in b.
+// It panics if len(b) < 16.
+func Uint128FromBytes(b []byte) Uint128 {
+	_ = b[15] // early bounds check
+	return Uint128{
+		hi: binary.BigEndian.Uint64(b[:8]),
+		lo: binary.BigEndian.Uint64(b[8:]),
+	}
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint128) UnmarshalBinary(b []byte) error {
+	if len(b) != 16 {
+		return ErrInvalidEncoding
+	}
+	*u = Uint128FromBytes(b)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is 32 bytes big-endian.
+func (u Uint256) MarshalBinary() ([]byte, error) {
+	return u.AppendBinary(make([]byte, 0, 32))
+}
+
+// AppendBinary appends the 32-byte big-endian encoding of u to b.
+func (u Uint256) AppendBinary(b []byte) ([]byte, error) {
+	var buf [32]byte
+	u.PutBytes(buf[:])
+	return append(b, buf[:]...), nil
+}
+
+// PutBytes writes the 32-byte big-endian encoding of u into b.
+// It panics if len(b) < 32.
+func (u Uint256) PutBytes(b []byte) {
+	_ = b[31] // early bounds check
+	u.hi.PutBytes(b[:16])
+	u.lo.PutBytes(b[16:])
+}
+
+// Uint256FromBytes returns the value of the 32-byte big-endian encoding in b.
+// It panics if len(b) < 32.
+func Uint256FromBytes(b []byte) Uint256 {
+	_ = b[31] // early bounds check
+	return Uint256{hi: Uint128FromBytes(b[:16]), lo: Uint128FromBytes(b[16:])}
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *Uint256) UnmarshalBinary(b []byte) error {
+	if len(b) != 32 {
+		return ErrInvalidEncoding
+	}
+	*u = Uint256FromBytes(b)
+	return nil
+}
+
+// Bytes returns the 16-byte big-endian encoding of u.
+func (u Uint128) Bytes() [16]byte {
+	var b [16]byte
+	u.PutBytes(b[:])
+	return b
+}
+
+// BytesLittleEndian returns the 16-byte little-endian encoding of u.
+func (u Uint128) BytesLittleEndian() [16]byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[:8], u.lo)
+	binary.LittleEndian.PutUint64(b[8:], u.hi)
+	return b
+}
+
+// SetBytes sets u to the value of b interpreted as a big-endian unsigned integer and returns u.
+// Shorter inputs are zero-extended, longer inputs are truncated to the low 16 bytes.
+func (u *Uint128) SetBytes(b []byte) *Uint128 {
+	var buf [16]byte
+	if len(b) > 16 {
+		b = b[len(b)-16:]
+	}
+	copy(buf[16-len(b):], b)
+	_ = u.UnmarshalBinary(buf[:])
+	return u
+}
+
+// SetBytesLittleEndian sets u to the value of b interpreted as a little-endian unsigned integer and returns u.
+// Shorter inputs are zero-extended, longer inputs are truncated to the low 16 bytes.
+func (u *Uint128) SetBytesLittleEndian(b []byte) *Uint128 {
+	var buf [16]byte
+	copy(buf[:], b)
+	u.lo = binary.LittleEndian.Uint64(buf[:8])
+	u.hi = binary.LittleEndian.Uint64(buf[8:])
+	return u
+}
@@ -0,0 +1,120 @@
+package mathx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUint128Binary(t *testing.T) {
+	u := NewUint128(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	b, err := u.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	if !bytes.Equal(b, want) {
+		t.Fatalf("unexpected encoding; got % x; want % x", b, want)
+	}
+
+	var got Uint128
+	if err := got.UnmarshalBinary(b); err != nil || got != u {
+		t.Fatalf("unexpected roundtrip; got %v, %v; want %v", got, err, u)
+	}
+	if err := got.UnmarshalBinary(b[:15]); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = u.AppendBinary(buf[:0])
+	})
+	if allocs != 0 || !bytes.Equal(buf, want) {
+		t.Fatalf("unexpected AppendBinary; got % x with %v allocs", buf, allocs)
+	}
+}
+
+func TestUint256Binary(t *testing.T) {
+	u := NewUint256(NewUint128(1, 2), NewUint128(3, 4))
+	b, err := u.AppendBinary([]byte{0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != 33 || b[0] != 0xff || b[8] != 1 || b[32] != 4 {
+		t.Fatalf("unexpected encoding: % x", b)
+	}
+
+	var got Uint256
+	if err := got.UnmarshalBinary(b[1:]); err != nil || got != u {
+		t.Fatalf("unexpected roundtrip; got %v, %v; want %v", got, err, u)
+	}
+	if err := got.UnmarshalBinary(b); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+}
+
+func TestUint128Bytes(t *testing.T) {
+	u := NewUint128(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	be, le := u.Bytes(), u.BytesLittleEndian()
+	for i := range be {
+		if be[i] != byte(i+1) || le[i] != byte(16-i) {
+			t.Fatalf("unexpected bytes; got % x and % x", be, le)
+		}
+	}
+
+	var got Uint128
+	if got.SetBytes(be[:]); got != u {
+		t.Fatalf("unexpected SetBytes; got %v; want %v", got, u)
+	}
+	if got.SetBytesLittleEndian(le[:]); got != u {
+		t.Fatalf("unexpected SetBytesLittleEndian; got %v; want %v", got, u)
+	}
+
+	testCases := []struct {
+		b      []byte
+		be, le Uint128
+	}{
+		{nil, Uint128{}, Uint128{}},
+		{[]byte{1, 2}, NewUint128(0, 0x0102), NewUint128(0, 0x0201)},
+		{append([]byte{0xff, 0xff}, be[:]...), u, NewUint128(0x0e0d0c0b0a090807, 0x060504030201ffff)},
+	}
+	for _, tc := range testCases {
+		if got := *new(Uint128).SetBytes(tc.b); got != tc.be {
+			t.Fatalf("unexpected SetBytes(% x); got %#x; want %#x", tc.b, got, tc.be)
+		}
+		if got := *new(Uint128).SetBytesLittleEndian(tc.b); got != tc.le {
+			t.Fatalf("unexpected SetBytesLittleEndian(% x); got %#x; want %#x", tc.b, got, tc.le)
+		}
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	u := NewUint256(NewUint128(1, 2), NewUint128(3, 4))
+	var buf [32]byte
+	allocs := testing.AllocsPerRun(100, func() {
+		u.PutBytes(buf[:])
+		u = Uint256FromBytes(buf[:])
+	})
+	if allocs != 0 || u != NewUint256(NewUint128(1, 2), NewUint128(3, 4)) {
+		t.Fatalf("unexpected roundtrip; got %v with %v allocs", u, allocs)
+	}
+	if got := Uint128FromBytes(buf[16:]); got != NewUint128(3, 4) {
+		t.Fatalf("unexpected Uint128FromBytes; got %v; want %v", got, NewUint128(3, 4))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on short input")
+		}
+	}()
+	Uint128FromBytes(buf[:15])
+}
+
+func BenchmarkUint256FromBytes(b *testing.B) {
+	var buf [32]byte
+	u := NewUint256(NewUint128(1, 2), NewUint128(3, 4))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		u.PutBytes(buf[:])
+		u = Uint256FromBytes(buf[:])
+	}
+}
@@ -0,0 +1,143 @@
+package mathx
+
+import "math"
+
+// Binomial proportion confidence intervals for successes out of total trials
+// at the given two-sided confidence level in (0, 1), e.g. 0.95.
+// For invalid arguments, total == 0, successes > total or a confidence
+// outside (0, 1), they return NaN, NaN.
+
+// WilsonInterval returns the Wilson score interval.
+// It behaves well for small totals and proportions near 0 or 1.
+func WilsonInterval(successes, total uint64, confidence float64) (lo, hi float64) {
+	z, ok := binomialZ(successes, total, confidence)
+	if !ok {
+		return NaN, NaN
+	}
+	n := float64(total)
+	p := float64(successes) / n
+	z2 := z * z
+	denom := 1 + z2/n
+	center := (p + z2/(2*n)) / denom
+	half := z * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / denom
+	return Clamp01(center - half), Clamp01(center + half)
+}
+
+// AgrestiCoullInterval returns the Agresti-Coull interval,
+// the Wald interval of the proportion with z^2/2 pseudo-successes and failures added.
+func AgrestiCoullInterval(successes, total uint64, confidence float64) (lo, hi float64) {
+	z, ok := binomialZ(successes, total, confidence)
+	if !ok {
+		return NaN, NaN
+	}
+	z2 := z * z
+	n := float64(total) + z2
+	p := (float64(successes) + z2/2) / n
+	half := z * math.Sqrt(p*(1-p)/n)
+	return Clamp01(p - half), Clamp01(p + half)
+}
+
+// ClopperPearsonInterval returns the exact Clopper-Pearson interval
+// based on beta distribution quantiles. It is conservative:
+// the coverage is at least the confidence level.
+func ClopperPearsonInterval(successes, total uint64, confidence float64) (lo, hi float64) {
+	if _, ok := binomialZ(successes, total, confidence); !ok {
+		return NaN, NaN
+	}
+	alpha := (1 - confidence) / 2
+	x, n := float64(successes), float64(total)
+	lo, hi = 0, 1
+	if successes > 0 {
+		lo = betaInv(alpha, x, n-x+1)
+	}
+	if successes < total {
+		hi = betaInv(1-alpha, x+1, n-x)
+	}
+	return lo, hi
+}
+
+// binomialZ returns the two-sided normal quantile for confidence
+// and whether the arguments are valid.
+func binomialZ(successes, total uint64, confidence float64) (float64, bool) {
+	if total == 0 || successes > total || !(confidence > 0 && confidence < 1) {
+		return 0, false
+	}
+	return NormInvCDF(1 - (1-confidence)/2), true
+}
+
+// betaInv returns x such that the regularized incomplete beta function I_x(a, b) == p.
+// I_x is monotonic in x, so bisection always converges to full precision.
+func betaInv(p, a, b float64) float64 {
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		if mid == lo || mid == hi {
+			break
+		}
+		if betaInc(mid, a, b) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// betaInc returns the regularized incomplete beta function I_x(a, b)
+// using the continued fraction from Numerical Recipes, 6.4.
+func betaInc(x, a, b float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betaCF(x, a, b) / a
+	}
+	return 1 - front*betaCF(1-x, b, a)/b
+}
+
+// betaCF evaluates the continued fraction for betaInc by the modified Lentz's method.
+func betaCF(x, a, b float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m <= 1000; m++ {
+		m2 := 2 * m
+		aa := m * (b - m) * x / ((a + m2 - 1) * (a + m2))
+		d, c = 1+aa*d, 1+aa/c
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + m) * (a + b + m) * x / ((a + m2) * (a + m2 + 1))
+		d, c = 1+aa*d, 1+aa/c
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-16 {
+			break
+		}
+	}
+	return h
+}
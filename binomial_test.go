@@ -0,0 +1,88 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWilsonAgrestiCoull(t *testing.T) {
+	testCases := []struct {
+		x, n       uint64
+		wLo, wHi   float64
+		acLo, acHi float64
+	}{
+		{5, 10, 0.236593090512564, 0.7634069094874361, 0.236593090512564, 0.7634069094874361},
+		{0, 10, 0, 0.2775327998628892, 0, 0.3208873057505457},
+		{1, 100, 0.0017674320641406505, 0.054486196178705315, 0, 0.05992686188185029},
+		{99, 100, 0.9455138038212946, 0.9982325679358593, 0.9400731381181496, 1},
+	}
+	for _, tc := range testCases {
+		lo, hi := WilsonInterval(tc.x, tc.n, 0.95)
+		if !approxEqual(lo, tc.wLo) || !approxEqual(hi, tc.wHi) {
+			t.Fatalf("unexpected Wilson(%d/%d); got [%v, %v]; want [%v, %v]", tc.x, tc.n, lo, hi, tc.wLo, tc.wHi)
+		}
+		lo, hi = AgrestiCoullInterval(tc.x, tc.n, 0.95)
+		if !approxEqual(lo, tc.acLo) || !approxEqual(hi, tc.acHi) {
+			t.Fatalf("unexpected AgrestiCoull(%d/%d); got [%v, %v]; want [%v, %v]", tc.x, tc.n, lo, hi, tc.acLo, tc.acHi)
+		}
+	}
+}
+
+func TestClopperPearson(t *testing.T) {
+	// Closed forms for the extreme counts.
+	lo, hi := ClopperPearsonInterval(0, 10, 0.95)
+	if lo != 0 || !approxEqual(hi, 1-math.Pow(0.025, 0.1)) {
+		t.Fatalf("unexpected interval for 0/10; got [%v, %v]", lo, hi)
+	}
+	lo, hi = ClopperPearsonInterval(10, 10, 0.95)
+	if !approxEqual(lo, math.Pow(0.025, 0.1)) || hi != 1 {
+		t.Fatalf("unexpected interval for 10/10; got [%v, %v]", lo, hi)
+	}
+
+	// The bounds are where the binomial tails equal alpha/2.
+	for _, tc := range []struct{ x, n int }{{5, 10}, {1, 50}, {37, 200}, {999, 1000}} {
+		lo, hi := ClopperPearsonInterval(uint64(tc.x), uint64(tc.n), 0.9)
+		if upper := binomTail(tc.x, tc.n, lo, true); math.Abs(upper-0.05) > 1e-9 {
+			t.Fatalf("unexpected lower bound for %d/%d: P(X >= x) = %v", tc.x, tc.n, upper)
+		}
+		if lower := binomTail(tc.x, tc.n, hi, false); math.Abs(lower-0.05) > 1e-9 {
+			t.Fatalf("unexpected upper bound for %d/%d: P(X <= x) = %v", tc.x, tc.n, lower)
+		}
+		if wLo, wHi := WilsonInterval(uint64(tc.x), uint64(tc.n), 0.9); lo > wLo || hi < wHi {
+			t.Fatalf("Clopper-Pearson must be wider than Wilson for %d/%d", tc.x, tc.n)
+		}
+	}
+}
+
+func TestBinomialIntervalInvalid(t *testing.T) {
+	fns := []func(uint64, uint64, float64) (float64, float64){WilsonInterval, AgrestiCoullInterval, ClopperPearsonInterval}
+	for _, fn := range fns {
+		for _, args := range []struct {
+			x, n uint64
+			c    float64
+		}{{0, 0, 0.95}, {2, 1, 0.95}, {1, 2, 0}, {1, 2, 1}, {1, 2, NaN}} {
+			if lo, hi := fn(args.x, args.n, args.c); !math.IsNaN(lo) || !math.IsNaN(hi) {
+				t.Fatalf("unexpected interval for %+v; got [%v, %v]; want NaN", args, lo, hi)
+			}
+		}
+	}
+}
+
+// binomTail returns P(X >= x) or P(X <= x) for X ~ Binomial(n, p).
+func binomTail(x, n int, p float64, upper bool) float64 {
+	var sum float64
+	for k := 0; k <= n; k++ {
+		if upper && k < x || !upper && k > x {
+			continue
+		}
+		lc, _ := math.Lgamma(float64(n + 1))
+		lk, _ := math.Lgamma(float64(k + 1))
+		lnk, _ := math.Lgamma(float64(n - k + 1))
+		sum += math.Exp(lc - lk - lnk + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p))
+	}
+	return sum
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-12*math.Max(1, math.Abs(b))
+}
@@ -0,0 +1,113 @@
+package mathx
+
+import "math/bits"
+
+// BitLen returns the minimum number of bits required to represent u, the result is 0 for u == 0.
+func (u Uint128) BitLen() int { return 128 - u.LeadingZeros() }
+
+// LeadingZeros returns the number of leading zero bits in u, the result is 128 for u == 0.
+func (u Uint128) LeadingZeros() int {
+	if u.hi != 0 {
+		return bits.LeadingZeros64(u.hi)
+	}
+	return 64 + bits.LeadingZeros64(u.lo)
+}
+
+// TrailingZeros returns the number of trailing zero bits in u, the result is 128 for u == 0.
+func (u Uint128) TrailingZeros() int {
+	if u.lo != 0 {
+		return bits.TrailingZeros64(u.lo)
+	}
+	return 64 + bits.TrailingZeros64(u.hi)
+}
+
+// OnesCount returns the number of one bits ("population count") in u.
+func (u Uint128) OnesCount() int { return bits.OnesCount64(u.hi) + bits.OnesCount64(u.lo) }
+
+// RotateLeft returns the value of u rotated left by (k mod 128) bits.
+// To rotate u right by k bits, call u.RotateLeft(-k).
+func (u Uint128) RotateLeft(k int) Uint128 {
+	n := uint(k) & 127
+	if n >= 64 {
+		u.hi, u.lo = u.lo, u.hi
+		n -= 64
+	}
+	if n == 0 {
+		return u
+	}
+	return Uint128{
+		hi: u.hi<<n | u.lo>>(64-n),
+		lo: u.lo<<n | u.hi>>(64-n),
+	}
+}
+
+// Reverse returns the value of u with its bits in reversed order.
+func (u Uint128) Reverse() Uint128 {
+	return Uint128{hi: bits.Reverse64(u.lo), lo: bits.Reverse64(u.hi)}
+}
+
+// ReverseBytes returns the value of u with its bytes in reversed order.
+func (u Uint128) ReverseBytes() Uint128 {
+	return Uint128{hi: bits.ReverseBytes64(u.lo), lo: bits.ReverseBytes64(u.hi)}
+}
+
+// Bit returns the value of the i'th bit of u, the result is 0 for i >= 128.
+func (u Uint128) Bit(i uint) uint {
+	return uint(u.Rsh(i).lo & 1)
+}
+
+// SetBit returns u with the i'th bit set, u is unchanged for i >= 128.
+func (u Uint128) SetBit(i uint) Uint128 { return u.Or(Uint128FromUint64(1).Lsh(i)) }
+
+// ClearBit returns u with the i'th bit cleared, u is unchanged for i >= 128.
+func (u Uint128) ClearBit(i uint) Uint128 { return u.And(Uint128FromUint64(1).Lsh(i).Not()) }
+
+// ToggleBit returns u with the i'th bit flipped, u is unchanged for i >= 128.
+func (u Uint128) ToggleBit(i uint) Uint128 { return u.Xor(Uint128FromUint64(1).Lsh(i)) }
+
+// LshCarry returns u << n and the bits shifted out,
+// that is the high and low halves of the 256-bit u << n.
+func (u Uint128) LshCarry(n uint) (Uint128, Uint128) {
+	if n > 128 {
+		return Uint128{}, u.Lsh(n - 128)
+	}
+	return u.Lsh(n), u.Rsh(128 - n)
+}
+
+// RshCarry returns u >> n and the bits shifted out,
+// that is the high and low halves of the 256-bit (u << 128) >> n.
+func (u Uint128) RshCarry(n uint) (Uint128, Uint128) {
+	if n > 128 {
+		return Uint128{}, u.Rsh(n - 128)
+	}
+	return u.Rsh(n), u.Lsh(128 - n)
+}
+
+// IsPowerOfTwo reports whether u is a power of two, the result is false for u == 0.
+func (u Uint128) IsPowerOfTwo() bool {
+	return !u.IsZero() && u.And(u.Dec()).IsZero()
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than or equal to u
+// and whether it fits into Uint128, the result is 1 for u == 0.
+func (u Uint128) NextPowerOfTwo() (Uint128, bool) {
+	if u.hi == 0 && u.lo <= 1 {
+		return Uint128FromUint64(1), true
+	}
+	n := u.Dec().BitLen()
+	if n == 128 {
+		return Uint128{}, false
+	}
+	return Uint128FromUint64(1).Lsh(uint(n)), true
+}
+
+// RoundUpToMultiple returns the smallest multiple of m greater than or equal to u
+// and whether it fits into Uint128. It panics for m == 0.
+func (u Uint128) RoundUpToMultiple(m Uint128) (Uint128, bool) {
+	r := u.Mod(m)
+	if r.IsZero() {
+		return u, true
+	}
+	sum, over := u.AddOverflow(m.Sub(r))
+	return sum, !over
+}
@@ -0,0 +1,181 @@
+package mathx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestUint128Bits(t *testing.T) {
+	testCases := []struct {
+		u                   Uint128
+		len, lz, tz, popcnt int
+	}{
+		{Uint128{}, 0, 128, 128, 0},
+		{NewUint128(0, 1), 1, 127, 0, 1},
+		{NewUint128(0, 0xf0), 8, 120, 4, 4},
+		{NewUint128(1, 0), 65, 63, 64, 1},
+		{NewUint128(1<<63, 1<<63), 128, 0, 63, 2},
+		{NewUint128(^uint64(0), ^uint64(0)), 128, 0, 0, 128},
+	}
+	for _, tc := range testCases {
+		if got := tc.u.BitLen(); got != tc.len {
+			t.Fatalf("unexpected BitLen(%#x); got %v; want %v", tc.u, got, tc.len)
+		}
+		if got := tc.u.LeadingZeros(); got != tc.lz {
+			t.Fatalf("unexpected LeadingZeros(%#x); got %v; want %v", tc.u, got, tc.lz)
+		}
+		if got := tc.u.TrailingZeros(); got != tc.tz {
+			t.Fatalf("unexpected TrailingZeros(%#x); got %v; want %v", tc.u, got, tc.tz)
+		}
+		if got := tc.u.OnesCount(); got != tc.popcnt {
+			t.Fatalf("unexpected OnesCount(%#x); got %v; want %v", tc.u, got, tc.popcnt)
+		}
+		if got := tc.u.Big().BitLen(); got != tc.len {
+			t.Fatalf("BitLen(%#x) differs from big.Int; got %v; want %v", tc.u, got, tc.len)
+		}
+	}
+}
+
+func TestUint128Rotate(t *testing.T) {
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	for k := -300; k <= 300; k++ {
+		n := uint(k) & 127
+		want := u.Lsh(n).Or(u.Rsh(128 - n))
+		if n == 0 {
+			want = u
+		}
+		if got := u.RotateLeft(k); got != want {
+			t.Fatalf("unexpected RotateLeft(%d); got %#x; want %#x", k, got, want)
+		}
+		if got := u.RotateLeft(k).RotateLeft(-k); got != u {
+			t.Fatalf("rotate by %d is not reverted; got %#x; want %#x", k, got, u)
+		}
+	}
+}
+
+func TestUint128Reverse(t *testing.T) {
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	if got, want := u.ReverseBytes(), NewUint128(0x1032547698badcfe, 0xefcdab8967452301); got != want {
+		t.Fatalf("unexpected ReverseBytes; got %#x; want %#x", got, want)
+	}
+	if got, want := NewUint128(0, 1).Reverse(), NewUint128(1<<63, 0); got != want {
+		t.Fatalf("unexpected Reverse; got %#x; want %#x", got, want)
+	}
+	if got := u.Reverse().Reverse(); got != u {
+		t.Fatalf("double Reverse must be identity; got %#x; want %#x", got, u)
+	}
+	b := u.Bytes()
+	if got := new(Uint128).SetBytesLittleEndian(b[:]); *got != u.ReverseBytes() {
+		t.Fatalf("ReverseBytes must swap byte order; got %#x; want %#x", *got, u.ReverseBytes())
+	}
+}
+
+func TestUint128Shift(t *testing.T) {
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	ub := u.Big()
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	for _, n := range []uint{0, 1, 63, 64, 65, 127, 128, 129, 191, 192, 255, 256, 1000, ^uint(0)} {
+		want := new(big.Int)
+		if n <= 512 {
+			want.Lsh(ub, n)
+		}
+		if got := u.Lsh(n); got.Big().Cmp(new(big.Int).And(want, mask)) != 0 {
+			t.Fatalf("unexpected Lsh(%d); got %#x", n, got)
+		}
+		res, carry := u.LshCarry(n)
+		if res.Big().Cmp(new(big.Int).And(want, mask)) != 0 || carry.Big().Cmp(new(big.Int).And(want.Rsh(want, 128), mask)) != 0 {
+			t.Fatalf("unexpected LshCarry(%d); got %#x, %#x", n, res, carry)
+		}
+
+		wide := new(big.Int).Lsh(ub, 128)
+		if n <= 512 {
+			wide.Rsh(wide, n)
+		} else {
+			wide.SetInt64(0)
+		}
+		if got := u.Rsh(n); got.Big().Cmp(new(big.Int).Rsh(wide, 128)) != 0 {
+			t.Fatalf("unexpected Rsh(%d); got %#x", n, got)
+		}
+		res, carry = u.RshCarry(n)
+		if res.Big().Cmp(new(big.Int).Rsh(wide, 128)) != 0 || carry.Big().Cmp(new(big.Int).And(wide, mask)) != 0 {
+			t.Fatalf("unexpected RshCarry(%d); got %#x, %#x", n, res, carry)
+		}
+	}
+}
+
+func TestUint128PowerOfTwo(t *testing.T) {
+	testCases := []struct {
+		u    Uint128
+		pow  bool
+		next Uint128
+		ok   bool
+	}{
+		{Uint128{}, false, NewUint128(0, 1), true},
+		{NewUint128(0, 1), true, NewUint128(0, 1), true},
+		{NewUint128(0, 3), false, NewUint128(0, 4), true},
+		{NewUint128(0, 1<<63), true, NewUint128(0, 1<<63), true},
+		{NewUint128(0, 1<<63+1), false, NewUint128(1, 0), true},
+		{NewUint128(1, 1), false, NewUint128(2, 0), true},
+		{NewUint128(1<<63, 0), true, NewUint128(1<<63, 0), true},
+		{NewUint128(1<<63, 1), false, Uint128{}, false},
+		{MaxUint128, false, Uint128{}, false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.u.IsPowerOfTwo(); got != tc.pow {
+			t.Fatalf("unexpected IsPowerOfTwo(%#x); got %v; want %v", tc.u, got, tc.pow)
+		}
+		if got, ok := tc.u.NextPowerOfTwo(); got != tc.next || ok != tc.ok {
+			t.Fatalf("unexpected NextPowerOfTwo(%#x); got %#x, %v; want %#x, %v", tc.u, got, ok, tc.next, tc.ok)
+		}
+	}
+}
+
+func TestUint128RoundUpToMultiple(t *testing.T) {
+	testCases := []struct {
+		u, m Uint128
+		want Uint128
+		ok   bool
+	}{
+		{Uint128{}, NewUint128(0, 7), Uint128{}, true},
+		{NewUint128(0, 1), NewUint128(0, 7), NewUint128(0, 7), true},
+		{NewUint128(0, 14), NewUint128(0, 7), NewUint128(0, 14), true},
+		{NewUint128(0, 15), NewUint128(0, 4096), NewUint128(0, 4096), true},
+		{NewUint128(0, 1), NewUint128(1, 0), NewUint128(1, 0), true},
+		{MaxUint128, NewUint128(0, 1), MaxUint128, true},
+		{MaxUint128, NewUint128(0, 2), Uint128{}, false},
+		{MaxUint128.Dec(), MaxUint128, MaxUint128, true},
+	}
+
+	for _, tc := range testCases {
+		if got, ok := tc.u.RoundUpToMultiple(tc.m); got != tc.want || ok != tc.ok {
+			t.Fatalf("unexpected RoundUpToMultiple(%#x, %#x); got %#x, %v; want %#x, %v", tc.u, tc.m, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestUint128BitOps(t *testing.T) {
+	var u Uint128
+	for _, i := range []uint{0, 5, 63, 64, 100, 127} {
+		u = u.SetBit(i)
+		if u.Bit(i) != 1 {
+			t.Fatalf("unexpected Bit(%d) after SetBit; got %v; want 1", i, u.Bit(i))
+		}
+	}
+	if want := NewUint128(1<<63|1<<36|1, 1<<63|1<<5|1); u != want {
+		t.Fatalf("unexpected bits; got %#x; want %#x", u, want)
+	}
+	if u.OnesCount() != 6 || u.Bit(1) != 0 || u.Bit(128) != 0 {
+		t.Fatalf("unexpected bits of %#x", u)
+	}
+
+	if got := u.ClearBit(64).ClearBit(0); got != NewUint128(1<<63|1<<36, 1<<63|1<<5) {
+		t.Fatalf("unexpected ClearBit; got %#x", got)
+	}
+	if got := u.ToggleBit(64).ToggleBit(1); got != NewUint128(1<<63|1<<36, 1<<63|1<<5|1<<1|1) {
+		t.Fatalf("unexpected ToggleBit; got %#x", got)
+	}
+	if u.SetBit(128) != u || u.ClearBit(1000) != u || u.ToggleBit(200) != u {
+		t.Fatalf("out of range bit index must not change the value")
+	}
+}
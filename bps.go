@@ -0,0 +1,94 @@
+package mathx
+
+import "math/bits"
+
+// RoundingMode specifies how an inexact result is rounded.
+type RoundingMode uint8
+
+const (
+	// RoundDown rounds toward zero.
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to nearest, ties away from zero.
+	RoundHalfUp
+	// RoundHalfEven rounds to nearest, ties to even.
+	RoundHalfEven
+)
+
+// BasisPoints is a ratio in units of 1/10000 (0.01%).
+type BasisPoints uint64
+
+// BpsPerUnit is the number of basis points in 100%.
+const BpsPerUnit BasisPoints = 10000
+
+// ApplyBps returns amount * bps / 10000 rounded according to mode
+// and whether the result fits into Uint128.
+func ApplyBps(amount Uint128, bps BasisPoints, mode RoundingMode) (Uint128, bool) {
+	p, c := amount.mulAdd64(uint64(bps), 0)
+	if c >= uint64(BpsPerUnit) {
+		return Uint128{}, false
+	}
+
+	var q Uint128
+	var r uint64
+	q.hi, r = bits.Div64(c, p.hi, uint64(BpsPerUnit))
+	q.lo, r = bits.Div64(r, p.lo, uint64(BpsPerUnit))
+	if !mode.roundUp(r, uint64(BpsPerUnit), q.lo) {
+		return q, true
+	}
+	q = q.Inc()
+	return q, !q.IsZero()
+}
+
+// ApplyBps256 returns amount * bps / 10000 rounded according to mode
+// and whether the result fits into Uint256.
+func ApplyBps256(amount Uint256, bps BasisPoints, mode RoundingMode) (Uint256, bool) {
+	p, c := amount.mulAdd64(uint64(bps), 0)
+	if c >= uint64(BpsPerUnit) {
+		return Uint256{}, false
+	}
+
+	var q Uint256
+	var r uint64
+	q.hi.hi, r = bits.Div64(c, p.hi.hi, uint64(BpsPerUnit))
+	q.hi.lo, r = bits.Div64(r, p.hi.lo, uint64(BpsPerUnit))
+	q.lo.hi, r = bits.Div64(r, p.lo.hi, uint64(BpsPerUnit))
+	q.lo.lo, r = bits.Div64(r, p.lo.lo, uint64(BpsPerUnit))
+	if !mode.roundUp(r, uint64(BpsPerUnit), q.lo.lo) {
+		return q, true
+	}
+	q = q.Inc()
+	return q, !q.IsZero()
+}
+
+// roundUp reports whether a quotient with the lowest limb q and
+// remainder r of a division by d must be incremented.
+func (m RoundingMode) roundUp(r, d, q uint64) bool {
+	half := 0
+	switch {
+	case r < d-r:
+		half = -1
+	case r > d-r:
+		half = 1
+	}
+	return m.roundUpCmp(r != 0, half, q&1 == 1)
+}
+
+// roundUpCmp is roundUp for any width, given whether the remainder r is non-zero,
+// the comparison of r with d-r and whether the quotient is odd.
+func (m RoundingMode) roundUpCmp(nonZero bool, half int, odd bool) bool {
+	if !nonZero {
+		return false
+	}
+	switch m {
+	case RoundUp:
+		return true
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfEven:
+		return half > 0 || (half == 0 && odd)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,57 @@
+package mathx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestApplyBps(t *testing.T) {
+	testCases := []struct {
+		amount uint64
+		bps    BasisPoints
+		mode   RoundingMode
+		want   uint64
+	}{
+		{10000, 30, RoundDown, 30},
+		{12345, 30, RoundDown, 37},
+		{12345, 30, RoundUp, 38},
+		{12345, 30, RoundHalfUp, 37},
+		{5000, 1, RoundHalfUp, 1},
+		{5000, 1, RoundHalfEven, 0},
+		{15000, 1, RoundHalfEven, 2},
+		{15001, 1, RoundHalfEven, 2},
+		{100, 0, RoundUp, 0},
+		{100, 25000, RoundDown, 250},
+	}
+
+	for _, tc := range testCases {
+		got, ok := ApplyBps(Uint128FromUint64(tc.amount), tc.bps, tc.mode)
+		if !ok || got != Uint128FromUint64(tc.want) {
+			t.Fatalf("unexpected ApplyBps(%d, %d, %d); got %v; want %d", tc.amount, tc.bps, tc.mode, got, tc.want)
+		}
+		got256, ok := ApplyBps256(Uint256FromUint64(tc.amount), tc.bps, tc.mode)
+		if !ok || got256 != Uint256FromUint64(tc.want) {
+			t.Fatalf("unexpected ApplyBps256(%d, %d, %d); got %v; want %d", tc.amount, tc.bps, tc.mode, got256, tc.want)
+		}
+	}
+}
+
+func TestApplyBpsLarge(t *testing.T) {
+	max := MaxUint128
+	got, ok := ApplyBps(max, 9999, RoundDown)
+	want := new(big.Int).Mul(max.Big(), big.NewInt(9999))
+	want.Quo(want, big.NewInt(10000))
+	if !ok || got.Big().Cmp(want) != 0 {
+		t.Fatalf("unexpected ApplyBps; got %v; want %v", got, want)
+	}
+
+	if _, ok := ApplyBps(max, BpsPerUnit, RoundDown); !ok {
+		t.Fatal("100% of max must fit")
+	}
+	if _, ok := ApplyBps(max, BpsPerUnit+1, RoundDown); ok {
+		t.Fatal("must overflow")
+	}
+	if _, ok := ApplyBps256(NewUint256(max, max), BpsPerUnit+1, RoundDown); ok {
+		t.Fatal("must overflow")
+	}
+}
@@ -0,0 +1,119 @@
+package mathx
+
+// SumUint128s returns the sum of a and the carry out of 128 bits.
+// The exact sum equals carry * 2**128 + sum.
+func SumUint128s(a []Uint128) (sum Uint128, carry uint64) {
+	var c uint64
+	for _, v := range a {
+		sum, c = sum.AddCarry(v, 0)
+		carry += c
+	}
+	return sum, carry
+}
+
+// AddUint128s sets dst[i] = x[i] + y[i] for each element of dst
+// and returns the number of elements which wrapped around.
+// It panics if x or y is shorter than dst.
+func AddUint128s(dst, x, y []Uint128) (wrapped int) {
+	x = x[:len(dst)]
+	y = y[:len(dst)]
+	for i := range dst {
+		var c uint64
+		dst[i], c = x[i].AddCarry(y[i], 0)
+		wrapped += int(c)
+	}
+	return wrapped
+}
+
+// SubUint128s sets dst[i] = x[i] - y[i] for each element of dst
+// and returns the number of elements which wrapped around.
+// It panics if x or y is shorter than dst.
+func SubUint128s(dst, x, y []Uint128) (wrapped int) {
+	x = x[:len(dst)]
+	y = y[:len(dst)]
+	for i := range dst {
+		var b uint64
+		dst[i], b = x[i].SubBorrow(y[i], 0)
+		wrapped += int(b)
+	}
+	return wrapped
+}
+
+// SumUint256s returns the sum of a and the carry out of 256 bits.
+// The exact sum equals carry * 2**256 + sum.
+func SumUint256s(a []Uint256) (sum Uint256, carry uint64) {
+	var c uint64
+	for _, v := range a {
+		sum, c = sum.AddCarry(v, 0)
+		carry += c
+	}
+	return sum, carry
+}
+
+// AddUint256s sets dst[i] = x[i] + y[i] for each element of dst
+// and returns the number of elements which wrapped around.
+// It panics if x or y is shorter than dst.
+func AddUint256s(dst, x, y []Uint256) (wrapped int) {
+	x = x[:len(dst)]
+	y = y[:len(dst)]
+	for i := range dst {
+		var c uint64
+		dst[i], c = x[i].AddCarry(y[i], 0)
+		wrapped += int(c)
+	}
+	return wrapped
+}
+
+// SubUint256s sets dst[i] = x[i] - y[i] for each element of dst
+// and returns the number of elements which wrapped around.
+// It panics if x or y is shorter than dst.
+func SubUint256s(dst, x, y []Uint256) (wrapped int) {
+	x = x[:len(dst)]
+	y = y[:len(dst)]
+	for i := range dst {
+		var b uint64
+		dst[i], b = x[i].SubBorrow(y[i], 0)
+		wrapped += int(b)
+	}
+	return wrapped
+}
+
+// SumFloat64s returns the sum of a.
+// The loop is unrolled into 4 independent accumulators which breaks
+// the dependency chain of additions, so the order of rounding differs
+// from a plain loop.
+func SumFloat64s(a []float64) float64 {
+	var s0, s1, s2, s3 float64
+	for ; len(a) >= 4; a = a[4:] {
+		s0 += a[0]
+		s1 += a[1]
+		s2 += a[2]
+		s3 += a[3]
+	}
+	for _, v := range a {
+		s0 += v
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// MinMaxFloat64s returns the minimum and maximum of a.
+// NaN values are skipped unless a[0] is NaN.
+// It returns (+Inf, -Inf) for an empty slice.
+//
+// Unlike SumFloat64s the loop is not unrolled: after the first values
+// the comparisons are well predicted and independent lanes only add work.
+func MinMaxFloat64s(a []float64) (min, max float64) {
+	if len(a) == 0 {
+		return InfPos, InfNeg
+	}
+	min, max = a[0], a[0]
+	for _, v := range a[1:] {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
@@ -0,0 +1,130 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSumUint128s(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+
+	sum, carry := SumUint128s([]Uint128{max, max, max, Uint128FromUint64(5)})
+	if carry != 3 || sum != Uint128FromUint64(2) {
+		t.Fatalf("unexpected sum; got %v carry %v; want %v carry %v", sum, carry, 2, 3)
+	}
+
+	sum, carry = SumUint128s(nil)
+	if carry != 0 || !sum.IsZero() {
+		t.Fatalf("unexpected sum of empty slice; got %v carry %v", sum, carry)
+	}
+}
+
+func TestAddSubUint128s(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	x := []Uint128{max, Uint128FromUint64(1), NewUint128(1, 0)}
+	y := []Uint128{Uint128FromUint64(1), Uint128FromUint64(2), Uint128FromUint64(1)}
+	dst := make([]Uint128, 3)
+
+	if wrapped := AddUint128s(dst, x, y); wrapped != 1 {
+		t.Fatalf("unexpected wrapped count; got %v; want %v", wrapped, 1)
+	}
+	want := []Uint128{{}, Uint128FromUint64(3), NewUint128(1, 1)}
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Fatalf("unexpected sum at %d; got %v; want %v", i, dst[i], want[i])
+		}
+	}
+
+	if wrapped := SubUint128s(dst, dst, y); wrapped != 1 {
+		t.Fatalf("unexpected wrapped count; got %v; want %v", wrapped, 1)
+	}
+	for i := range x {
+		if dst[i] != x[i] {
+			t.Fatalf("unexpected difference at %d; got %v; want %v", i, dst[i], x[i])
+		}
+	}
+}
+
+func TestSumUint256s(t *testing.T) {
+	max := NewUint256(NewUint128(math.MaxUint64, math.MaxUint64), NewUint128(math.MaxUint64, math.MaxUint64))
+	a := []Uint256{max, Uint256FromUint64(2)}
+
+	sum, carry := SumUint256s(a)
+	if carry != 1 || sum != Uint256FromUint64(1) {
+		t.Fatalf("unexpected sum; got %v carry %v; want %v carry %v", sum, carry, 1, 1)
+	}
+
+	dst := make([]Uint256, 2)
+	if wrapped := AddUint256s(dst, a, a); wrapped != 1 {
+		t.Fatalf("unexpected wrapped count; got %v; want %v", wrapped, 1)
+	}
+	if wrapped := SubUint256s(dst, dst, a); wrapped != 1 || dst[0] != max || dst[1] != a[1] {
+		t.Fatalf("unexpected difference; got %v (wrapped %v)", dst, wrapped)
+	}
+}
+
+func TestSumFloat64s(t *testing.T) {
+	for n := 0; n < 20; n++ {
+		a := make([]float64, n)
+		var want float64
+		for i := range a {
+			a[i] = float64(i + 1)
+			want += a[i]
+		}
+		if got := SumFloat64s(a); got != want {
+			t.Fatalf("unexpected sum of %d values; got %v; want %v", n, got, want)
+		}
+	}
+}
+
+func TestMinMaxFloat64s(t *testing.T) {
+	if min, max := MinMaxFloat64s(nil); min != InfPos || max != InfNeg {
+		t.Fatalf("unexpected min/max of empty; got %v, %v", min, max)
+	}
+	for n := 2; n < 20; n++ {
+		for pos := 0; pos < n; pos++ {
+			a := make([]float64, n)
+			a[pos], a[(pos+1)%n] = -1, 1
+			if min, max := MinMaxFloat64s(a); min != -1 || max != 1 {
+				t.Fatalf("unexpected min/max of %v; got %v, %v", a, min, max)
+			}
+		}
+	}
+	if min, max := MinMaxFloat64s([]float64{2, NaN, 1, 3, NaN, 0}); min != 0 || max != 3 {
+		t.Fatalf("NaN must be skipped; got %v, %v", min, max)
+	}
+}
+
+var benchFloats = func() []float64 {
+	a := make([]float64, 1000)
+	for i := range a {
+		a[i] = float64(i*7919%1000) - 500
+	}
+	return a
+}()
+
+var sinkFloat float64
+
+func BenchmarkSumFloat64s(b *testing.B) {
+	b.Run("unrolled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sinkFloat = SumFloat64s(benchFloats)
+		}
+	})
+	b.Run("loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var sum float64
+			for _, v := range benchFloats {
+				sum += v
+			}
+			sinkFloat = sum
+		}
+	})
+}
+
+func BenchmarkMinMaxFloat64s(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		min, max := MinMaxFloat64s(benchFloats)
+		sinkFloat = min + max
+	}
+}
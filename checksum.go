@@ -0,0 +1,43 @@
+package mathx
+
+// Mod97 returns u mod 97 as used by ISO 7064 MOD 97-10 (IBAN).
+// A numeric IBAN representation is valid when Mod97 returns 1.
+func (u Uint128) Mod97() uint64 {
+	_, r := u.QuoRem64(97)
+	return r
+}
+
+// LuhnCheck reports whether the decimal digits of u pass the Luhn check,
+// the last digit being the check digit.
+func (u Uint128) LuhnCheck() bool {
+	return luhnSum(u, false)%10 == 0
+}
+
+// LuhnCheckDigit returns the Luhn check digit to append to the decimal digits of u.
+func (u Uint128) LuhnCheckDigit() uint8 {
+	return uint8((10 - luhnSum(u, true)%10) % 10)
+}
+
+// luhnSum returns the Luhn sum of decimal digits of u.
+// When double is true the last digit is doubled.
+func luhnSum(u Uint128, double bool) uint64 {
+	var sum uint64
+	for !u.IsZero() {
+		var r uint64
+		u, r = u.QuoRem64(1e19)
+		// Leading zeros of the last chunk add nothing.
+		for i := 0; i < 19; i++ {
+			d := r % 10
+			r /= 10
+			if double {
+				d *= 2
+				if d > 9 {
+					d -= 9
+				}
+			}
+			sum += d
+			double = !double
+		}
+	}
+	return sum
+}
@@ -0,0 +1,45 @@
+package mathx
+
+import "testing"
+
+func TestMod97(t *testing.T) {
+	// GB82 WEST 1234 5698 7654 32 in numeric form.
+	var u Uint128
+	if err := u.ParseNumeric([]byte("3214282912345698765432161182")); err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Mod97(); got != 1 {
+		t.Fatalf("unexpected Mod97; got %v; want %v", got, 1)
+	}
+	if got := Uint128FromUint64(196).Mod97(); got != 2 {
+		t.Fatalf("unexpected Mod97; got %v; want %v", got, 2)
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	testCases := []struct {
+		v     string
+		valid bool
+	}{
+		{"79927398713", true},
+		{"79927398710", false},
+		{"4111111111111111", true},
+		{"4111111111111112", false},
+		{"1234567812345670", true},
+		{"12345678901234567890123456789012345675", true},
+		{"0", true},
+	}
+	for _, tc := range testCases {
+		var u Uint128
+		if err := u.ParseNumeric([]byte(tc.v)); err != nil {
+			t.Fatal(err)
+		}
+		if got := u.LuhnCheck(); got != tc.valid {
+			t.Fatalf("unexpected LuhnCheck(%s); got %v; want %v", tc.v, got, tc.valid)
+		}
+	}
+
+	if got := Uint128FromUint64(7992739871).LuhnCheckDigit(); got != 3 {
+		t.Fatalf("unexpected LuhnCheckDigit; got %v; want %v", got, 3)
+	}
+}
@@ -0,0 +1,89 @@
+package mathx
+
+import (
+	"testing"
+
+	"github.com/valyala/fastrand"
+)
+
+func TestCmp(t *testing.T) {
+	edge := []uint64{0, 1, 1<<63 - 1, 1 << 63, 1<<64 - 1}
+	var vs []Uint128
+	for _, hi := range edge {
+		for _, lo := range edge {
+			vs = append(vs, NewUint128(hi, lo))
+		}
+	}
+
+	for _, a := range vs {
+		for _, b := range vs {
+			want := a.Big().Cmp(b.Big())
+			if got := a.Cmp(b); got != want {
+				t.Fatalf("unexpected %v.Cmp(%v); got %v; want %v", a, b, got, want)
+			}
+
+			a256, b256 := NewUint256(a, b), NewUint256(b, a)
+			want = a256.Big().Cmp(b256.Big())
+			if got := a256.Cmp(b256); got != want {
+				t.Fatalf("unexpected %v.Cmp(%v); got %v; want %v", a256, b256, got, want)
+			}
+		}
+	}
+
+	if !(Uint256{}).IsZero() || NewUint256(Uint128{}, Uint128FromUint64(1)).IsZero() {
+		t.Fatal("unexpected Uint256.IsZero")
+	}
+}
+
+func BenchmarkUint128Cmp(b *testing.B) {
+	vs := randUint128s(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var sink int
+	for i := 0; i < b.N; i++ {
+		sink += vs[i&1023].Cmp(vs[(i+1)&1023])
+	}
+	_ = sink
+}
+
+func BenchmarkUint256Cmp(b *testing.B) {
+	xs := randUint128s(2048)
+	vs := make([]Uint256, 1024)
+	for i := range vs {
+		vs[i] = NewUint256(xs[2*i], xs[2*i+1])
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var sink int
+	for i := 0; i < b.N; i++ {
+		sink += vs[i&1023].Cmp(vs[(i+1)&1023])
+	}
+	_ = sink
+}
+
+func BenchmarkSortUint128s(b *testing.B) {
+	vs := randUint128s(1024)
+	tmp := make([]Uint128, len(vs))
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		copy(tmp, vs)
+		SortUint128s(tmp)
+	}
+}
+
+func randUint128s(n int) []Uint128 {
+	var r fastrand.RNG
+	r.Seed(1)
+	vs := make([]Uint128, n)
+	for i := range vs {
+		// Share high limbs often to exercise both comparison paths.
+		hi := uint64(r.Uint32n(4))
+		lo := uint64(r.Uint32())<<32 | uint64(r.Uint32())
+		vs[i] = NewUint128(hi, lo)
+	}
+	return vs
+}
@@ -0,0 +1,53 @@
+package mathx
+
+import "strconv"
+
+// ColumnError records a failed conversion of a column value.
+type ColumnError struct {
+	Row int   // index of the failed value
+	Err error // *SyntaxError or ErrOverflow
+}
+
+func (e *ColumnError) Error() string {
+	return "mathx: row " + strconv.Itoa(e.Row) + ": " + e.Err.Error()
+}
+
+func (e *ColumnError) Unwrap() error { return e.Err }
+
+// ParseUint128Column appends values of the decimal rows to dst.
+// Rows must contain only digits, without sign, spaces or a fractional part.
+// It does not allocate besides growing dst.
+//
+// On failure it returns the values parsed so far and a *ColumnError
+// with the index of the invalid row.
+func ParseUint128Column(dst []Uint128, rows [][]byte) ([]Uint128, error) {
+	for i, row := range rows {
+		v, err := parseDecimalUint128(row)
+		if err != nil {
+			return dst, &ColumnError{Row: i, Err: err}
+		}
+		dst = append(dst, v)
+	}
+	return dst, nil
+}
+
+// AppendUint128Column appends values of vs in decimal to dst,
+// each one followed by sep, e.g. '\n'.
+func AppendUint128Column(dst []byte, vs []Uint128, sep byte) []byte {
+	for _, v := range vs {
+		dst = append(v.AppendNumeric(dst), sep)
+	}
+	return dst
+}
+
+func parseDecimalUint128(b []byte) (Uint128, error) {
+	if len(b) == 0 {
+		return Uint128{}, &SyntaxError{Offset: 0}
+	}
+	for i, c := range b {
+		if c < '0' || c > '9' {
+			return Uint128{}, &SyntaxError{Offset: i}
+		}
+	}
+	return decimalUint128(b)
+}
@@ -0,0 +1,78 @@
+package mathx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestParseUint128Column(t *testing.T) {
+	rows := [][]byte{
+		[]byte("0"),
+		[]byte("42"),
+		[]byte("340282366920938463463374607431768211455"),
+		[]byte("18446744073709551616"),
+	}
+	got, err := ParseUint128Column(nil, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Uint128{{}, {lo: 42}, MaxUint128, {hi: 1}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected value %d; got %v; want %v", i, got[i], want[i])
+		}
+	}
+
+	out := AppendUint128Column(nil, got, '\n')
+	if want := bytes.Join(rows, []byte("\n")); !bytes.Equal(out, append(want, '\n')) {
+		t.Fatalf("unexpected formatted column; got %q", out)
+	}
+
+	buf := make([]Uint128, 0, len(rows))
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = ParseUint128Column(buf[:0], rows)
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected allocations; got %v; want 0", allocs)
+	}
+}
+
+func TestParseUint128ColumnError(t *testing.T) {
+	testCases := []struct {
+		row string
+		err error
+	}{
+		{"", ErrSyntax},
+		{"-1", ErrSyntax},
+		{"1.0", ErrSyntax},
+		{" 1", ErrSyntax},
+		{"340282366920938463463374607431768211456", ErrOverflow},
+	}
+	for _, tc := range testCases {
+		got, err := ParseUint128Column(nil, [][]byte{[]byte("7"), []byte(tc.row)})
+		var colErr *ColumnError
+		if !errors.As(err, &colErr) || colErr.Row != 1 || !errors.Is(err, tc.err) {
+			t.Fatalf("unexpected error for %q; got %v; want row 1: %v", tc.row, err, tc.err)
+		}
+		if len(got) != 1 || got[0] != NewUint128(0, 7) {
+			t.Fatalf("unexpected values parsed before the error; got %v", got)
+		}
+	}
+	if msg := (&ColumnError{Row: 3, Err: ErrSyntax}).Error(); msg != "mathx: row 3: mathx: invalid syntax" {
+		t.Fatalf("unexpected message; got %q", msg)
+	}
+}
+
+func BenchmarkParseUint128Column(b *testing.B) {
+	rows := make([][]byte, 1000)
+	for i := range rows {
+		rows[i] = NewUint128(uint64(i)*7919, uint64(i)*104729).AppendNumeric(nil)
+	}
+	buf := make([]Uint128, 0, len(rows))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = ParseUint128Column(buf[:0], rows)
+	}
+}
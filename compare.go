@@ -0,0 +1,75 @@
+package mathx
+
+import (
+	"math"
+	"sort"
+)
+
+// KSDistance returns the two-sample Kolmogorov–Smirnov statistic,
+// the maximum distance between empirical distributions of a and b.
+// The result is in [0, 1] or NaN if any histogram is empty.
+func KSDistance(a, b *Histogram) float64 {
+	xs, ys := sortedSamples(a), sortedSamples(b)
+	if len(xs) == 0 || len(ys) == 0 {
+		return NaN
+	}
+
+	nx, ny := float64(len(xs)), float64(len(ys))
+	var d float64
+	i, j := 0, 0
+	for i < len(xs) && j < len(ys) {
+		v := math.Min(xs[i], ys[j])
+		for i < len(xs) && xs[i] <= v {
+			i++
+		}
+		for j < len(ys) && ys[j] <= v {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/nx-float64(j)/ny))
+	}
+	return d
+}
+
+// PopulationStabilityIndex returns PSI of actual relative to expected.
+// Bucket bounds are taken from the quantiles of expected so each bucket
+// holds roughly the same share of expected values.
+// Empty buckets are smoothed to avoid infinite terms.
+// The result is NaN if any histogram is empty.
+//
+// Common rule of thumb: below 0.1 is no shift, above 0.25 is a major shift.
+func PopulationStabilityIndex(expected, actual *Histogram, buckets int) float64 {
+	if buckets <= 0 {
+		panic("mathx: buckets must be positive")
+	}
+	xs, ys := sortedSamples(expected), sortedSamples(actual)
+	if len(xs) == 0 || len(ys) == 0 {
+		return NaN
+	}
+
+	bounds := make([]float64, buckets-1)
+	for i := range bounds {
+		bounds[i] = xs[(i+1)*len(xs)/buckets]
+	}
+
+	const eps = 1e-4
+	var psi float64
+	pi, pj := 0, 0
+	for b := 0; b < buckets; b++ {
+		i, j := len(xs), len(ys)
+		if b < len(bounds) {
+			i = sort.SearchFloat64s(xs, bounds[b])
+			j = sort.SearchFloat64s(ys, bounds[b])
+		}
+		e := math.Max(float64(i-pi)/float64(len(xs)), eps)
+		a := math.Max(float64(j-pj)/float64(len(ys)), eps)
+		psi += (a - e) * math.Log(a/e)
+		pi, pj = i, j
+	}
+	return psi
+}
+
+func sortedSamples(h *Histogram) []float64 {
+	xs := append([]float64(nil), h.res.vals...)
+	sort.Float64s(xs)
+	return xs
+}
@@ -0,0 +1,41 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestKSDistance(t *testing.T) {
+	a, b, c := NewHistogram(), NewHistogram(), NewHistogram()
+	for i := 0; i < 1000; i++ {
+		a.Update(float64(i))
+		b.Update(float64(i))
+		c.Update(float64(i) + 500)
+	}
+
+	if d := KSDistance(a, b); d != 0 {
+		t.Fatalf("unexpected KSDistance; got %v; want %v", d, 0)
+	}
+	if d := KSDistance(a, c); math.Abs(d-0.5) > 0.01 {
+		t.Fatalf("unexpected KSDistance; got %v; want %v", d, 0.5)
+	}
+	if d := KSDistance(a, NewHistogram()); !math.IsNaN(d) {
+		t.Fatalf("unexpected KSDistance; got %v; want NaN", d)
+	}
+}
+
+func TestPopulationStabilityIndex(t *testing.T) {
+	a, b, c := NewHistogram(), NewHistogram(), NewHistogram()
+	for i := 0; i < 1000; i++ {
+		a.Update(float64(i))
+		b.Update(float64(i))
+		c.Update(float64(i) * 2)
+	}
+
+	if psi := PopulationStabilityIndex(a, b, 10); psi != 0 {
+		t.Fatalf("unexpected PSI; got %v; want %v", psi, 0)
+	}
+	if psi := PopulationStabilityIndex(a, c, 10); psi < 0.25 {
+		t.Fatalf("unexpected PSI; got %v; want major shift", psi)
+	}
+}
@@ -0,0 +1,80 @@
+package mathx
+
+import (
+	"math"
+	"math/bits"
+	"unsafe"
+)
+
+// Signed is a constraint for signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint for unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is a constraint for integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is a constraint for floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint for integer and floating-point types.
+type Number interface {
+	Integer | Float
+}
+
+// Convert returns To(v) and whether v was converted exactly,
+// without wrapping, truncation or rounding.
+// Out of range conversions from floating-point to integer types,
+// which are implementation-defined in Go, give 0.
+// NaN and infinities convert exactly only between floating-point types.
+func Convert[To, From Number](v From) (To, bool) {
+	toFloat, toSigned, toBits := numberKind[To]()
+	fromFloat, _, _ := numberKind[From]()
+
+	switch {
+	case fromFloat && toFloat:
+		t := To(v)
+		return t, From(t) == v || v != v
+
+	case fromFloat:
+		f := float64(v)
+		lo, hi := 0.0, math.Ldexp(1, toBits)
+		if toSigned {
+			lo, hi = -math.Ldexp(1, toBits-1), math.Ldexp(1, toBits-1)
+		}
+		if !(f >= lo && f < hi) {
+			return 0, false
+		}
+		return To(v), f == math.Trunc(f)
+
+	case toFloat:
+		mag := uint64(v)
+		if v < 0 {
+			mag = -uint64(int64(v))
+		}
+		mant := 53
+		if toBits == 32 {
+			mant = 24
+		}
+		return To(v), mag == 0 || bits.Len64(mag>>bits.TrailingZeros64(mag)) <= mant
+
+	default:
+		t := To(v)
+		return t, From(t) == v && (t < 0) == (v < 0)
+	}
+}
+
+// numberKind reports whether T is a floating-point type, whether it is signed, and its size in bits.
+func numberKind[T Number]() (isFloat, signed bool, size int) {
+	var one, zero T = 1, 0
+	return one/2 != 0, zero-one < 0, 8 * int(unsafe.Sizeof(one))
+}
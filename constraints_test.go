@@ -0,0 +1,58 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvertNumber(t *testing.T) {
+	check := func(name string, got interface{}, ok, wantOK bool) {
+		t.Helper()
+		if ok != wantOK {
+			t.Fatalf("unexpected %s; got %v, %v; want ok %v", name, got, ok, wantOK)
+		}
+	}
+
+	v8, ok := Convert[int8](int64(-128))
+	check("int64 -128 to int8", v8, ok, v8 == -128)
+	v8, ok = Convert[int8](int64(128))
+	check("int64 128 to int8", v8, ok, false)
+	if v8 != -128 {
+		t.Fatalf("inexact integer conversion must wrap; got %v", v8)
+	}
+	u8, ok := Convert[uint8](int8(-1))
+	check("int8 -1 to uint8", u8, ok, false)
+	i8, ok := Convert[int8](uint8(255))
+	check("uint8 255 to int8", i8, ok, false)
+	u64, ok := Convert[uint64](int64(math.MaxInt64))
+	check("int64 max to uint64", u64, ok, u64 == math.MaxInt64)
+
+	f64, ok := Convert[float64](int64(1<<53 + 1))
+	check("2^53+1 to float64", f64, ok, false)
+	f64, ok = Convert[float64](int64(math.MinInt64))
+	check("int64 min to float64", f64, ok, f64 == -1<<63)
+	f64, ok = Convert[float64](uint64(math.MaxUint64))
+	check("uint64 max to float64", f64, ok, false)
+	f32, ok := Convert[float32](int32(1<<24 + 1))
+	check("2^24+1 to float32", f32, ok, false)
+	f32, ok = Convert[float32](int32(1<<24 + 2))
+	check("2^24+2 to float32", f32, ok, f32 == 1<<24+2)
+
+	i64, ok := Convert[int64](float64(1 << 63))
+	check("2^63 to int64", i64, ok, false)
+	i64, ok = Convert[int64](float64(-1 << 63))
+	check("-2^63 to int64", i64, ok, i64 == math.MinInt64)
+	u16, ok := Convert[uint16](float32(65535))
+	check("65535 to uint16", u16, ok, u16 == 65535)
+	u16, ok = Convert[uint16](2.5)
+	check("2.5 to uint16", u16, ok, false)
+	u16, ok = Convert[uint16](NaN)
+	check("NaN to uint16", u16, ok, false)
+
+	f32, ok = Convert[float32](0.1)
+	check("0.1 to float32", f32, ok, false)
+	f32, ok = Convert[float32](InfNeg)
+	check("-Inf to float32", f32, ok, math.IsInf(float64(f32), -1))
+	f32, ok = Convert[float32](NaN)
+	check("NaN to float32", f32, ok, f32 != f32)
+}
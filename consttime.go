@@ -0,0 +1,71 @@
+package mathx
+
+import "math/bits"
+
+// CmpCT is like Cmp but takes constant time without data-dependent branches.
+func (u Uint128) CmpCT(x Uint128) int {
+	_, lt := u.SubBorrow(x, 0)
+	_, gt := x.SubBorrow(u, 0)
+	return int(gt) - int(lt)
+}
+
+// EqualCT returns 1 if u == x and 0 otherwise.
+// It takes constant time without data-dependent branches.
+func (u Uint128) EqualCT(x Uint128) int {
+	return eqZeroCT((u.hi ^ x.hi) | (u.lo ^ x.lo))
+}
+
+// LessCT returns 1 if u < x and 0 otherwise.
+// It takes constant time without data-dependent branches.
+func (u Uint128) LessCT(x Uint128) int {
+	_, lt := u.SubBorrow(x, 0)
+	return int(lt)
+}
+
+// SelectUint128 returns a if cond is 1 and b if cond is 0.
+// It takes constant time without data-dependent branches.
+// The behavior is undefined if cond takes any other value.
+func SelectUint128(cond int, a, b Uint128) Uint128 {
+	m := -uint64(cond)
+	return Uint128{
+		hi: b.hi ^ (m & (a.hi ^ b.hi)),
+		lo: b.lo ^ (m & (a.lo ^ b.lo)),
+	}
+}
+
+// CmpCT is like Cmp but takes constant time without data-dependent branches.
+func (u Uint256) CmpCT(x Uint256) int {
+	_, lt := u.SubBorrow(x, 0)
+	_, gt := x.SubBorrow(u, 0)
+	return int(gt) - int(lt)
+}
+
+// EqualCT returns 1 if u == x and 0 otherwise.
+// It takes constant time without data-dependent branches.
+func (u Uint256) EqualCT(x Uint256) int {
+	v := (u.hi.hi ^ x.hi.hi) | (u.hi.lo ^ x.hi.lo) | (u.lo.hi ^ x.lo.hi) | (u.lo.lo ^ x.lo.lo)
+	return eqZeroCT(v)
+}
+
+// LessCT returns 1 if u < x and 0 otherwise.
+// It takes constant time without data-dependent branches.
+func (u Uint256) LessCT(x Uint256) int {
+	_, lt := u.SubBorrow(x, 0)
+	return int(lt)
+}
+
+// SelectUint256 returns a if cond is 1 and b if cond is 0.
+// It takes constant time without data-dependent branches.
+// The behavior is undefined if cond takes any other value.
+func SelectUint256(cond int, a, b Uint256) Uint256 {
+	return Uint256{
+		hi: SelectUint128(cond, a.hi, b.hi),
+		lo: SelectUint128(cond, a.lo, b.lo),
+	}
+}
+
+// eqZeroCT returns 1 if v == 0 and 0 otherwise.
+func eqZeroCT(v uint64) int {
+	_, borrow := bits.Sub64(v, 1, 0)
+	return int(borrow)
+}
@@ -0,0 +1,72 @@
+package mathx
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestUint128ConstantTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a := NewUint128(rng.Uint64()%3, rng.Uint64()%3)
+		b := NewUint128(rng.Uint64()%3, rng.Uint64()%3)
+
+		if got, want := a.CmpCT(b), a.Cmp(b); got != want {
+			t.Fatalf("unexpected CmpCT(%v, %v); got %v; want %v", a, b, got, want)
+		}
+
+		want := 0
+		if a == b {
+			want = 1
+		}
+		if got := a.EqualCT(b); got != want {
+			t.Fatalf("unexpected EqualCT(%v, %v); got %v; want %v", a, b, got, want)
+		}
+
+		want = 0
+		if a.Cmp(b) < 0 {
+			want = 1
+		}
+		if got := a.LessCT(b); got != want {
+			t.Fatalf("unexpected LessCT(%v, %v); got %v; want %v", a, b, got, want)
+		}
+	}
+
+	a, b := NewUint128(1, 2), NewUint128(3, 4)
+	if SelectUint128(1, a, b) != a || SelectUint128(0, a, b) != b {
+		t.Fatal("unexpected SelectUint128 result")
+	}
+}
+
+func TestUint256ConstantTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a := NewUint256(NewUint128(0, rng.Uint64()%3), NewUint128(rng.Uint64()%3, rng.Uint64()%3))
+		b := NewUint256(NewUint128(0, rng.Uint64()%3), NewUint128(rng.Uint64()%3, rng.Uint64()%3))
+
+		if got, want := a.CmpCT(b), a.Cmp(b); got != want {
+			t.Fatalf("unexpected CmpCT(%v, %v); got %v; want %v", a, b, got, want)
+		}
+
+		want := 0
+		if a == b {
+			want = 1
+		}
+		if got := a.EqualCT(b); got != want {
+			t.Fatalf("unexpected EqualCT(%v, %v); got %v; want %v", a, b, got, want)
+		}
+
+		want = 0
+		if a.Cmp(b) < 0 {
+			want = 1
+		}
+		if got := a.LessCT(b); got != want {
+			t.Fatalf("unexpected LessCT(%v, %v); got %v; want %v", a, b, got, want)
+		}
+	}
+
+	a, b := Uint256FromUint64(1), Uint256FromUint64(2)
+	if SelectUint256(1, a, b) != a || SelectUint256(0, a, b) != b {
+		t.Fatal("unexpected SelectUint256 result")
+	}
+}
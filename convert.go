@@ -0,0 +1,235 @@
+package mathx
+
+import "math"
+
+// Conversions between wide integer types.
+//
+// Checked conversions return the value truncated to the target width
+// (like Go conversions between integer types) and whether the value
+// was converted exactly. Saturating conversions clamp the value
+// to the range of the target type. Lossless conversions have no checked form.
+
+var (
+	// MaxUint128 is the largest Uint128 value, 2^128-1.
+	MaxUint128 = Uint128{hi: math.MaxUint64, lo: math.MaxUint64}
+	// MaxUint256 is the largest Uint256 value, 2^256-1.
+	MaxUint256 = Uint256{hi: MaxUint128, lo: MaxUint128}
+
+	maxInt128 = Int128{u: Uint128{hi: math.MaxInt64, lo: math.MaxUint64}}
+	minInt128 = Int128{u: Uint128{hi: 1 << 63}}
+	maxInt256 = Int256{u: Uint256{hi: maxInt128.u, lo: MaxUint128}}
+)
+
+// Uint128FromInt64 returns v as Uint128 and whether v is non-negative.
+func Uint128FromInt64(v int64) (Uint128, bool) {
+	return Uint128{hi: uint64(v >> 63), lo: uint64(v)}, v >= 0
+}
+
+// Uint128FromInt64Sat returns v as Uint128 or 0 for negative v.
+func Uint128FromInt64Sat(v int64) Uint128 {
+	if v < 0 {
+		return Uint128{}
+	}
+	return Uint128{lo: uint64(v)}
+}
+
+// Uint256FromInt64 returns v as Uint256 and whether v is non-negative.
+func Uint256FromInt64(v int64) (Uint256, bool) {
+	return Int256FromInt64(v).u, v >= 0
+}
+
+// Uint256FromInt64Sat returns v as Uint256 or 0 for negative v.
+func Uint256FromInt64Sat(v int64) Uint256 {
+	if v < 0 {
+		return Uint256{}
+	}
+	return Uint256FromUint64(uint64(v))
+}
+
+func (u Uint128) Uint64() (uint64, bool) { return u.lo, u.hi == 0 }
+func (u Uint128) Int64() (int64, bool)   { return int64(u.lo), u.hi == 0 && u.lo <= math.MaxInt64 }
+func (u Uint128) Int128() (Int128, bool) { return Int128{u: u}, u.hi>>63 == 0 }
+func (u Uint128) Uint256() Uint256       { return Uint256{lo: u} }
+func (u Uint128) Int256() Int256         { return Int256{u: Uint256{lo: u}} }
+
+func (u Uint128) Uint64Sat() uint64 {
+	if u.hi != 0 {
+		return math.MaxUint64
+	}
+	return u.lo
+}
+
+func (u Uint128) Int64Sat() int64 {
+	if v, ok := u.Int64(); ok {
+		return v
+	}
+	return math.MaxInt64
+}
+
+func (u Uint128) Int128Sat() Int128 {
+	if v, ok := u.Int128(); ok {
+		return v
+	}
+	return maxInt128
+}
+
+func (u Uint256) Uint64() (uint64, bool)   { return u.lo.lo, u.hi.IsZero() && u.lo.hi == 0 }
+func (u Uint256) Uint128() (Uint128, bool) { return u.lo, u.hi.IsZero() }
+func (u Uint256) Int256() (Int256, bool)   { return Int256{u: u}, u.hi.hi>>63 == 0 }
+
+func (u Uint256) Int64() (int64, bool) {
+	v, ok := u.lo.Int64()
+	return v, ok && u.hi.IsZero()
+}
+
+func (u Uint256) Int128() (Int128, bool) {
+	v, ok := u.lo.Int128()
+	return v, ok && u.hi.IsZero()
+}
+
+func (u Uint256) Uint64Sat() uint64 {
+	if v, ok := u.Uint64(); ok {
+		return v
+	}
+	return math.MaxUint64
+}
+
+func (u Uint256) Int64Sat() int64 {
+	if v, ok := u.Int64(); ok {
+		return v
+	}
+	return math.MaxInt64
+}
+
+func (u Uint256) Uint128Sat() Uint128 {
+	if v, ok := u.Uint128(); ok {
+		return v
+	}
+	return MaxUint128
+}
+
+func (u Uint256) Int128Sat() Int128 {
+	if v, ok := u.Int128(); ok {
+		return v
+	}
+	return maxInt128
+}
+
+func (u Uint256) Int256Sat() Int256 {
+	if v, ok := u.Int256(); ok {
+		return v
+	}
+	return maxInt256
+}
+
+func (i Int128) Uint64() (uint64, bool)   { return i.u.lo, i.u.hi == 0 }
+func (i Int128) Int64() (int64, bool)     { return int64(i.u.lo), int64(i.u.hi) == int64(i.u.lo)>>63 }
+func (i Int128) Uint128() (Uint128, bool) { return i.u, !i.IsNeg() }
+func (i Int128) Uint256() (Uint256, bool) { return i.Int256().u, !i.IsNeg() }
+
+func (i Int128) Int256() Int256 {
+	s := uint64(int64(i.u.hi) >> 63)
+	return Int256{u: Uint256{hi: Uint128{hi: s, lo: s}, lo: i.u}}
+}
+
+func (i Int128) Uint64Sat() uint64 {
+	switch v, ok := i.Uint64(); {
+	case ok:
+		return v
+	case i.IsNeg():
+		return 0
+	default:
+		return math.MaxUint64
+	}
+}
+
+func (i Int128) Int64Sat() int64 {
+	switch v, ok := i.Int64(); {
+	case ok:
+		return v
+	case i.IsNeg():
+		return math.MinInt64
+	default:
+		return math.MaxInt64
+	}
+}
+
+func (i Int128) Uint128Sat() Uint128 {
+	if i.IsNeg() {
+		return Uint128{}
+	}
+	return i.u
+}
+
+func (i Int128) Uint256Sat() Uint256 {
+	if i.IsNeg() {
+		return Uint256{}
+	}
+	return Uint256{lo: i.u}
+}
+
+func (i Int256) Uint64() (uint64, bool)   { return i.u.Uint64() }
+func (i Int256) Uint128() (Uint128, bool) { return i.u.Uint128() }
+func (i Int256) Uint256() (Uint256, bool) { return i.u, !i.IsNeg() }
+
+func (i Int256) Int64() (int64, bool) {
+	v128, ok128 := i.Int128()
+	v, ok := v128.Int64()
+	return v, ok && ok128
+}
+
+func (i Int256) Int128() (Int128, bool) {
+	s := uint64(int64(i.u.lo.hi) >> 63)
+	return Int128{u: i.u.lo}, i.u.hi.hi == s && i.u.hi.lo == s
+}
+
+func (i Int256) Uint64Sat() uint64 {
+	switch v, ok := i.Uint64(); {
+	case ok:
+		return v
+	case i.IsNeg():
+		return 0
+	default:
+		return math.MaxUint64
+	}
+}
+
+func (i Int256) Int64Sat() int64 {
+	switch v, ok := i.Int64(); {
+	case ok:
+		return v
+	case i.IsNeg():
+		return math.MinInt64
+	default:
+		return math.MaxInt64
+	}
+}
+
+func (i Int256) Uint128Sat() Uint128 {
+	switch v, ok := i.Uint128(); {
+	case ok:
+		return v
+	case i.IsNeg():
+		return Uint128{}
+	default:
+		return MaxUint128
+	}
+}
+
+func (i Int256) Int128Sat() Int128 {
+	switch v, ok := i.Int128(); {
+	case ok:
+		return v
+	case i.IsNeg():
+		return minInt128
+	default:
+		return maxInt128
+	}
+}
+
+func (i Int256) Uint256Sat() Uint256 {
+	if i.IsNeg() {
+		return Uint256{}
+	}
+	return i.u
+}
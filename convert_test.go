@@ -0,0 +1,162 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	var (
+		maxU64  = new(big.Int).SetUint64(math.MaxUint64)
+		minI64  = big.NewInt(math.MinInt64)
+		maxI64  = big.NewInt(math.MaxInt64)
+		maxU128 = MaxUint128.Big()
+		minI128 = minInt128.Big()
+		maxI128 = maxInt128.Big()
+		maxU256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		maxI256 = maxInt256.Big()
+		zero    = new(big.Int)
+	)
+
+	// check verifies a checked and a saturating conversion of v to range [lo, hi].
+	check := func(name string, v *big.Int, lo, hi *big.Int, got *big.Int, ok bool, sat *big.Int) {
+		t.Helper()
+
+		fits := v.Cmp(lo) >= 0 && v.Cmp(hi) <= 0
+		if ok != fits {
+			t.Fatalf("%s(%v): unexpected ok; got %v; want %v", name, v, ok, fits)
+		}
+		if ok && got.Cmp(v) != 0 {
+			t.Fatalf("%s(%v): unexpected value; got %v", name, v, got)
+		}
+
+		want := v
+		switch {
+		case v.Cmp(lo) < 0:
+			want = lo
+		case v.Cmp(hi) > 0:
+			want = hi
+		}
+		if sat.Cmp(want) != 0 {
+			t.Fatalf("%s(%v): unexpected saturated value; got %v; want %v", name, v, sat, want)
+		}
+	}
+
+	u64 := func(v uint64) *big.Int { return new(big.Int).SetUint64(v) }
+	i64 := func(v int64) *big.Int { return big.NewInt(v) }
+
+	rng := rand.New(rand.NewSource(1))
+	word := func() uint64 {
+		switch rng.Intn(4) {
+		case 0:
+			return 0
+		case 1:
+			return math.MaxUint64
+		case 2:
+			return 1 << 63
+		default:
+			return rng.Uint64()
+		}
+	}
+
+	for i := 0; i < 10000; i++ {
+		u128 := NewUint128(word(), word())
+		u256 := NewUint256(NewUint128(word(), word()), NewUint128(word(), word()))
+		i128 := Int128{u: NewUint128(word(), word())}
+		i256 := Int256{u: NewUint256(NewUint128(word(), word()), NewUint128(word(), word()))}
+
+		b := u128.Big()
+		v1, ok := u128.Uint64()
+		check("Uint128.Uint64", b, zero, maxU64, u64(v1), ok, u64(u128.Uint64Sat()))
+		v2, ok := u128.Int64()
+		check("Uint128.Int64", b, minI64, maxI64, i64(v2), ok, i64(u128.Int64Sat()))
+		v3, ok := u128.Int128()
+		check("Uint128.Int128", b, minI128, maxI128, v3.Big(), ok, u128.Int128Sat().Big())
+		check("Uint128.Uint256", b, zero, maxU256, u128.Uint256().Big(), true, u128.Uint256().Big())
+		check("Uint128.Int256", b, zero, maxI256, u128.Int256().Big(), true, u128.Int256().Big())
+
+		b = u256.Big()
+		v1, ok = u256.Uint64()
+		check("Uint256.Uint64", b, zero, maxU64, u64(v1), ok, u64(u256.Uint64Sat()))
+		v2, ok = u256.Int64()
+		check("Uint256.Int64", b, minI64, maxI64, i64(v2), ok, i64(u256.Int64Sat()))
+		v4, ok := u256.Uint128()
+		check("Uint256.Uint128", b, zero, maxU128, v4.Big(), ok, u256.Uint128Sat().Big())
+		v3, ok = u256.Int128()
+		check("Uint256.Int128", b, minI128, maxI128, v3.Big(), ok, u256.Int128Sat().Big())
+		v5, ok := u256.Int256()
+		check("Uint256.Int256", b, zero, maxI256, v5.Big(), ok, u256.Int256Sat().Big())
+
+		b = i128.Big()
+		v1, ok = i128.Uint64()
+		check("Int128.Uint64", b, zero, maxU64, u64(v1), ok, u64(i128.Uint64Sat()))
+		v2, ok = i128.Int64()
+		check("Int128.Int64", b, minI64, maxI64, i64(v2), ok, i64(i128.Int64Sat()))
+		v4, ok = i128.Uint128()
+		check("Int128.Uint128", b, zero, maxU128, v4.Big(), ok, i128.Uint128Sat().Big())
+		v6, ok := i128.Uint256()
+		check("Int128.Uint256", b, zero, maxU256, v6.Big(), ok, i128.Uint256Sat().Big())
+		check("Int128.Int256", b, b, b, i128.Int256().Big(), true, i128.Int256().Big())
+
+		b = i256.Big()
+		v1, ok = i256.Uint64()
+		check("Int256.Uint64", b, zero, maxU64, u64(v1), ok, u64(i256.Uint64Sat()))
+		v2, ok = i256.Int64()
+		check("Int256.Int64", b, minI64, maxI64, i64(v2), ok, i64(i256.Int64Sat()))
+		v4, ok = i256.Uint128()
+		check("Int256.Uint128", b, zero, maxU128, v4.Big(), ok, i256.Uint128Sat().Big())
+		v3, ok = i256.Int128()
+		check("Int256.Int128", b, minI128, maxI128, v3.Big(), ok, i256.Int128Sat().Big())
+		v6, ok = i256.Uint256()
+		check("Int256.Uint256", b, zero, maxU256, v6.Big(), ok, i256.Uint256Sat().Big())
+	}
+}
+
+func TestConvertFromInt64(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, math.MaxInt64, math.MinInt64} {
+		u128, ok := Uint128FromInt64(v)
+		if ok != (v >= 0) || (ok && u128.Big().Int64() != v) {
+			t.Fatalf("unexpected Uint128FromInt64(%v); got %v %v", v, u128, ok)
+		}
+		u256, ok := Uint256FromInt64(v)
+		if ok != (v >= 0) || (ok && u256.Big().Int64() != v) {
+			t.Fatalf("unexpected Uint256FromInt64(%v); got %v %v", v, u256, ok)
+		}
+		if got := Int128FromInt64(v).Big().Int64(); got != v {
+			t.Fatalf("unexpected Int128FromInt64(%v); got %v", v, got)
+		}
+		if got := Int256FromInt64(v).Big().Int64(); got != v {
+			t.Fatalf("unexpected Int256FromInt64(%v); got %v", v, got)
+		}
+		if v < 0 && (!Uint128FromInt64Sat(v).IsZero() || !Uint256FromInt64Sat(v).IsZero()) {
+			t.Fatalf("saturated value of %v must be zero", v)
+		}
+	}
+
+	if got := Int128FromInt64(-5).Cmp(Int128FromInt64(3)); got != -1 {
+		t.Fatalf("unexpected Cmp; got %v; want %v", got, -1)
+	}
+	if got := Int256FromInt64(-5).Add(Int256FromInt64(3)).String(); got != "-2" {
+		t.Fatalf("unexpected Add; got %v; want %v", got, "-2")
+	}
+}
+
+func TestMaxValues(t *testing.T) {
+	if MaxUint128.Inc() != (Uint128{}) || MaxUint128.String() != "340282366920938463463374607431768211455" {
+		t.Fatalf("unexpected MaxUint128; got %v", MaxUint128)
+	}
+	if MaxUint256.Inc() != (Uint256{}) || MaxUint256.Big().BitLen() != 256 {
+		t.Fatalf("unexpected MaxUint256; got %v", MaxUint256)
+	}
+
+	a, b := NewUint128(1, 0), NewUint128(0, math.MaxUint64)
+	if a.Min(b) != b || b.Min(a) != b || a.Max(b) != a || b.Max(a) != a || a.Min(a) != a {
+		t.Fatal("unexpected Uint128 Min/Max")
+	}
+	x, y := NewUint256(Uint128{}, MaxUint128), NewUint256(NewUint128(0, 1), Uint128{})
+	if x.Min(y) != x || y.Min(x) != x || x.Max(y) != y || y.Max(x) != y {
+		t.Fatal("unexpected Uint256 Min/Max")
+	}
+}
@@ -0,0 +1,133 @@
+package mathx
+
+import "sort"
+
+// CounterBucket is a value and its count.
+type CounterBucket struct {
+	Value int64
+	Count uint64
+}
+
+// CounterHistogram counts exact occurrences of discrete int64 values,
+// such as HTTP status codes or retry counts.
+//
+// With a limit on distinct values, the least frequent values are compacted
+// into Other once there are twice as many, leaving the limit most frequent.
+// Add and Merge use the same rule.
+type CounterHistogram struct {
+	limit  int
+	counts map[int64]uint64
+	total  uint64
+	other  uint64
+}
+
+// NewCounterHistogram returns new CounterHistogram keeping at most limit distinct values
+// after compaction, 0 keeps every value.
+func NewCounterHistogram(limit int) *CounterHistogram {
+	if limit < 0 {
+		panic("mathx: counter histogram limit must be non-negative")
+	}
+	return &CounterHistogram{limit: limit, counts: map[int64]uint64{}}
+}
+
+// Reset resets the histogram.
+func (h *CounterHistogram) Reset() {
+	for v := range h.counts {
+		delete(h.counts, v)
+	}
+	h.total, h.other = 0, 0
+}
+
+// Inc adds 1 occurrence of v.
+func (h *CounterHistogram) Inc(v int64) { h.Add(v, 1) }
+
+// Add n occurrences of v.
+func (h *CounterHistogram) Add(v int64, n uint64) {
+	if n == 0 {
+		return
+	}
+	h.counts[v] += n
+	h.total += n
+	if h.limit > 0 && len(h.counts) > 2*h.limit {
+		h.compact()
+	}
+}
+
+// Merge adds all counts of x into h.
+func (h *CounterHistogram) Merge(x *CounterHistogram) {
+	for v, n := range x.counts {
+		h.counts[v] += n
+	}
+	h.total += x.total
+	h.other += x.other
+	if h.limit > 0 && len(h.counts) > 2*h.limit {
+		h.compact()
+	}
+}
+
+// Count returns the count of v, 0 for unknown or compacted values.
+func (h *CounterHistogram) Count(v int64) uint64 { return h.counts[v] }
+
+// Total returns the total count, including compacted values.
+func (h *CounterHistogram) Total() uint64 { return h.total }
+
+// Other returns the total count of compacted values.
+func (h *CounterHistogram) Other() uint64 { return h.other }
+
+// Buckets appends buckets sorted by value to dst.
+func (h *CounterHistogram) Buckets(dst []CounterBucket) []CounterBucket {
+	start := len(dst)
+	for v, n := range h.counts {
+		dst = append(dst, CounterBucket{Value: v, Count: n})
+	}
+	bs := dst[start:]
+	sort.Slice(bs, func(i, j int) bool { return bs[i].Value < bs[j].Value })
+	return dst
+}
+
+// Top returns at most n buckets with the largest counts, ties by smaller value.
+func (h *CounterHistogram) Top(n int) []CounterBucket {
+	bs := h.topSorted()
+	if n < len(bs) {
+		bs = bs[:n]
+	}
+	return bs
+}
+
+// Quantile returns the value at the given phi of the counted values.
+// Compacted values are not taken into account. It returns NaN if h is empty.
+func (h *CounterHistogram) Quantile(phi float64) float64 {
+	bs := h.Buckets(nil)
+	var n uint64
+	for _, b := range bs {
+		n += b.Count
+	}
+	if n == 0 || phi != phi {
+		return NaN
+	}
+	phi = Clamp01(phi)
+
+	// Nearest rank, like Histogram.
+	rank := uint64(phi*float64(n-1) + 0.5)
+	for _, b := range bs {
+		if rank < b.Count {
+			return float64(b.Value)
+		}
+		rank -= b.Count
+	}
+	return float64(bs[len(bs)-1].Value)
+}
+
+// compact keeps the limit most frequent values and moves the rest into other.
+func (h *CounterHistogram) compact() {
+	for _, b := range h.topSorted()[h.limit:] {
+		delete(h.counts, b.Value)
+		h.other += b.Count
+	}
+}
+
+func (h *CounterHistogram) topSorted() []CounterBucket {
+	bs := h.Buckets(make([]CounterBucket, 0, len(h.counts)))
+	sort.SliceStable(bs, func(i, j int) bool { return bs[i].Count > bs[j].Count })
+	return bs
+}
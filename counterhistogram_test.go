@@ -0,0 +1,81 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCounterHistogram(t *testing.T) {
+	h := NewCounterHistogram(0)
+	for i := 0; i < 90; i++ {
+		h.Inc(200)
+	}
+	h.Add(404, 7)
+	h.Add(500, 3)
+	h.Add(301, 0)
+
+	if h.Total() != 100 || h.Count(200) != 90 || h.Count(301) != 0 || h.Other() != 0 {
+		t.Fatalf("unexpected counts; total %v", h.Total())
+	}
+	bs := h.Buckets(nil)
+	want := []CounterBucket{{200, 90}, {404, 7}, {500, 3}}
+	if len(bs) != len(want) {
+		t.Fatalf("unexpected buckets; got %v; want %v", bs, want)
+	}
+	for i := range want {
+		if bs[i] != want[i] {
+			t.Fatalf("unexpected buckets; got %v; want %v", bs, want)
+		}
+	}
+	if top := h.Top(1); len(top) != 1 || top[0] != want[0] {
+		t.Fatalf("unexpected top; got %v", top)
+	}
+	if q := h.Quantile(0.5); q != 200 {
+		t.Fatalf("unexpected median; got %v", q)
+	}
+	if q := h.Quantile(0.95); q != 404 {
+		t.Fatalf("unexpected p95; got %v", q)
+	}
+	if q := h.Quantile(1); q != 500 {
+		t.Fatalf("unexpected max; got %v", q)
+	}
+
+	h.Reset()
+	if h.Total() != 0 || !math.IsNaN(h.Quantile(0.5)) {
+		t.Fatal("histogram must be empty after Reset")
+	}
+}
+
+func TestCounterHistogramCompact(t *testing.T) {
+	h := NewCounterHistogram(2)
+	h.Add(1, 100)
+	h.Add(2, 50)
+	for v := int64(10); v < 20; v++ {
+		h.Add(v, 1)
+	}
+	if n := len(h.Buckets(nil)); n > 4 {
+		t.Fatalf("unexpected number of buckets; got %v; want at most 4", n)
+	}
+	if h.Total() != 160 || h.Count(1) != 100 || h.Count(2) != 50 {
+		t.Fatalf("frequent values must be kept; got total %v", h.Total())
+	}
+	var kept uint64
+	for _, b := range h.Buckets(nil) {
+		kept += b.Count
+	}
+	if kept+h.Other() != h.Total() {
+		t.Fatalf("counts must add up; got %v + %v; want %v", kept, h.Other(), h.Total())
+	}
+
+	x := NewCounterHistogram(0)
+	x.Add(3, 1000)
+	x.Add(4, 10)
+	x.Add(5, 10)
+	h.Merge(x)
+	if top := h.Top(2); top[0] != (CounterBucket{3, 1000}) || top[1] != (CounterBucket{1, 100}) || len(h.Buckets(nil)) > 4 {
+		t.Fatalf("unexpected buckets after merge; got %v", h.Buckets(nil))
+	}
+	if h.Total() != 1180 || h.Other()+h.Count(1)+h.Count(3) != 1180 {
+		t.Fatalf("unexpected counts after merge; got total %v", h.Total())
+	}
+}
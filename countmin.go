@@ -0,0 +1,133 @@
+package mathx
+
+import "encoding/binary"
+
+// CountMinSketch estimates frequencies of items in a stream.
+// Items are identified by their 64-bit hash.
+// Estimates never undercount and overcount by at most e*N/width
+// with probability 1 - exp(-depth), where N is the total count.
+//
+// See: Cormode, G., Muthukrishnan, S. An improved data stream summary: the count-min sketch and its applications. https://doi.org/10.1016/j.jalgor.2003.12.001
+type CountMinSketch struct {
+	width  uint32
+	depth  uint32
+	total  uint64
+	counts []uint64
+}
+
+// NewCountMinSketch returns new CountMinSketch with the given dimensions.
+func NewCountMinSketch(width, depth int) *CountMinSketch {
+	if width <= 0 || depth <= 0 {
+		panic("mathx: count-min sketch dimensions must be positive")
+	}
+	return &CountMinSketch{
+		width:  uint32(width),
+		depth:  uint32(depth),
+		counts: make([]uint64, width*depth),
+	}
+}
+
+// Width of the sketch.
+func (s *CountMinSketch) Width() int { return int(s.width) }
+
+// Depth of the sketch.
+func (s *CountMinSketch) Depth() int { return int(s.depth) }
+
+// Total returns the sum of all added counts.
+func (s *CountMinSketch) Total() uint64 { return s.total }
+
+// Reset resets the sketch.
+func (s *CountMinSketch) Reset() {
+	s.total = 0
+	for i := range s.counts {
+		s.counts[i] = 0
+	}
+}
+
+// Add n occurrences of the item with the given hash.
+func (s *CountMinSketch) Add(hash, n uint64) {
+	h1, h2 := cmsHashes(hash)
+	for i := uint32(0); i < s.depth; i++ {
+		s.counts[i*s.width+(h1+i*h2)%s.width] += n
+	}
+	s.total += n
+}
+
+// Estimate returns the estimated count of the item with the given hash.
+func (s *CountMinSketch) Estimate(hash uint64) uint64 {
+	h1, h2 := cmsHashes(hash)
+	min := ^uint64(0)
+	for i := uint32(0); i < s.depth; i++ {
+		if c := s.counts[i*s.width+(h1+i*h2)%s.width]; c < min {
+			min = c
+		}
+	}
+	return min
+}
+
+// Merge adds counts of x to s.
+// Both sketches must have the same dimensions.
+func (s *CountMinSketch) Merge(x *CountMinSketch) error {
+	if s.width != x.width || s.depth != x.depth {
+		return ErrMismatch
+	}
+	for i, c := range x.counts {
+		s.counts[i] += c
+	}
+	s.total += x.total
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s *CountMinSketch) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 16+8*len(s.counts))
+	binary.BigEndian.PutUint32(b[0:], s.width)
+	binary.BigEndian.PutUint32(b[4:], s.depth)
+	binary.BigEndian.PutUint64(b[8:], s.total)
+	for i, c := range s.counts {
+		binary.BigEndian.PutUint64(b[16+8*i:], c)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *CountMinSketch) UnmarshalBinary(b []byte) error {
+	if len(b) < 16 {
+		return ErrInvalidEncoding
+	}
+	width := binary.BigEndian.Uint32(b[0:])
+	depth := binary.BigEndian.Uint32(b[4:])
+	total := binary.BigEndian.Uint64(b[8:])
+	b = b[16:]
+
+	n := uint64(width) * uint64(depth)
+	// Compare n before multiplying, 8*n wraps for large headers.
+	if width == 0 || depth == 0 || n > uint64(len(b))/8 || uint64(len(b)) != 8*n {
+		return ErrInvalidEncoding
+	}
+
+	counts := make([]uint64, n)
+	for i := range counts {
+		counts[i] = binary.BigEndian.Uint64(b[8*i:])
+	}
+
+	s.width, s.depth, s.total, s.counts = width, depth, total, counts
+	return nil
+}
+
+// cmsHashes derives 2 row hashes from the item hash.
+// See: Kirsch, A., Mitzenmacher, M. Less hashing, same performance: Building a better Bloom filter. https://doi.org/10.1002/rsa.20208
+func cmsHashes(hash uint64) (uint32, uint32) {
+	hash = mix64(hash)
+	return uint32(hash), uint32(hash>>32) | 1
+}
+
+// mix64 is the splitmix64 finalizer.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
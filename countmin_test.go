@@ -0,0 +1,68 @@
+package mathx
+
+import "testing"
+
+func TestCountMinSketch(t *testing.T) {
+	s := NewCountMinSketch(1000, 5)
+	for i := uint64(0); i < 1000; i++ {
+		s.Add(i, i%10+1)
+	}
+	s.Add(12345, 500)
+
+	if got := s.Estimate(12345); got < 500 || got > 520 {
+		t.Fatalf("unexpected estimate; got %v; want about %v", got, 500)
+	}
+	for i := uint64(0); i < 1000; i++ {
+		if got := s.Estimate(i); got < i%10+1 {
+			t.Fatalf("estimate must not undercount; got %v; want at least %v", got, i%10+1)
+		}
+	}
+	if s.Total() != 6000 {
+		t.Fatalf("unexpected total; got %v; want %v", s.Total(), 6000)
+	}
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	a := NewCountMinSketch(100, 4)
+	b := NewCountMinSketch(100, 4)
+	a.Add(1, 10)
+	b.Add(1, 5)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Estimate(1); got != 15 {
+		t.Fatalf("unexpected estimate; got %v; want %v", got, 15)
+	}
+	if err := a.Merge(NewCountMinSketch(10, 4)); err != ErrMismatch {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+}
+
+func TestCountMinSketchBinary(t *testing.T) {
+	s := NewCountMinSketch(64, 3)
+	s.Add(7, 3)
+	s.Add(8, 4)
+
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got CountMinSketch
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.Width() != 64 || got.Depth() != 3 || got.Total() != 7 || got.Estimate(8) != 4 {
+		t.Fatalf("unexpected sketch after decoding; got %+v", got)
+	}
+	if err := got.UnmarshalBinary(b[:20]); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+
+	// width 2**31 and depth 2**30 give 8*n == 0 modulo 2**64.
+	header := []byte{0x80, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	if err := got.UnmarshalBinary(header); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error for huge header; got %v; want %v", err, ErrInvalidEncoding)
+	}
+}
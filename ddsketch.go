@@ -0,0 +1,382 @@
+package mathx
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// DDSketch is a quantile sketch with relative-error guarantees.
+// It uses the logarithmic index mapping without interpolation
+// and unbounded stores, like the DataDog reference implementations.
+//
+// See: Masson, C., Rim, J.E., Lee, H.K. DDSketch: A Fast and Fully-Mergeable Quantile Sketch with Relative-Error Guarantees. https://arxiv.org/abs/1908.10693
+type DDSketch struct {
+	gamma       float64
+	multiplier  float64 // 1 / ln(gamma)
+	indexOffset float64
+	zero        float64
+	pos, neg    map[int32]float64
+}
+
+// NewDDSketch returns new DDSketch with the given relative accuracy in (0, 1).
+// Bin indices are int32, values whose index is out of range are kept
+// in the first or the last bin without the accuracy guarantee.
+func NewDDSketch(relativeAccuracy float64) *DDSketch {
+	if !(relativeAccuracy > 0 && relativeAccuracy < 1) {
+		panic("mathx: ddsketch relative accuracy must be in range (0, 1)")
+	}
+	return newDDSketch((1+relativeAccuracy)/(1-relativeAccuracy), 0)
+}
+
+func newDDSketch(gamma, indexOffset float64) *DDSketch {
+	return &DDSketch{
+		gamma:       gamma,
+		multiplier:  1 / math.Log(gamma),
+		indexOffset: indexOffset,
+		pos:         map[int32]float64{},
+		neg:         map[int32]float64{},
+	}
+}
+
+// RelativeAccuracy of the sketch.
+func (s *DDSketch) RelativeAccuracy() float64 { return 1 - 2/(1+s.gamma) }
+
+// Count returns the total count of added values.
+func (s *DDSketch) Count() float64 {
+	return s.zero + sumCounts(s.pos) + sumCounts(s.neg)
+}
+
+// Reset resets the sketch.
+func (s *DDSketch) Reset() {
+	s.zero = 0
+	for k := range s.pos {
+		delete(s.pos, k)
+	}
+	for k := range s.neg {
+		delete(s.neg, k)
+	}
+}
+
+// Add the value v with count 1.
+func (s *DDSketch) Add(v float64) { s.AddCount(v, 1) }
+
+// AddCount adds the value v with count n.
+// Non-finite values and non-positive counts are ignored.
+func (s *DDSketch) AddCount(v, n float64) {
+	switch {
+	case math.IsNaN(v) || math.IsInf(v, 0) || !(n > 0):
+	case v > 0:
+		s.pos[s.index(v)] += n
+	case v < 0:
+		s.neg[s.index(-v)] += n
+	default:
+		s.zero += n
+	}
+}
+
+// Merge adds all values of x into s.
+// It returns ErrMismatch if the sketches have different index mappings.
+func (s *DDSketch) Merge(x *DDSketch) error {
+	if s.gamma != x.gamma || s.indexOffset != x.indexOffset {
+		return ErrMismatch
+	}
+	s.zero += x.zero
+	for k, n := range x.pos {
+		s.pos[k] += n
+	}
+	for k, n := range x.neg {
+		s.neg[k] += n
+	}
+	return nil
+}
+
+// Quantile returns the estimated quantile value for the given phi.
+// It returns NaN if the sketch is empty.
+func (s *DDSketch) Quantile(phi float64) float64 {
+	count := s.Count()
+	if count == 0 || math.IsNaN(phi) || phi < 0 || phi > 1 {
+		return NaN
+	}
+
+	rank := phi * (count - 1)
+	negCount := sumCounts(s.neg)
+	switch {
+	case rank < negCount:
+		return -s.value(keyAtRank(s.neg, negCount-1-rank))
+	case rank < negCount+s.zero:
+		return 0
+	default:
+		return s.value(keyAtRank(s.pos, rank-negCount-s.zero))
+	}
+}
+
+// index returns the bin of v > 0. Indices out of the int32 range,
+// e.g. for tiny accuracies or large index offsets, saturate,
+// so such values lose the accuracy guarantee but never land in unrelated bins.
+func (s *DDSketch) index(v float64) int32 {
+	i := math.Floor(math.Log(v)*s.multiplier + s.indexOffset)
+	switch {
+	case i < math.MinInt32:
+		return math.MinInt32
+	case i > math.MaxInt32:
+		return math.MaxInt32
+	}
+	return int32(i)
+}
+
+func (s *DDSketch) value(i int32) float64 {
+	lower := math.Exp((float64(i) - s.indexOffset) / s.multiplier)
+	return lower * (1 + s.RelativeAccuracy())
+}
+
+// Fields of the DDSketch protobuf messages, see ddsketch.proto
+// in https://github.com/DataDog/sketches-java.
+const (
+	ddSketchMapping  = 1
+	ddSketchPositive = 2
+	ddSketchNegative = 3
+	ddSketchZero     = 4
+
+	ddMappingGamma         = 1
+	ddMappingIndexOffset   = 2
+	ddMappingInterpolation = 3
+
+	ddStoreBinCounts    = 1
+	ddStoreContiguous   = 2
+	ddStoreContiguousAt = 3
+)
+
+// AppendProto appends s encoded as the DDSketch protobuf message to dst.
+func (s *DDSketch) AppendProto(dst []byte) []byte {
+	var mapping []byte
+	mapping = appendProtoDouble(mapping, ddMappingGamma, s.gamma)
+	mapping = appendProtoDouble(mapping, ddMappingIndexOffset, s.indexOffset)
+	dst = appendProtoBytes(dst, ddSketchMapping, mapping)
+
+	dst = appendProtoBytes(dst, ddSketchPositive, appendDDStore(nil, s.pos))
+	dst = appendProtoBytes(dst, ddSketchNegative, appendDDStore(nil, s.neg))
+	if s.zero != 0 {
+		dst = appendProtoDouble(dst, ddSketchZero, s.zero)
+	}
+	return dst
+}
+
+// ParseDDSketchProto decodes a DDSketch protobuf message.
+// Only the logarithmic mapping without interpolation is supported,
+// other mappings give ErrMismatch.
+func ParseDDSketchProto(b []byte) (*DDSketch, error) {
+	var mapping, pos, neg []byte
+	var zero float64
+	err := walkProto(b, func(field int, wire int, v uint64, data []byte) error {
+		switch {
+		case field == ddSketchMapping && wire == protoBytes:
+			mapping = data
+		case field == ddSketchPositive && wire == protoBytes:
+			pos = data
+		case field == ddSketchNegative && wire == protoBytes:
+			neg = data
+		case field == ddSketchZero && wire == protoFixed64:
+			zero = math.Float64frombits(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var gamma, offset float64
+	var interpolation uint64
+	err = walkProto(mapping, func(field int, wire int, v uint64, _ []byte) error {
+		switch {
+		case field == ddMappingGamma && wire == protoFixed64:
+			gamma = math.Float64frombits(v)
+		case field == ddMappingIndexOffset && wire == protoFixed64:
+			offset = math.Float64frombits(v)
+		case field == ddMappingInterpolation && wire == protoVarint:
+			interpolation = v
+		}
+		return nil
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case !(gamma > 1) || math.IsInf(gamma, 0) || math.IsNaN(offset):
+		return nil, ErrInvalidEncoding
+	case interpolation != 0:
+		return nil, ErrMismatch
+	}
+
+	s := newDDSketch(gamma, offset)
+	s.zero = zero
+	if err := parseDDStore(s.pos, pos); err != nil {
+		return nil, err
+	}
+	if err := parseDDStore(s.neg, neg); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func appendDDStore(dst []byte, bins map[int32]float64) []byte {
+	for _, k := range sortedKeys(bins, false) {
+		var entry []byte
+		entry = appendProtoVarint(entry, 1, zigzag32(k))
+		entry = appendProtoDouble(entry, 2, bins[k])
+		dst = appendProtoBytes(dst, ddStoreBinCounts, entry)
+	}
+	return dst
+}
+
+func parseDDStore(bins map[int32]float64, b []byte) error {
+	var contiguous []float64
+	var offset int32
+	err := walkProto(b, func(field int, wire int, v uint64, data []byte) error {
+		switch {
+		case field == ddStoreBinCounts && wire == protoBytes:
+			var key int32
+			var count float64
+			err := walkProto(data, func(field int, wire int, v uint64, _ []byte) error {
+				switch {
+				case field == 1 && wire == protoVarint:
+					key = unzigzag32(v)
+				case field == 2 && wire == protoFixed64:
+					count = math.Float64frombits(v)
+				}
+				return nil
+			})
+			bins[key] += count
+			return err
+		case field == ddStoreContiguous && wire == protoBytes:
+			if len(data)%8 != 0 {
+				return ErrInvalidEncoding
+			}
+			for i := 0; i < len(data); i += 8 {
+				contiguous = append(contiguous, math.Float64frombits(binary.LittleEndian.Uint64(data[i:])))
+			}
+		case field == ddStoreContiguous && wire == protoFixed64:
+			contiguous = append(contiguous, math.Float64frombits(v))
+		case field == ddStoreContiguousAt && wire == protoVarint:
+			offset = unzigzag32(v)
+		}
+		return nil
+	})
+	for i, n := range contiguous {
+		if n != 0 {
+			bins[offset+int32(i)] += n
+		}
+	}
+	return err
+}
+
+func sumCounts(bins map[int32]float64) float64 {
+	var sum float64
+	for _, n := range bins {
+		sum += n
+	}
+	return sum
+}
+
+// keyAtRank returns the smallest key whose cumulative count exceeds rank.
+func keyAtRank(bins map[int32]float64, rank float64) int32 {
+	keys := sortedKeys(bins, false)
+	var cum float64
+	for _, k := range keys {
+		cum += bins[k]
+		if cum > rank {
+			return k
+		}
+	}
+	return keys[len(keys)-1]
+}
+
+// sortedKeys returns the keys of bins in ascending or descending order.
+func sortedKeys(bins map[int32]float64, desc bool) []int32 {
+	keys := make([]int32, 0, len(bins))
+	for k := range bins {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] != desc })
+	return keys
+}
+
+// Protobuf wire types.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+func appendProtoTag(dst []byte, field, wire int) []byte {
+	return appendUvarint(dst, uint64(field)<<3|uint64(wire))
+}
+
+func appendProtoVarint(dst []byte, field int, v uint64) []byte {
+	return appendUvarint(appendProtoTag(dst, field, protoVarint), v)
+}
+
+func appendProtoDouble(dst []byte, field int, f float64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	return append(appendProtoTag(dst, field, protoFixed64), b[:]...)
+}
+
+func appendProtoBytes(dst []byte, field int, data []byte) []byte {
+	dst = appendUvarint(appendProtoTag(dst, field, protoBytes), uint64(len(data)))
+	return append(dst, data...)
+}
+
+func appendUvarint(dst []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(dst, b[:binary.PutUvarint(b[:], v)]...)
+}
+
+// walkProto calls fn for each field of the protobuf message in b.
+// For varint and fixed fields v holds the value, for bytes fields data holds the payload.
+func walkProto(b []byte, fn func(field, wire int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 || tag>>3 == 0 || tag>>3 > math.MaxInt32 {
+			return ErrInvalidEncoding
+		}
+		b = b[n:]
+
+		var v uint64
+		var data []byte
+		switch wire := int(tag & 7); wire {
+		case protoVarint:
+			v, n = binary.Uvarint(b)
+			if n <= 0 {
+				return ErrInvalidEncoding
+			}
+			b = b[n:]
+		case protoFixed64:
+			if len(b) < 8 {
+				return ErrInvalidEncoding
+			}
+			v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case protoFixed32:
+			if len(b) < 4 {
+				return ErrInvalidEncoding
+			}
+			v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case protoBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return ErrInvalidEncoding
+			}
+			data, b = b[n:n+int(size)], b[n+int(size):]
+		default:
+			return ErrInvalidEncoding
+		}
+		if err := fn(int(tag>>3), int(tag&7), v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func zigzag32(v int32) uint64 { return uint64(uint32(v<<1) ^ uint32(v>>31)) }
+
+func unzigzag32(v uint64) int32 { return int32(uint32(v)>>1) ^ -int32(v&1) }
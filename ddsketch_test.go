@@ -0,0 +1,114 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDDSketch(t *testing.T) {
+	const alpha = 0.01
+	s := NewDDSketch(alpha)
+	for i := 1; i <= 10000; i++ {
+		s.Add(float64(i))
+		s.Add(-float64(i))
+	}
+	s.Add(0)
+
+	if got := s.Count(); got != 20001 {
+		t.Fatalf("unexpected count; got %v; want %v", got, 20001)
+	}
+	for _, phi := range []float64{0, 0.01, 0.25, 0.5, 0.75, 0.99, 1} {
+		want := math.Round(phi*20000) - 10000
+		got := s.Quantile(phi)
+		if math.Abs(got-want) > alpha*math.Abs(want)+1e-9 {
+			t.Fatalf("unexpected quantile %v; got %v; want %v", phi, got, want)
+		}
+	}
+	if got := NewDDSketch(alpha).Quantile(0.5); !math.IsNaN(got) {
+		t.Fatalf("unexpected quantile of empty sketch; got %v", got)
+	}
+}
+
+func TestDDSketchIndexRange(t *testing.T) {
+	s := NewDDSketch(1e-9)
+	if got := s.index(1e-300); got != math.MinInt32 {
+		t.Fatalf("unexpected index of tiny value; got %v; want %v", got, math.MinInt32)
+	}
+	if got := s.index(1e300); got != math.MaxInt32 {
+		t.Fatalf("unexpected index of huge value; got %v; want %v", got, math.MaxInt32)
+	}
+	s.Add(1e-300)
+	s.Add(1e300)
+	if lo, hi := s.Quantile(0), s.Quantile(1); !(lo < hi) {
+		t.Fatalf("unexpected order of saturated bins; got %v, %v", lo, hi)
+	}
+
+	shifted := newDDSketch(s.gamma, 1e12)
+	if got := shifted.index(1); got != math.MaxInt32 {
+		t.Fatalf("unexpected index with large offset; got %v; want %v", got, math.MaxInt32)
+	}
+}
+
+func TestDDSketchMerge(t *testing.T) {
+	a, b := NewDDSketch(0.02), NewDDSketch(0.02)
+	for i := 1; i <= 1000; i++ {
+		a.Add(float64(i))
+		b.Add(float64(i + 1000))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Quantile(0.5); math.Abs(got-1000) > 20 {
+		t.Fatalf("unexpected median; got %v; want %v", got, 1000)
+	}
+	if err := a.Merge(NewDDSketch(0.01)); err != ErrMismatch {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+}
+
+func TestDDSketchProto(t *testing.T) {
+	s := NewDDSketch(0.01)
+	for _, v := range []float64{-5, 0, 0, 1, 2, 1000, 1e-9} {
+		s.Add(v)
+	}
+
+	got, err := ParseDDSketchProto(s.AppendProto(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.gamma != s.gamma || got.zero != 2 || len(got.pos) != len(s.pos) || len(got.neg) != 1 {
+		t.Fatalf("unexpected sketch: %+v", got)
+	}
+	for k, n := range s.pos {
+		if got.pos[k] != n {
+			t.Fatalf("unexpected bin %v; got %v; want %v", k, got.pos[k], n)
+		}
+	}
+
+	// mapping{gamma: 2}, positiveValues{contiguousBinCounts: [1, 0, 3], contiguousBinIndexOffset: -1}
+	msg := []byte{
+		0x0a, 0x09, 0x09, 0, 0, 0, 0, 0, 0, 0, 0x40,
+		0x12, 0x1c,
+		0x12, 0x18,
+		0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+		0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0x08, 0x40,
+		0x18, 0x01,
+	}
+	got, err = ParseDDSketchProto(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.gamma != 2 || got.pos[-1] != 1 || got.pos[1] != 3 || len(got.pos) != 2 {
+		t.Fatalf("unexpected sketch: %+v", got)
+	}
+
+	// interpolation: CUBIC
+	cubic := []byte{0x0a, 0x0b, 0x09, 0, 0, 0, 0, 0, 0, 0, 0x40, 0x18, 0x03}
+	if _, err := ParseDDSketchProto(cubic); err != ErrMismatch {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+	if _, err := ParseDDSketchProto(msg[:20]); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+}
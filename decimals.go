@@ -0,0 +1,40 @@
+package mathx
+
+// ScaleDecimals rescales the fixed-point amount v from `from` to `to` decimal places,
+// e.g. from 18 to 6 to convert a token amount between precisions.
+// Upscaling is exact and returns ErrOverflow if the result does not fit,
+// downscaling rounds according to mode.
+func ScaleDecimals(v Uint256, from, to uint8, mode RoundingMode) (Uint256, error) {
+	if to >= from {
+		for n := int(to - from); n > 0; n -= 19 {
+			k := n
+			if k > 19 {
+				k = 19
+			}
+			var carry uint64
+			v, carry = v.mulAdd64(pow10u64[k], 0)
+			if carry != 0 {
+				return Uint256{}, ErrOverflow
+			}
+		}
+		return v, nil
+	}
+
+	n := int(from - to)
+	if n > 77 {
+		// 10**n is more than twice any Uint256, so the quotient is 0 and v is below half.
+		if mode.roundUpCmp(!v.IsZero(), -1, false) {
+			return Uint256FromUint64(1), nil
+		}
+		return Uint256{}, nil
+	}
+	d := Uint256FromUint64(1)
+	for ; n > 0; n -= 19 {
+		k := n
+		if k > 19 {
+			k = 19
+		}
+		d, _ = d.mulAdd64(pow10u64[k], 0)
+	}
+	return v.DivRound(d, mode), nil
+}
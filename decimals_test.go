@@ -0,0 +1,57 @@
+package mathx
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestScaleDecimals(t *testing.T) {
+	wei := Uint256FromUint64(1_234_567_890_123_456_789) // 1.234567890123456789 with 18 decimals
+	testCases := []struct {
+		v        Uint256
+		from, to uint8
+		mode     RoundingMode
+		want     Uint256
+	}{
+		{wei, 18, 18, RoundDown, wei},
+		{wei, 18, 6, RoundDown, Uint256FromUint64(1_234_567)},
+		{wei, 18, 6, RoundUp, Uint256FromUint64(1_234_568)},
+		{wei, 18, 6, RoundHalfUp, Uint256FromUint64(1_234_568)},
+		{Uint256FromUint64(1_234_500), 6, 2, RoundHalfEven, Uint256FromUint64(123)},
+		{Uint256FromUint64(1_235_500), 6, 2, RoundHalfEven, Uint256FromUint64(124)},
+		{Uint256FromUint64(1_234_567), 6, 18, RoundDown, wei.Sub(Uint256FromUint64(890_123_456_789))},
+		{MaxUint256, 255, 0, RoundDown, Uint256{}},
+		{MaxUint256, 255, 0, RoundHalfUp, Uint256{}},
+		{Uint256FromUint64(1), 255, 0, RoundUp, Uint256FromUint64(1)},
+		{Uint256{}, 0, 255, RoundDown, Uint256{}},
+	}
+
+	for _, tc := range testCases {
+		got, err := ScaleDecimals(tc.v, tc.from, tc.to, tc.mode)
+		if err != nil || got != tc.want {
+			t.Fatalf("unexpected ScaleDecimals(%v, %d, %d); got %v, %v; want %v", tc.v, tc.from, tc.to, got, err, tc.want)
+		}
+	}
+}
+
+func TestScaleDecimalsBig(t *testing.T) {
+	v := NewUint256(NewUint128(0, 0x1234), NewUint128(0x5678, 0x9abc))
+	for n := uint8(0); n < 90; n++ {
+		p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+
+		want := new(big.Int).Mul(v.Big(), p)
+		got, err := ScaleDecimals(v, 0, n, RoundDown)
+		if fits := want.BitLen() <= 256; fits != (err == nil) || fits && got.Big().Cmp(want) != 0 {
+			t.Fatalf("unexpected upscale by %d; got %v, %v; want %v", n, got, err, want)
+		}
+		if err != nil && !errors.Is(err, ErrOverflow) {
+			t.Fatalf("unexpected error; got %v; want %v", err, ErrOverflow)
+		}
+
+		want.Quo(v.Big(), p)
+		if got, err := ScaleDecimals(v, n, 0, RoundDown); err != nil || got.Big().Cmp(want) != 0 {
+			t.Fatalf("unexpected downscale by %d; got %v, %v; want %v", n, got, err, want)
+		}
+	}
+}
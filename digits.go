@@ -0,0 +1,107 @@
+package mathx
+
+// Digits returns the digits of u in the given base, most significant first.
+// Zero has a single digit 0. It panics if base is not in [2, 256].
+func (u Uint128) Digits(base int) []uint8 {
+	checkDigitsBase(base)
+	var buf [128]uint8
+	i := len(buf)
+	for {
+		var r uint64
+		u, r = u.QuoRem64(uint64(base))
+		i--
+		buf[i] = uint8(r)
+		if u.IsZero() {
+			break
+		}
+	}
+	return append([]uint8(nil), buf[i:]...)
+}
+
+// DigitSum returns the sum of digits of u in the given base.
+// It panics if base is not in [2, 256].
+func (u Uint128) DigitSum(base int) uint64 {
+	checkDigitsBase(base)
+	var sum uint64
+	for !u.IsZero() {
+		var r uint64
+		u, r = u.QuoRem64(uint64(base))
+		sum += r
+	}
+	return sum
+}
+
+// Uint128FromDigits returns the value of digits in the given base, most significant first.
+// It returns a *SyntaxError with the index of a digit not less than base and ErrOverflow if the value does not fit.
+// It panics if base is not in [2, 256].
+func Uint128FromDigits(digits []uint8, base int) (Uint128, error) {
+	checkDigitsBase(base)
+	var u Uint128
+	for i, d := range digits {
+		if int(d) >= base {
+			return Uint128{}, &SyntaxError{Offset: i}
+		}
+		var c uint64
+		u, c = u.mulAdd64(uint64(base), uint64(d))
+		if c != 0 {
+			return Uint128{}, ErrOverflow
+		}
+	}
+	return u, nil
+}
+
+// Digits returns the digits of u in the given base, most significant first.
+// Zero has a single digit 0. It panics if base is not in [2, 256].
+func (u Uint256) Digits(base int) []uint8 {
+	checkDigitsBase(base)
+	var buf [256]uint8
+	i := len(buf)
+	for {
+		var r uint64
+		u, r = u.QuoRem64(uint64(base))
+		i--
+		buf[i] = uint8(r)
+		if u.IsZero() {
+			break
+		}
+	}
+	return append([]uint8(nil), buf[i:]...)
+}
+
+// DigitSum returns the sum of digits of u in the given base.
+// It panics if base is not in [2, 256].
+func (u Uint256) DigitSum(base int) uint64 {
+	checkDigitsBase(base)
+	var sum uint64
+	for !u.IsZero() {
+		var r uint64
+		u, r = u.QuoRem64(uint64(base))
+		sum += r
+	}
+	return sum
+}
+
+// Uint256FromDigits returns the value of digits in the given base, most significant first.
+// It returns a *SyntaxError with the index of a digit not less than base and ErrOverflow if the value does not fit.
+// It panics if base is not in [2, 256].
+func Uint256FromDigits(digits []uint8, base int) (Uint256, error) {
+	checkDigitsBase(base)
+	var u Uint256
+	for i, d := range digits {
+		if int(d) >= base {
+			return Uint256{}, &SyntaxError{Offset: i}
+		}
+		var c uint64
+		u, c = u.mulAdd64(uint64(base), uint64(d))
+		if c != 0 {
+			return Uint256{}, ErrOverflow
+		}
+	}
+	return u, nil
+}
+
+func checkDigitsBase(base int) {
+	if base < 2 || base > 256 {
+		panic("mathx: base must be in [2, 256]")
+	}
+}
@@ -0,0 +1,53 @@
+package mathx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestDigits(t *testing.T) {
+	u := NewUint128(0x1, 0x2)
+	for _, base := range []int{2, 10, 16, 97, 256} {
+		ds := u.Digits(base)
+		got, err := Uint128FromDigits(ds, base)
+		if err != nil || got != u {
+			t.Fatalf("unexpected roundtrip in base %d; got %v, %v; want %v", base, got, err, u)
+		}
+
+		// Uint256 must agree on values that fit into Uint128.
+		if ds256 := u.Uint256().Digits(base); !bytes.Equal(ds, ds256) {
+			t.Fatalf("unexpected Uint256 digits in base %d; got %v; want %v", base, ds256, ds)
+		}
+	}
+
+	if got := Uint128FromUint64(1234).Digits(10); !bytes.Equal(got, []uint8{1, 2, 3, 4}) {
+		t.Fatalf("unexpected digits; got %v", got)
+	}
+	if got := (Uint128{}).Digits(10); !bytes.Equal(got, []uint8{0}) {
+		t.Fatalf("unexpected digits of zero; got %v", got)
+	}
+	if got := MaxUint128.Digits(2); len(got) != 128 {
+		t.Fatalf("unexpected number of digits; got %v; want %v", len(got), 128)
+	}
+
+	if got := Uint128FromUint64(1234).DigitSum(10); got != 10 {
+		t.Fatalf("unexpected DigitSum; got %v; want %v", got, 10)
+	}
+	if got := NewUint256(MaxUint128, MaxUint128).DigitSum(2); got != 256 {
+		t.Fatalf("unexpected DigitSum; got %v; want %v", got, 256)
+	}
+}
+
+func TestFromDigitsErrors(t *testing.T) {
+	var synErr *SyntaxError
+	if _, err := Uint128FromDigits([]uint8{1, 10}, 10); !errors.As(err, &synErr) || synErr.Offset != 1 {
+		t.Fatalf("unexpected error; got %v; want offset 1", err)
+	}
+	if _, err := Uint128FromDigits(bytes.Repeat([]uint8{1}, 129), 2); err != ErrOverflow {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrOverflow)
+	}
+	if _, err := Uint256FromDigits(bytes.Repeat([]uint8{255}, 33), 256); err != ErrOverflow {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrOverflow)
+	}
+}
@@ -0,0 +1,261 @@
+package mathx
+
+import "math/bits"
+
+// Div128By64 returns the quotient and remainder of (hi, lo) divided by d:
+// q = (hi, lo)/d, r = (hi, lo)%d with the dividend bits' upper half in parameter hi
+// and the lower half in parameter lo.
+// It panics for d == 0 (division by zero) or d <= hi (quotient overflow), like bits.Div64.
+func Div128By64(hi, lo, d uint64) (q, r uint64) {
+	return bits.Div64(hi, lo, d)
+}
+
+// Div128By64OK is like Div128By64 but returns ok == false instead of panicking
+// when d == 0 or the quotient does not fit into 64 bits.
+func Div128By64OK(hi, lo, d uint64) (q, r uint64, ok bool) {
+	if d == 0 || hi >= d {
+		return 0, 0, false
+	}
+	q, r = bits.Div64(hi, lo, d)
+	return q, r, true
+}
+
+// Div128By64Full returns the full 128-bit quotient (qhi, qlo) and the remainder
+// of (hi, lo) divided by d. Unlike Div128By64 the quotient never overflows.
+// It panics for d == 0 (division by zero).
+func Div128By64Full(hi, lo, d uint64) (qhi, qlo, r uint64) {
+	qhi, r = bits.Div64(0, hi, d)
+	qlo, r = bits.Div64(r, lo, d)
+	return qhi, qlo, r
+}
+
+// Div128By64FullOK is like Div128By64Full but returns ok == false
+// instead of panicking when d == 0.
+func Div128By64FullOK(hi, lo, d uint64) (qhi, qlo, r uint64, ok bool) {
+	if d == 0 {
+		return 0, 0, 0, false
+	}
+	qhi, qlo, r = Div128By64Full(hi, lo, d)
+	return qhi, qlo, r, true
+}
+
+// divLimbs sets q = u / v and r = u % v for little-endian 64-bit limbs
+// using Knuth's Algorithm D. It requires 1 <= len(v) <= 4, len(u) <= 8,
+// v[len(v)-1] != 0, len(q) >= len(u)-len(v)+1 and len(r) >= len(v).
+//
+// See: Knuth, D.E. The Art of Computer Programming, Vol. 2, §4.3.1.
+func divLimbs(q, r, u, v []uint64) {
+	m, n := len(u), len(v)
+	for i := range q {
+		q[i] = 0
+	}
+	if m < n {
+		copy(r, u)
+		for i := m; i < n; i++ {
+			r[i] = 0
+		}
+		return
+	}
+
+	if n == 1 {
+		var rem uint64
+		for j := m - 1; j >= 0; j-- {
+			q[j], rem = bits.Div64(rem, u[j], v[0])
+		}
+		r[0] = rem
+		return
+	}
+
+	// Normalize, so the top bit of the divisor is set.
+	var unBuf [9]uint64
+	var vnBuf [4]uint64
+	un, vn := unBuf[:m+1], vnBuf[:n]
+
+	s := uint(bits.LeadingZeros64(v[n-1]))
+	for i := n - 1; i > 0; i-- {
+		vn[i] = v[i]<<s | v[i-1]>>(64-s)
+	}
+	vn[0] = v[0] << s
+
+	un[m] = u[m-1] >> (64 - s)
+	for i := m - 1; i > 0; i-- {
+		un[i] = u[i]<<s | u[i-1]>>(64-s)
+	}
+	un[0] = u[0] << s
+
+	for j := m - n; j >= 0; j-- {
+		// Estimate the quotient digit.
+		var qhat, rhat uint64
+		overflow := false
+		if un[j+n] >= vn[n-1] {
+			qhat = ^uint64(0)
+			var c uint64
+			rhat, c = bits.Add64(un[j+n-1], vn[n-1], 0)
+			overflow = c != 0
+		} else {
+			qhat, rhat = bits.Div64(un[j+n], un[j+n-1], vn[n-1])
+		}
+
+		for !overflow {
+			ph, pl := bits.Mul64(qhat, vn[n-2])
+			if ph < rhat || (ph == rhat && pl <= un[j+n-2]) {
+				break
+			}
+			qhat--
+			var c uint64
+			rhat, c = bits.Add64(rhat, vn[n-1], 0)
+			overflow = c != 0
+		}
+
+		// Multiply and subtract.
+		var k, borrow uint64
+		for i := 0; i < n; i++ {
+			ph, pl := bits.Mul64(qhat, vn[i])
+			var c uint64
+			pl, c = bits.Add64(pl, k, 0)
+			un[i+j], borrow = bits.Sub64(un[i+j], pl, borrow)
+			k = ph + c
+		}
+		un[j+n], borrow = bits.Sub64(un[j+n], k, borrow)
+
+		// Add back if the estimate was one too large.
+		if borrow != 0 {
+			qhat--
+			var c uint64
+			for i := 0; i < n; i++ {
+				un[i+j], c = bits.Add64(un[i+j], vn[i], c)
+			}
+			un[j+n] += c
+		}
+		q[j] = qhat
+	}
+
+	// Unnormalize the remainder.
+	for i := 0; i < n; i++ {
+		r[i] = un[i]>>s | un[i+1]<<(64-s)
+	}
+}
+
+// trimLimbs returns x without the most significant zero limbs.
+func trimLimbs(x []uint64) []uint64 {
+	for len(x) > 0 && x[len(x)-1] == 0 {
+		x = x[:len(x)-1]
+	}
+	return x
+}
+
+// div256by128 returns n / d and n % d.
+// It panics for d == 0 (division by zero).
+func div256by128(n Uint256, d Uint128) (Uint256, Uint128) {
+	if d.IsZero() {
+		panic("mathx: division by zero")
+	}
+	u := [4]uint64{n.lo.lo, n.lo.hi, n.hi.lo, n.hi.hi}
+	v := [2]uint64{d.lo, d.hi}
+	var q [4]uint64
+	var r [2]uint64
+	divLimbs(q[:], r[:], trimLimbs(u[:]), trimLimbs(v[:]))
+	return Uint256{hi: Uint128{hi: q[3], lo: q[2]}, lo: Uint128{hi: q[1], lo: q[0]}}, Uint128{hi: r[1], lo: r[0]}
+}
+
+// Div returns u / x. It panics for x == 0 (division by zero).
+func (u Uint128) Div(x Uint128) Uint128 {
+	q, _ := u.DivMod(x)
+	return q
+}
+
+// Mod returns u % x. It panics for x == 0 (division by zero).
+func (u Uint128) Mod(x Uint128) Uint128 {
+	_, r := u.DivMod(x)
+	return r
+}
+
+// DivMod returns u / x and u % x. It panics for x == 0 (division by zero).
+func (u Uint128) DivMod(x Uint128) (Uint128, Uint128) {
+	if x.hi == 0 {
+		if x.lo == 0 {
+			panic("mathx: division by zero")
+		}
+		q, r := u.QuoRem64(x.lo)
+		return q, Uint128FromUint64(r)
+	}
+	if u.Cmp(x) < 0 {
+		return Uint128{}, u
+	}
+
+	// The quotient fits into 64 bits, estimate it from the top limbs
+	// of the normalized operands, it is exact or one too large.
+	// See: Warren, H.S. Hacker's Delight, 2nd ed., §9-5.
+	n := uint(bits.LeadingZeros64(x.hi))
+	v := x.Lsh(n)
+	w := u.Rsh(1)
+	q, _ := bits.Div64(w.hi, w.lo, v.hi)
+	q >>= 63 - n
+	if q != 0 {
+		q--
+	}
+
+	r := u.Sub(x.Mul(Uint128FromUint64(q)))
+	if r.Cmp(x) >= 0 {
+		q++
+		r = r.Sub(x)
+	}
+	return Uint128FromUint64(q), r
+}
+
+// Div returns u / x. It panics for x == 0 (division by zero).
+func (u Uint256) Div(x Uint256) Uint256 {
+	q, _ := u.DivMod(x)
+	return q
+}
+
+// Mod returns u % x. It panics for x == 0 (division by zero).
+func (u Uint256) Mod(x Uint256) Uint256 {
+	_, r := u.DivMod(x)
+	return r
+}
+
+// DivMod returns u / x and u % x. It panics for x == 0 (division by zero).
+// Divisors that fit into 64 or 128 bits take faster paths.
+func (u Uint256) DivMod(x Uint256) (Uint256, Uint256) {
+	switch {
+	case x.hi.IsZero() && x.lo.hi == 0:
+		if x.lo.lo == 0 {
+			panic("mathx: division by zero")
+		}
+		q, r := u.QuoRem64(x.lo.lo)
+		return q, Uint256FromUint64(r)
+	case u.Cmp(x) < 0:
+		return Uint256{}, u
+	case x.hi.IsZero():
+		q, r := div256by128(u, x.lo)
+		return q, Uint256{lo: r}
+	}
+
+	n := [4]uint64{u.lo.lo, u.lo.hi, u.hi.lo, u.hi.hi}
+	d := [4]uint64{x.lo.lo, x.lo.hi, x.hi.lo, x.hi.hi}
+	var q, r [4]uint64
+	divLimbs(q[:], r[:], trimLimbs(n[:]), trimLimbs(d[:]))
+	return Uint256{hi: Uint128{hi: q[3], lo: q[2]}, lo: Uint128{hi: q[1], lo: q[0]}},
+		Uint256{hi: Uint128{hi: r[3], lo: r[2]}, lo: Uint128{hi: r[1], lo: r[0]}}
+}
+
+// DivRound returns u / x rounded according to mode.
+// It panics for x == 0 (division by zero).
+func (u Uint128) DivRound(x Uint128, mode RoundingMode) Uint128 {
+	q, r := u.DivMod(x)
+	if mode.roundUpCmp(!r.IsZero(), r.Cmp(x.Sub(r)), q.lo&1 == 1) {
+		q = q.Inc() // cannot overflow: q is max only for x == 1 and r == 0
+	}
+	return q
+}
+
+// DivRound returns u / x rounded according to mode.
+// It panics for x == 0 (division by zero).
+func (u Uint256) DivRound(x Uint256, mode RoundingMode) Uint256 {
+	q, r := u.DivMod(x)
+	if mode.roundUpCmp(!r.IsZero(), r.Cmp(x.Sub(r)), q.lo.lo&1 == 1) {
+		q = q.Inc()
+	}
+	return q
+}
@@ -0,0 +1,243 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestDiv128By64(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		hi, lo, d := rng.Uint64()>>uint(rng.Intn(64)), rng.Uint64(), rng.Uint64()>>uint(rng.Intn(64))
+		if d == 0 {
+			d = 1
+		}
+
+		n := NewUint128(hi, lo).Big()
+		wantQ, wantR := new(big.Int).QuoRem(n, new(big.Int).SetUint64(d), new(big.Int))
+
+		qhi, qlo, r := Div128By64Full(hi, lo, d)
+		if NewUint128(qhi, qlo).Big().Cmp(wantQ) != 0 || r != wantR.Uint64() {
+			t.Fatalf("unexpected Div128By64Full(%v, %v, %v); got %v %v %v", hi, lo, d, qhi, qlo, r)
+		}
+
+		q, r, ok := Div128By64OK(hi, lo, d)
+		if ok != (hi < d) {
+			t.Fatalf("unexpected ok for Div128By64OK(%v, %v, %v); got %v", hi, lo, d, ok)
+		}
+		if ok && (q != qlo || r != wantR.Uint64()) {
+			t.Fatalf("unexpected Div128By64OK(%v, %v, %v); got %v %v", hi, lo, d, q, r)
+		}
+	}
+
+	if q, r := Div128By64(1, 0, 2); q != 1<<63 || r != 0 {
+		t.Fatalf("unexpected Div128By64(1, 0, 2); got %v %v", q, r)
+	}
+	if _, _, ok := Div128By64OK(1, 0, 0); ok {
+		t.Fatal("division by zero must not be ok")
+	}
+	if _, _, _, ok := Div128By64FullOK(math.MaxUint64, 0, 0); ok {
+		t.Fatal("division by zero must not be ok")
+	}
+}
+
+func TestDiv256By128(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	word := func() uint64 {
+		switch rng.Intn(4) {
+		case 0:
+			return 0
+		case 1:
+			return math.MaxUint64
+		default:
+			return rng.Uint64()
+		}
+	}
+
+	for i := 0; i < 100000; i++ {
+		n := NewUint256(NewUint128(word(), word()), NewUint128(word(), word()))
+		d := NewUint128(word(), word())
+		if rng.Intn(2) == 0 {
+			d = NewUint128(0, word())
+		}
+		if d.IsZero() {
+			d = Uint128FromUint64(3)
+		}
+
+		q, r := div256by128(n, d)
+		wantQ, wantR := new(big.Int).QuoRem(n.Big(), d.Big(), new(big.Int))
+		if q.Big().Cmp(wantQ) != 0 || r.Big().Cmp(wantR) != 0 {
+			t.Fatalf("unexpected %v / %v; got %v, %v; want %v, %v", n, d, q, r, wantQ, wantR)
+		}
+	}
+}
+
+func TestUint128DivMod(t *testing.T) {
+	edge := []uint64{0, 1, 2, 3, 1<<32 - 1, 1 << 32, 1<<63 - 1, 1 << 63, 1<<64 - 1}
+	var vs []Uint128
+	for _, hi := range edge {
+		for _, lo := range edge {
+			vs = append(vs, NewUint128(hi, lo))
+		}
+	}
+
+	for _, u := range vs {
+		for _, x := range vs {
+			if x.IsZero() {
+				continue
+			}
+			wq, wr := new(big.Int).QuoRem(u.Big(), x.Big(), new(big.Int))
+			q, r := u.DivMod(x)
+			if q.Big().Cmp(wq) != 0 || r.Big().Cmp(wr) != 0 {
+				t.Fatalf("unexpected %v.DivMod(%v); got %v, %v; want %v, %v", u, x, q, r, wq, wr)
+			}
+			if u.Div(x) != q || u.Mod(x) != r {
+				t.Fatalf("unexpected Div or Mod for %v, %v", u, x)
+			}
+		}
+	}
+}
+
+func TestUint128DivByZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("must panic")
+		}
+	}()
+	Uint128FromUint64(1).Div(Uint128{})
+}
+
+func TestUint256DivMod(t *testing.T) {
+	limbs := []uint64{0, 1, 3, 1<<63 - 1, 1 << 63, 1<<64 - 1}
+	var vs []Uint256
+	for _, a := range limbs {
+		for _, b := range limbs {
+			// Spread the limbs over every width from 64 to 256 bits.
+			vs = append(vs,
+				NewUint256(Uint128{}, NewUint128(0, a)),
+				NewUint256(Uint128{}, NewUint128(a, b)),
+				NewUint256(NewUint128(0, a), NewUint128(b, a)),
+				NewUint256(NewUint128(a, b), NewUint128(b, 7)),
+			)
+		}
+	}
+
+	for _, u := range vs {
+		for _, x := range vs {
+			if x.IsZero() {
+				continue
+			}
+			wq, wr := new(big.Int).QuoRem(u.Big(), x.Big(), new(big.Int))
+			q, r := u.DivMod(x)
+			if q.Big().Cmp(wq) != 0 || r.Big().Cmp(wr) != 0 {
+				t.Fatalf("unexpected %v.DivMod(%v); got %v, %v; want %v, %v", u, x, q, r, wq, wr)
+			}
+			if u.Div(x) != q || u.Mod(x) != r {
+				t.Fatalf("unexpected Div or Mod for %v, %v", u, x)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("must panic")
+		}
+	}()
+	Uint256FromUint64(1).Div(Uint256{})
+}
+
+func TestQuoRem64(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		u := NewUint128(rng.Uint64()>>uint(rng.Intn(64)), rng.Uint64())
+		d := rng.Uint64() >> uint(rng.Intn(64))
+		if d == 0 {
+			continue
+		}
+
+		q, r := u.QuoRem64(d)
+		wq, wr := new(big.Int).QuoRem(u.Big(), new(big.Int).SetUint64(d), new(big.Int))
+		if q.Big().Cmp(wq) != 0 || r != wr.Uint64() {
+			t.Fatalf("unexpected %v.QuoRem64(%v); got %v, %v; want %v, %v", u, d, q, r, wq, wr)
+		}
+
+		u256 := NewUint256(u, u)
+		q256, r := u256.QuoRem64(d)
+		wq.QuoRem(u256.Big(), new(big.Int).SetUint64(d), wr)
+		if q256.Big().Cmp(wq) != 0 || r != wr.Uint64() {
+			t.Fatalf("unexpected %v.QuoRem64(%v); got %v, %v; want %v, %v", u256, d, q256, r, wq, wr)
+		}
+	}
+}
+
+func BenchmarkUint128QuoRem64(b *testing.B) {
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	var sink uint64
+	for i := 0; i < b.N; i++ {
+		_, r := u.QuoRem64(1e19)
+		sink += r
+	}
+	_ = sink
+}
+
+func BenchmarkUint128DivMod(b *testing.B) {
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	x := NewUint128(1, 0x1234)
+	var sink Uint128
+	for i := 0; i < b.N; i++ {
+		_, r := u.DivMod(x)
+		sink = sink.Add(r)
+	}
+	_ = sink
+}
+
+func TestDivRound(t *testing.T) {
+	modes := []RoundingMode{RoundDown, RoundUp, RoundHalfUp, RoundHalfEven}
+	testCases := []struct {
+		u, x uint64
+		want [4]uint64 // per mode
+	}{
+		{10, 4, [4]uint64{2, 3, 3, 2}},
+		{14, 4, [4]uint64{3, 4, 4, 4}},
+		{13, 4, [4]uint64{3, 4, 3, 3}},
+		{15, 4, [4]uint64{3, 4, 4, 4}},
+		{12, 4, [4]uint64{3, 3, 3, 3}},
+		{1, 3, [4]uint64{0, 1, 0, 0}},
+		{2, 3, [4]uint64{0, 1, 1, 1}},
+	}
+	for _, tc := range testCases {
+		for i, mode := range modes {
+			got := NewUint128(0, tc.u).DivRound(NewUint128(0, tc.x), mode)
+			if got != NewUint128(0, tc.want[i]) {
+				t.Fatalf("unexpected %d/%d in mode %d; got %v; want %v", tc.u, tc.x, mode, got, tc.want[i])
+			}
+			got256 := Uint256FromUint64(tc.u).DivRound(Uint256FromUint64(tc.x), mode)
+			if got256 != Uint256FromUint64(tc.want[i]) {
+				t.Fatalf("unexpected Uint256 %d/%d in mode %d; got %v; want %v", tc.u, tc.x, mode, got256, tc.want[i])
+			}
+		}
+	}
+
+	// Wide operands against big.Int.
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	max256 := NewUint256(max, max)
+	nums := []Uint256{max256, max256.Dec(), NewUint256(NewUint128(1, 0), Uint128{}), NewUint256(Uint128{}, max)}
+	dens := []Uint256{NewUint256(max, Uint128{}), NewUint256(Uint128{}, NewUint128(1, 1)), Uint256FromUint64(3), max256}
+	for _, n := range nums {
+		for _, d := range dens {
+			q, r := new(big.Int).QuoRem(n.Big(), d.Big(), new(big.Int))
+			twice := new(big.Int).Lsh(r, 1)
+			if c := twice.Cmp(d.Big()); c > 0 || c == 0 && q.Bit(0) == 1 {
+				q.Add(q, big.NewInt(1))
+			}
+			if got := n.DivRound(d, RoundHalfEven); got.Big().Cmp(q) != 0 {
+				t.Fatalf("unexpected %v/%v; got %v; want %v", n, d, got, q)
+			}
+		}
+	}
+	if got := max.DivRound(NewUint128(0, 1), RoundUp); got != max {
+		t.Fatalf("unexpected max/1; got %v", got)
+	}
+}
@@ -21,6 +21,14 @@ var (
 
 // Double float by T.J. Dekker.
 //
+// The Go compiler may fuse a multiplication and an addition into a single
+// FMA instruction on some architectures (arm64, ppc64, s390x, riscv64),
+// so results may differ in the last bits between amd64 and arm64.
+// Build with the mathx_deterministic tag to forbid fusion: every operation
+// is then rounded individually as in IEEE 754 and results are bit-exact
+// across architectures. Functions based on the math package (Sqrt2, Exp, Ln2)
+// are deterministic only as far as the math package is.
+//
 // See: Dekker, T.J. A floating-point technique for extending the available precision. Numer. Math. 18, 224–242 (1971). https://doi.org/10.1007/BF01397083
 type Double struct{ hi, lo float64 }
 
@@ -44,18 +52,18 @@ func (d Double) LT(x Double) bool    { return d.hi < x.hi || (d.hi == x.hi && d.
 func (d Double) GE(x Double) bool    { return d.hi > x.hi || (d.hi == x.hi && d.lo >= x.lo) }
 func (d Double) LE(x Double) bool    { return d.hi < x.hi || (d.hi == x.hi && d.lo <= x.lo) }
 
+// Parts returns the high and low components of d.
+func (d Double) Parts() (float64, float64) { return d.hi, d.lo }
+
 func eq21(x Double, f float64) bool { return x.hi == f && x.lo == 0. }
 func le21(x Double, f float64) bool { return x.hi < f || (x.hi == f && x.lo <= 0.) }
 
 // x ** 2
 func Sqr2(x Double) Double {
 	S := oneSqr(x.hi)
-	c := x.hi * x.lo
+	c := fmul(x.hi, x.lo)
 	S.lo += c + c
-	return Double{
-		hi: S.hi + S.lo,
-		lo: S.lo - (x.hi - S.hi),
-	}
+	return quickTwoSum(S.hi, S.lo)
 }
 
 // x ** 0.5
@@ -63,10 +71,7 @@ func Sqrt2(x Double) Double {
 	s := math.Sqrt(x.hi)
 	t := oneSqr(s)
 	e := (x.hi - t.hi - t.lo + x.lo) * 0.5 / s
-	return Double{
-		hi: s + e,
-		lo: e - (x.hi - s),
-	}
+	return quickTwoSum(s, e)
 }
 
 var padeCoef = []float64{1, 272, 36720, 3255840, 211629600, 10666131840, 430200650880, 14135164243200,
@@ -93,7 +98,7 @@ func Exp(x Double) Double {
 		if i%2 == 0 {
 			s = 1.0
 		}
-		V = addDF(mul22(V, x), padeCoef[i]*s)
+		V = addDF(mul22(V, x), fmul(padeCoef[i], s))
 	}
 	x = mulDFpow2(div22(U, V), int(n))
 	return x
@@ -126,6 +131,15 @@ func Pow22(base Double, exp Double) Double {
 
 const splitter = 1<<27 + 1 // Veltkamp’s splitter
 
+// quickTwoSum requires |a| >= |b|.
+func quickTwoSum(a, b float64) Double {
+	s := a + b
+	return Double{
+		hi: s,
+		lo: b - (s - a),
+	}
+}
+
 func twoSum(a, b float64) Double {
 	s := a + b
 	a1 := s - b
@@ -136,28 +150,28 @@ func twoSum(a, b float64) Double {
 }
 
 func twoProd(a, b float64) Double {
-	t := splitter * a
+	t := fmul(splitter, a)
 	ah := t + (a - t)
 	al := a - ah
-	t = splitter * b
+	t = fmul(splitter, b)
 	bh := t + (b - t)
 	bl := b - bh
-	t = a * b
+	t = fmul(a, b)
 	return Double{
 		hi: t,
-		lo: ((ah*bh - t) + ah*bl + al*bh) + al*bl,
+		lo: ((fmul(ah, bh) - t) + fmul(ah, bl) + fmul(al, bh)) + fmul(al, bl),
 	}
 }
 
 func oneSqr(a float64) Double {
-	t := splitter * a
+	t := fmul(splitter, a)
 	ah := t + (a - t)
 	al := a - ah
-	t = a * a
-	hl := al * ah
+	t = fmul(a, a)
+	hl := fmul(al, ah)
 	return Double{
 		hi: t,
-		lo: ((ah*ah - t) + hl + hl) + al*al,
+		lo: ((fmul(ah, ah) - t) + hl + hl) + fmul(al, al),
 	}
 }
 
@@ -168,10 +182,7 @@ func add22(x, y Double) Double {
 	vh := s.hi + c
 	vl := c - (vh - s.hi)
 	c = vl + e.lo
-	return Double{
-		hi: vh + c,
-		lo: c - (x.hi - vh),
-	}
+	return quickTwoSum(vh, c)
 }
 
 func sub22(x, y Double) Double {
@@ -181,62 +192,41 @@ func sub22(x, y Double) Double {
 	vh := s.hi + c
 	vl := c - (vh - s.hi)
 	c = vl + e.lo
-	return Double{
-		hi: vh + c,
-		lo: c - (x.hi - vh),
-	}
+	return quickTwoSum(vh, c)
 }
 
 func mul22(x, y Double) Double {
 	s := twoProd(x.hi, y.hi)
-	s.lo += x.hi*y.lo + x.lo*y.hi
-	return Double{
-		hi: s.hi + s.lo,
-		lo: s.lo - (x.hi - s.hi),
-	}
+	s.lo += fmul(x.hi, y.lo) + fmul(x.lo, y.hi)
+	return quickTwoSum(s.hi, s.lo)
 }
 
 func div22(x, y Double) Double {
 	s := x.hi / y.hi
 	t := twoProd(s, y.hi)
-	e := ((((x.hi - t.hi) - t.lo) + x.lo) - s*y.lo) / y.hi
-	return Double{
-		hi: s + e,
-		lo: e - (x.hi - s),
-	}
+	e := ((((x.hi - t.hi) - t.lo) + x.lo) - fmul(s, y.lo)) / y.hi
+	return quickTwoSum(s, e)
 }
 
 // x + f
 func addDF(x Double, f float64) Double {
 	s := twoSum(x.hi, f)
 	s.lo += x.lo
-	return Double{
-		hi: s.hi + s.lo,
-		lo: s.lo - (x.hi - s.hi),
-	}
+	return quickTwoSum(s.hi, s.lo)
 }
 
 // x - f
 func subDF(x Double, f float64) Double {
 	s := twoSum(x.hi, -f)
 	s.lo += x.lo
-	return Double{
-		hi: s.hi + s.lo,
-		lo: s.lo - (x.hi - s.hi),
-	}
+	return quickTwoSum(s.hi, s.lo)
 }
 
 // x * f
 func mulDF(x Double, f float64) Double {
 	c := twoProd(x.hi, f)
-	cl := x.lo * f
-	th := c.hi + cl
-	x.lo = cl - (th - c.hi)
-	cl = x.lo + c.lo
-	return Double{
-		hi: th + cl,
-		lo: cl - (x.hi - th),
-	}
+	c.lo += fmul(x.lo, f)
+	return quickTwoSum(c.hi, c.lo)
 }
 
 // x / f
@@ -245,10 +235,7 @@ func divDF(x Double, f float64) Double {
 	p := twoProd(th, f)
 	d := twoSum(x.hi, -p.hi)
 	tl := (d.hi + (d.lo + (x.lo - p.lo))) / f
-	return Double{
-		hi: th + tl,
-		lo: tl - (x.hi - th),
-	}
+	return quickTwoSum(th, tl)
 }
 
 // |x|
@@ -268,23 +255,13 @@ func inv2(x Double) Double {
 	s := 1. / xh
 	x = mulDF(x, s)
 	zl := (1. - x.hi - x.lo) / xh
-	return Double{
-		hi: s + zl,
-		lo: zl - (x.hi - s),
-	}
+	return quickTwoSum(s, zl)
 }
 
 // x * 2 ** n
 func mulDFpow2(x Double, n int) Double {
-	if n < 0 {
-		n = -n
-	}
-	c := float64(int(1) << n)
-	if n < 0 {
-		c = 1 / c
-	}
-	x.hi = x.hi * c
-	x.lo = x.lo * c
+	x.hi = math.Ldexp(x.hi, n)
+	x.lo = math.Ldexp(x.lo, n)
 	return x
 }
 
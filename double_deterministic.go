@@ -0,0 +1,8 @@
+//go:build mathx_deterministic
+
+package mathx
+
+// fmul returns a * b rounded to float64.
+// The explicit conversion prevents fusing the product with a subsequent addition.
+// See: https://go.dev/ref/spec#Floating_point_operators
+func fmul(a, b float64) float64 { return float64(a * b) }
@@ -0,0 +1,37 @@
+//go:build mathx_deterministic
+
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestTwoProdDeterministic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		a := math.Ldexp(rng.Float64()+0.5, rng.Intn(200)-100)
+		b := math.Ldexp(rng.Float64()+0.5, rng.Intn(200)-100)
+		if rng.Intn(2) == 0 {
+			b = -b
+		}
+
+		want := new(big.Float).SetPrec(256).Mul(big.NewFloat(a), big.NewFloat(b))
+		checkExact(t, "twoProd", a, b, twoProd(a, b), want)
+		checkExact(t, "oneSqr", a, a, oneSqr(a), new(big.Float).SetPrec(256).Mul(big.NewFloat(a), big.NewFloat(a)))
+	}
+}
+
+// checkExact fails unless got.hi is the rounded product a*b and got.hi + got.lo is exactly want.
+func checkExact(t *testing.T, name string, a, b float64, got Double, want *big.Float) {
+	t.Helper()
+	if hi := a * b; got.hi != hi {
+		t.Fatalf("unexpected %s(%v, %v) high part; got %v; want %v", name, a, b, got.hi, hi)
+	}
+	sum := new(big.Float).SetPrec(256).Add(big.NewFloat(got.hi), big.NewFloat(got.lo))
+	if sum.Cmp(want) != 0 {
+		t.Fatalf("unexpected %s(%v, %v); got %v + %v; want %v", name, a, b, got.hi, got.lo, want)
+	}
+}
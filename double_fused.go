@@ -0,0 +1,6 @@
+//go:build !mathx_deterministic
+
+package mathx
+
+// fmul returns a * b. The product may be fused with a subsequent addition.
+func fmul(a, b float64) float64 { return a * b }
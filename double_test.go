@@ -0,0 +1,35 @@
+package mathx
+
+import "testing"
+
+func TestDoubleArith(t *testing.T) {
+	a, b := DoubleFromFloat(3), DoubleFromFloat(2)
+
+	testCases := []struct {
+		name string
+		got  Double
+		want float64
+	}{
+		{"add", a.Add(b), 5},
+		{"sub", a.Sub(b), 1},
+		{"mul", a.Mul(b), 6},
+		{"div", a.Div(b), 1.5},
+		{"inv", b.Inv(), 0.5},
+		{"sqr", a.Sqr(), 9},
+		{"sqrt", Sqrt2(DoubleFromFloat(16)), 4},
+		{"pow2", mulDFpow2(a, -1), 1.5},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.got.ToFloat64(); got != tc.want {
+			t.Fatalf("unexpected %s result; got %v; want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// 0.1 + 0.2 keeps the rounding error of the sum in the low part.
+	x, y := 0.1, 0.2
+	s := DoubleFromFloat(x).Add(DoubleFromFloat(y))
+	if s.hi != x+y || s.lo == 0 {
+		t.Fatalf("unexpected sum; got %+v", s)
+	}
+}
@@ -0,0 +1,77 @@
+package mathx
+
+import "math"
+
+// doubleEps bounds the relative rounding error of one Double operation.
+const doubleEps = 0x1p-104
+
+// TrackedDouble is a Double with a running bound on its absolute error,
+// for diagnosing cancellation in Double computations.
+// Every operation propagates the error bounds of its operands to first order
+// and adds its own rounding error, so the bound is an estimate, not a proof.
+// It is several times slower than Double and meant for debugging.
+type TrackedDouble struct {
+	v   Double
+	err float64
+}
+
+// NewTrackedDouble returns d as an exact TrackedDouble.
+func NewTrackedDouble(d Double) TrackedDouble { return TrackedDouble{v: d} }
+
+// Double returns the value of t.
+func (t TrackedDouble) Double() Double { return t.v }
+
+// ErrorBound returns the estimated bound on the absolute error of t.
+func (t TrackedDouble) ErrorBound() float64 { return t.err }
+
+// RelError returns the estimated bound on the relative error of t,
+// +Inf if t is zero with a non-zero error bound.
+func (t TrackedDouble) RelError() float64 {
+	if t.err == 0 {
+		return 0
+	}
+	return t.err / math.Abs(t.v.hi)
+}
+
+// Bits returns the estimated number of correct significant bits of t,
+// 106 for full Double accuracy and 53 for float64 accuracy.
+func (t TrackedDouble) Bits() int {
+	rel := t.RelError()
+	switch {
+	case rel <= 0x1p-106:
+		return 106
+	case !(rel < 1):
+		return 0
+	default:
+		return int(-math.Log2(rel))
+	}
+}
+
+func (t TrackedDouble) Add(x TrackedDouble) TrackedDouble {
+	r := t.v.Add(x.v)
+	return TrackedDouble{v: r, err: t.err + x.err + doubleEps*math.Abs(r.hi)}
+}
+
+func (t TrackedDouble) Sub(x TrackedDouble) TrackedDouble {
+	r := t.v.Sub(x.v)
+	return TrackedDouble{v: r, err: t.err + x.err + doubleEps*math.Abs(r.hi)}
+}
+
+func (t TrackedDouble) Mul(x TrackedDouble) TrackedDouble {
+	r := t.v.Mul(x.v)
+	err := math.Abs(t.v.hi)*x.err + math.Abs(x.v.hi)*t.err + t.err*x.err
+	return TrackedDouble{v: r, err: err + doubleEps*math.Abs(r.hi)}
+}
+
+// Div returns t / x. The error bound is +Inf if the error of x reaches its value.
+func (t TrackedDouble) Div(x TrackedDouble) TrackedDouble {
+	r := t.v.Div(x.v)
+	d := math.Abs(x.v.hi)
+	if x.err >= d {
+		return TrackedDouble{v: r, err: math.Inf(1)}
+	}
+	err := (t.err + math.Abs(r.hi)*x.err) / (d - x.err)
+	return TrackedDouble{v: r, err: err + doubleEps*math.Abs(r.hi)}
+}
+
+func (t TrackedDouble) Neg() TrackedDouble { return TrackedDouble{v: t.v.Neg(), err: t.err} }
@@ -0,0 +1,41 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrackedDouble(t *testing.T) {
+	one := NewTrackedDouble(DoubleOne)
+	if one.Bits() != 106 || one.ErrorBound() != 0 || one.RelError() != 0 {
+		t.Fatalf("unexpected exact value; got %v bits, %v", one.Bits(), one.ErrorBound())
+	}
+
+	// Well-conditioned arithmetic keeps nearly full accuracy.
+	x := one.Div(NewTrackedDouble(DoubleFromFloat(3))).Mul(NewTrackedDouble(DoubleFromFloat(3)))
+	if got := x.Bits(); got < 100 {
+		t.Fatalf("unexpected bits of well-conditioned result; got %v", got)
+	}
+	if got := x.Double().ToFloat64(); got != 1 {
+		t.Fatalf("unexpected value; got %v; want %v", got, 1)
+	}
+
+	// Cancellation loses the bits of the large operand.
+	big := NewTrackedDouble(DoubleFromFloat(1e20)).Mul(one.Div(NewTrackedDouble(DoubleFromFloat(3))))
+	y := big.Add(one).Sub(big)
+	if got := y.Bits(); got < 30 || got > 45 {
+		t.Fatalf("unexpected bits after cancellation; got %v", got)
+	}
+	if math.Abs(y.Double().ToFloat64()-1) > y.ErrorBound() {
+		t.Fatalf("error %v exceeds bound %v", y.Double().ToFloat64()-1, y.ErrorBound())
+	}
+	if got := y.Neg(); got.ErrorBound() != y.ErrorBound() || !got.Double().Equal(y.Double().Neg()) {
+		t.Fatalf("unexpected Neg; got %v", got)
+	}
+
+	// Division by a value lost in its error has no accuracy.
+	z := one.Div(big.Sub(big))
+	if !math.IsInf(z.ErrorBound(), 1) || z.Bits() != 0 {
+		t.Fatalf("unexpected division by noise; got %v bits, %v", z.Bits(), z.ErrorBound())
+	}
+}
@@ -0,0 +1,40 @@
+package mathx
+
+import (
+	"math"
+	"time"
+)
+
+// AddDuration returns a+b or ErrOverflow if the sum does not fit into time.Duration.
+func AddDuration(a, b time.Duration) (time.Duration, error) {
+	sum := a + b
+	if (sum > a) != (b > 0) {
+		return 0, ErrOverflow
+	}
+	return sum, nil
+}
+
+// MulDuration returns d*n or ErrOverflow if the product does not fit into time.Duration.
+func MulDuration(d time.Duration, n int64) (time.Duration, error) {
+	if d == 0 || n == 0 {
+		return 0, nil
+	}
+	p := d * time.Duration(n)
+	if p/time.Duration(n) != d || (d == -1 && n == math.MinInt64) || (n == -1 && d == math.MinInt64) {
+		return 0, ErrOverflow
+	}
+	return p, nil
+}
+
+// DurationFromFloatSeconds returns s seconds rounded to the nearest nanosecond.
+// It returns ErrNaN for NaN and ErrOverflow if the value does not fit into time.Duration.
+func DurationFromFloatSeconds(s float64) (time.Duration, error) {
+	ns := math.Round(s * 1e9)
+	switch {
+	case math.IsNaN(ns):
+		return 0, ErrNaN
+	case !(ns >= math.MinInt64 && ns < math.MaxInt64): // MaxInt64 rounds to 2^63
+		return 0, ErrOverflow
+	}
+	return time.Duration(ns), nil
+}
@@ -0,0 +1,76 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAddDuration(t *testing.T) {
+	const max, min = time.Duration(math.MaxInt64), time.Duration(math.MinInt64)
+	testCases := []struct {
+		a, b time.Duration
+		want time.Duration
+		err  error
+	}{
+		{time.Second, time.Second, 2 * time.Second, nil},
+		{max, 0, max, nil},
+		{max, 1, 0, ErrOverflow},
+		{max, min, -1, nil},
+		{min, -1, 0, ErrOverflow},
+		{min, time.Second, min + time.Second, nil},
+	}
+	for _, tc := range testCases {
+		if got, err := AddDuration(tc.a, tc.b); got != tc.want || err != tc.err {
+			t.Fatalf("unexpected AddDuration(%d, %d); got %d, %v; want %d, %v", tc.a, tc.b, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestMulDuration(t *testing.T) {
+	const max, min = time.Duration(math.MaxInt64), time.Duration(math.MinInt64)
+	testCases := []struct {
+		d    time.Duration
+		n    int64
+		want time.Duration
+		err  error
+	}{
+		{time.Second, 60, time.Minute, nil},
+		{time.Hour, 2562047, 2562047 * time.Hour, nil},
+		{time.Hour, 2562048, 0, ErrOverflow},
+		{max, -1, -max, nil},
+		{min, -1, 0, ErrOverflow},
+		{-1, math.MinInt64, 0, ErrOverflow},
+		{min, 1, min, nil},
+		{max, 0, 0, nil},
+		{1 << 32, 1 << 31, 0, ErrOverflow},
+	}
+	for _, tc := range testCases {
+		if got, err := MulDuration(tc.d, tc.n); got != tc.want || err != tc.err {
+			t.Fatalf("unexpected MulDuration(%d, %d); got %d, %v; want %d, %v", tc.d, tc.n, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestDurationFromFloatSeconds(t *testing.T) {
+	testCases := []struct {
+		s    float64
+		want time.Duration
+		err  error
+	}{
+		{1.5, 1500 * time.Millisecond, nil},
+		{-0.25, -250 * time.Millisecond, nil},
+		{1e-10, 0, nil},
+		{6e-10, 1, nil},
+		{9.2e9, 9.2e18, nil},
+		{9.3e9, 0, ErrOverflow},
+		{-9.3e9, 0, ErrOverflow},
+		{InfPos, 0, ErrOverflow},
+		{NaN, 0, ErrNaN},
+	}
+	for _, tc := range testCases {
+		if got, err := DurationFromFloatSeconds(tc.s); got != tc.want || err != tc.err {
+			t.Fatalf("unexpected DurationFromFloatSeconds(%v); got %d, %v; want %d, %v", tc.s, got, err, tc.want, tc.err)
+		}
+	}
+}
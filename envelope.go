@@ -0,0 +1,121 @@
+package mathx
+
+import "fmt"
+
+// Kind identifies the type of a value in an envelope.
+type Kind uint8
+
+// Kinds of values supported by AppendEnvelope and DecodeAny.
+const (
+	KindUint128 Kind = iota + 1
+	KindUint256
+	KindHistogram
+	KindCountMinSketch
+	KindHyperLogLog
+	KindTDigest
+	KindDDSketch
+)
+
+// The envelope is a magic byte, a format version, a kind and the payload.
+// The payload of each kind is its MarshalBinary encoding, or AppendMergingDigest
+// for TDigest and AppendProto for DDSketch. A change to any payload layout
+// bumps envelopeVersion, so data written today stays readable.
+//
+// Version 2 adds the sums to the Histogram payload.
+const (
+	envelopeMagic   = 0xd7
+	envelopeVersion = 2
+	envelopeHeader  = 3
+)
+
+// AppendEnvelope appends v with a versioned header to dst.
+// v must be Uint128, Uint256, *Histogram, *CountMinSketch,
+// *HyperLogLog, *TDigest or *DDSketch.
+func AppendEnvelope(dst []byte, v interface{}) ([]byte, error) {
+	var kind Kind
+	var payload []byte
+	var err error
+	switch v := v.(type) {
+	case Uint128:
+		kind = KindUint128
+		payload, err = v.MarshalBinary()
+	case Uint256:
+		kind = KindUint256
+		payload, err = v.MarshalBinary()
+	case *Histogram:
+		kind = KindHistogram
+		payload, err = v.MarshalBinary()
+	case *CountMinSketch:
+		kind = KindCountMinSketch
+		payload, err = v.MarshalBinary()
+	case *HyperLogLog:
+		kind = KindHyperLogLog
+		payload, err = v.MarshalBinary()
+	case *TDigest:
+		kind = KindTDigest
+		payload = v.AppendMergingDigest(nil)
+	case *DDSketch:
+		kind = KindDDSketch
+		payload = v.AppendProto(nil)
+	default:
+		return dst, fmt.Errorf("mathx: unsupported envelope type %T", v)
+	}
+	if err != nil {
+		return dst, err
+	}
+	dst = append(dst, envelopeMagic, envelopeVersion, byte(kind))
+	return append(dst, payload...), nil
+}
+
+// DecodeAny decodes a value written by AppendEnvelope and returns its kind.
+// The value has the same type that was passed to AppendEnvelope.
+// It returns ErrVersion for data written by a newer format version
+// and ErrInvalidEncoding for malformed data.
+func DecodeAny(b []byte) (Kind, interface{}, error) {
+	if len(b) < envelopeHeader || b[0] != envelopeMagic {
+		return 0, nil, ErrInvalidEncoding
+	}
+	if b[1] == 0 || b[1] > envelopeVersion {
+		return 0, nil, ErrVersion
+	}
+	kind, payload := Kind(b[2]), b[envelopeHeader:]
+
+	var v interface{}
+	var err error
+	switch kind {
+	case KindUint128:
+		var u Uint128
+		err = u.UnmarshalBinary(payload)
+		v = u
+	case KindUint256:
+		var u Uint256
+		err = u.UnmarshalBinary(payload)
+		v = u
+	case KindHistogram:
+		h := NewHistogram()
+		header := histogramHeader
+		if b[1] == 1 {
+			header = histogramHeaderV1
+		}
+		err = h.unmarshalBinary(payload, header)
+		v = h
+	case KindCountMinSketch:
+		s := &CountMinSketch{}
+		err = s.UnmarshalBinary(payload)
+		v = s
+	case KindHyperLogLog:
+		h := &HyperLogLog{}
+		err = h.UnmarshalBinary(payload)
+		v = h
+	case KindTDigest:
+		v, err = ParseMergingDigest(payload)
+	case KindDDSketch:
+		v, err = ParseDDSketchProto(payload)
+	default:
+		return 0, nil, ErrInvalidEncoding
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return kind, v, nil
+}
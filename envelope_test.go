@@ -0,0 +1,106 @@
+package mathx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnvelope(t *testing.T) {
+	h := NewHistogram()
+	for i := 0; i < 2000; i++ {
+		h.Update(float64(i))
+	}
+	cms := NewCountMinSketch(16, 4)
+	cms.Add(1, 10)
+	hll := NewHyperLogLog(4)
+	hll.Add(42)
+	td := NewTDigest(20)
+	td.Add(1)
+	dd := NewDDSketch(0.01)
+	dd.Add(1)
+
+	values := []struct {
+		kind Kind
+		v    interface{}
+	}{
+		{KindUint128, NewUint128(1, 2)},
+		{KindUint256, NewUint256(NewUint128(1, 2), NewUint128(3, 4))},
+		{KindHistogram, h},
+		{KindCountMinSketch, cms},
+		{KindHyperLogLog, hll},
+		{KindTDigest, td},
+		{KindDDSketch, dd},
+	}
+	for _, tc := range values {
+		b, err := AppendEnvelope(nil, tc.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b[0] != envelopeMagic || b[1] != envelopeVersion || Kind(b[2]) != tc.kind {
+			t.Fatalf("unexpected header: % x", b[:3])
+		}
+
+		kind, v, err := DecodeAny(b)
+		if err != nil {
+			t.Fatalf("kind %d: %v", tc.kind, err)
+		}
+		if kind != tc.kind || reflect.TypeOf(v) != reflect.TypeOf(tc.v) {
+			t.Fatalf("unexpected value; got %d %T; want %d %T", kind, v, tc.kind, tc.v)
+		}
+	}
+
+	b, _ := AppendEnvelope(nil, h)
+	_, v, _ := DecodeAny(b)
+	got := v.(*Histogram)
+	if got.res.Count() != 2000 || got.Quantile(0.5) != h.Quantile(0.5) || got.Quantile(1) != 1999 {
+		t.Fatalf("unexpected histogram: count %v, median %v", got.res.Count(), got.Quantile(0.5))
+	}
+}
+
+func TestEnvelopeHistogramV1(t *testing.T) {
+	h := NewHistogram()
+	for i := 0; i < 100; i++ {
+		h.Update(float64(i))
+	}
+	b, _ := AppendEnvelope(nil, h)
+
+	// Version 1 payloads have no sums after min, max and count.
+	v1 := append([]byte{envelopeMagic, 1, byte(KindHistogram)}, b[envelopeHeader:envelopeHeader+histogramHeaderV1]...)
+	v1 = append(v1, b[envelopeHeader+histogramHeader:]...)
+	_, v, err := DecodeAny(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.(*Histogram)
+	if got.Count() != 100 || got.Quantile(1) != 99 || got.Sum() != 0 {
+		t.Fatalf("unexpected histogram: count %v, max %v, sum %v", got.Count(), got.Quantile(1), got.Sum())
+	}
+
+	_, v, _ = DecodeAny(b)
+	if got := v.(*Histogram).Sum(); got != 4950 {
+		t.Fatalf("unexpected sum; got %v; want %v", got, 4950)
+	}
+}
+
+func TestEnvelopeErrors(t *testing.T) {
+	if _, err := AppendEnvelope(nil, 42); err == nil {
+		t.Fatal("unsupported type must fail")
+	}
+
+	b, _ := AppendEnvelope(nil, NewUint128(1, 2))
+	testCases := []struct {
+		b   []byte
+		err error
+	}{
+		{nil, ErrInvalidEncoding},
+		{append([]byte{0}, b[1:]...), ErrInvalidEncoding},
+		{append([]byte{b[0], envelopeVersion + 1}, b[2:]...), ErrVersion},
+		{append([]byte{b[0], b[1], 0xff}, b[3:]...), ErrInvalidEncoding},
+		{b[:len(b)-1], ErrInvalidEncoding},
+	}
+	for _, tc := range testCases {
+		if _, _, err := DecodeAny(tc.b); err != tc.err {
+			t.Fatalf("unexpected error for % x; got %v; want %v", tc.b, err, tc.err)
+		}
+	}
+}
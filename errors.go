@@ -0,0 +1,47 @@
+package mathx
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrMismatch is returned when combining values with incompatible parameters.
+	ErrMismatch = errors.New("mathx: mismatched parameters")
+
+	// ErrInvalidEncoding is returned when decoding malformed binary data.
+	ErrInvalidEncoding = errors.New("mathx: invalid encoding")
+
+	// ErrSyntax is returned when parsing malformed text,
+	// parsers return it wrapped in a *SyntaxError.
+	ErrSyntax = errors.New("mathx: invalid syntax")
+
+	// ErrOverflow is returned when a value does not fit into the target type.
+	ErrOverflow = errors.New("mathx: value out of range")
+
+	// ErrNoInverse is returned when a modular inverse does not exist.
+	ErrNoInverse = errors.New("mathx: no modular inverse")
+
+	// ErrNaN is returned when a NaN value cannot be converted.
+	ErrNaN = errors.New("mathx: NaN value")
+
+	// ErrNegative is returned when a negative value is converted to an unsigned type.
+	ErrNegative = errors.New("mathx: negative value")
+
+	// ErrInexact is returned when a value cannot be represented without rounding.
+	ErrInexact = errors.New("mathx: inexact value")
+
+	// ErrVersion is returned when decoding data written by a newer, unknown format version.
+	ErrVersion = errors.New("mathx: unsupported encoding version")
+)
+
+// SyntaxError records the position of malformed input, it wraps ErrSyntax.
+type SyntaxError struct {
+	Offset int // byte offset of the first invalid byte, the length of the input if it ends early
+}
+
+func (e *SyntaxError) Error() string {
+	return "mathx: invalid syntax at offset " + strconv.Itoa(e.Offset)
+}
+
+func (e *SyntaxError) Unwrap() error { return ErrSyntax }
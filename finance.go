@@ -0,0 +1,100 @@
+package mathx
+
+import "math"
+
+// NPV returns the net present value of cashflows at the given rate per period.
+// The first cash flow is at time 0 and is not discounted, like numpy-financial's npv.
+// The sum is evaluated by Horner's scheme in Double precision.
+func NPV(rate Double, cashflows []float64) Double {
+	x := addDF(rate, 1).Inv()
+	var npv Double
+	for i := len(cashflows) - 1; i >= 0; i-- {
+		npv = addDF(npv.Mul(x), cashflows[i])
+	}
+	return npv
+}
+
+// IRR returns the internal rate of return of cashflows, the rate greater than -1
+// at which NPV is zero, and whether it was found.
+// It uses Newton's method in Double precision starting at 10%
+// and falls back to bisection of a bracketing interval when Newton's method
+// diverges. Cash flows with several sign changes may have several rates,
+// IRR returns one of them. It returns false if all cash flows have the same sign.
+func IRR(cashflows []float64) (Double, bool) {
+	var pos, neg bool
+	for _, c := range cashflows {
+		pos = pos || c > 0
+		neg = neg || c < 0
+	}
+	if !pos || !neg {
+		return DoubleNaN, false
+	}
+
+	if r, ok := irrNewton(cashflows, DoubleFromFloat(0.1)); ok {
+		return r, true
+	}
+	return irrBisect(cashflows)
+}
+
+// irrEps is the relative step at which IRR iterations stop, close to the Double precision.
+const irrEps = 0x1p-100
+
+func irrNewton(cashflows []float64, r Double) (Double, bool) {
+	for iter := 0; iter < 100; iter++ {
+		// With x = 1/(1+r) the NPV is the polynomial p(x) and dNPV/dr = -p'(x) * x**2.
+		x := addDF(r, 1).Inv()
+		var p, dp Double
+		for i := len(cashflows) - 1; i >= 0; i-- {
+			dp = dp.Mul(x).Add(p)
+			p = addDF(p.Mul(x), cashflows[i])
+		}
+		if p.hi == 0 {
+			return r, true
+		}
+
+		step := p.Div(dp.Mul(x.Sqr()))
+		r = r.Add(step)
+		switch {
+		case math.IsNaN(r.hi) || math.IsInf(r.hi, 0) || !(r.hi > -1):
+			return DoubleNaN, false
+		case math.Abs(step.hi) <= irrEps*(1+math.Abs(r.hi)):
+			return r, true
+		}
+	}
+	return DoubleNaN, false
+}
+
+// irrBisect finds a sign change of NPV on a grid of rates in (-1, 2**40)
+// and bisects it.
+func irrBisect(cashflows []float64) (Double, bool) {
+	sign := func(r Double) bool { return NPV(r, cashflows).hi > 0 }
+
+	var grid []float64
+	for k := 50; k >= 1; k-- {
+		grid = append(grid, -1+math.Ldexp(1, -k))
+	}
+	for k := 0; k <= 40; k++ {
+		grid = append(grid, math.Ldexp(1, k)-1)
+	}
+
+	for i := 1; i < len(grid); i++ {
+		lo, hi := DoubleFromFloat(grid[i-1]), DoubleFromFloat(grid[i])
+		slo := sign(lo)
+		if slo == sign(hi) {
+			continue
+		}
+		for j := 0; j < 200; j++ {
+			mid := mulDFpow2(lo.Add(hi), -1)
+			if sign(mid) == slo {
+				lo = mid
+			} else {
+				hi = mid
+			}
+			if hi.Sub(lo).hi <= irrEps*(1+math.Abs(lo.hi)) {
+				break
+			}
+		}
+		return mulDFpow2(lo.Add(hi), -1), true
+	}
+	return DoubleNaN, false
+}
@@ -0,0 +1,54 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNPV(t *testing.T) {
+	cashflows := []float64{-100, 39, 59, 55, 20}
+	want := -100 + 39/1.1 + 59/1.21 + 55/1.331 + 20/1.4641
+	if got := NPV(DoubleFromFloat(0.1), cashflows).ToFloat64(); math.Abs(got-want) > 1e-12 {
+		t.Fatalf("unexpected NPV; got %v; want %v", got, want)
+	}
+	if got := NPV(DoubleZero, cashflows).ToFloat64(); got != 73 {
+		t.Fatalf("unexpected NPV at zero rate; got %v; want %v", got, 73)
+	}
+	if got := NPV(DoubleOne, nil); !got.Equal(DoubleZero) {
+		t.Fatalf("unexpected NPV of no cash flows; got %v", got)
+	}
+}
+
+func TestIRR(t *testing.T) {
+	testCases := []struct {
+		cashflows []float64
+		want      float64
+	}{
+		{[]float64{-100, 110}, 0.1},
+		{[]float64{-100, 39, 59, 55, 20}, 0.2809484211599611},
+		{[]float64{-1, 1000}, 999},
+		{[]float64{-1000, 1000.0000001}, 1e-10},
+		{[]float64{100, -50, -60}, 0.06394102980498532},
+	}
+
+	for _, tc := range testCases {
+		r, ok := IRR(tc.cashflows)
+		if !ok || math.Abs(r.ToFloat64()-tc.want) > 1e-12*math.Max(1, tc.want) {
+			t.Fatalf("unexpected IRR(%v); got %v, %v; want %v", tc.cashflows, r.ToFloat64(), ok, tc.want)
+		}
+		if npv := NPV(r, tc.cashflows).ToFloat64(); math.Abs(npv) > 1e-25 {
+			t.Fatalf("unexpected NPV at IRR(%v); got %v; want 0", tc.cashflows, npv)
+		}
+
+		b, ok := irrBisect(tc.cashflows)
+		if !ok || math.Abs(b.ToFloat64()-tc.want) > 1e-12*math.Max(1, tc.want) {
+			t.Fatalf("unexpected bisection IRR(%v); got %v, %v; want %v", tc.cashflows, b.ToFloat64(), ok, tc.want)
+		}
+	}
+
+	for _, cashflows := range [][]float64{nil, {1, 2}, {-1, 0, -2}} {
+		if _, ok := IRR(cashflows); ok {
+			t.Fatalf("unexpected IRR(%v) without sign change", cashflows)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+)
+
+// Fixed128 is a signed binary fixed-point number with a configurable
+// number of fractional bits. Its value is raw / 2**frac, where raw is an Int128.
+// For example, frac = 64 gives the Q64.64 format (63 integer bits and a sign).
+//
+// Operations round to nearest with ties to even and report overflow
+// instead of wrapping around.
+type Fixed128 struct {
+	raw  Int128
+	frac uint8
+}
+
+// NewFixed128 returns new Fixed128 with the given raw value and number of fractional bits.
+// It panics if frac > 127.
+func NewFixed128(raw Int128, frac uint) Fixed128 {
+	if frac > 127 {
+		panic("mathx: fixed-point fractional bits must be in range [0, 127]")
+	}
+	return Fixed128{raw: raw, frac: uint8(frac)}
+}
+
+// Fixed128FromInt64 returns v in the fixed-point format with frac fractional bits
+// and whether v is representable in that format.
+func Fixed128FromInt64(v int64, frac uint) (Fixed128, bool) {
+	f := NewFixed128(Int128{}, frac)
+	mag := Uint128FromUint64(uint64(v))
+	if v < 0 {
+		mag = Uint128FromUint64(-uint64(v))
+	}
+	return f.fromMag(Uint256{lo: mag}.Lsh(frac), v < 0)
+}
+
+// Fixed128FromFloat64 returns x rounded to the fixed-point format with frac fractional bits
+// and whether x is representable in that format.
+func Fixed128FromFloat64(x float64, frac uint) (Fixed128, bool) {
+	f := NewFixed128(Int128{}, frac)
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return f, false
+	}
+
+	// Scaling by a power of two is exact, so is rounding to an integer.
+	m := math.RoundToEven(math.Ldexp(math.Abs(x), int(frac)))
+	if m >= 0x1p128 {
+		return f, false
+	}
+	hi := math.Floor(m / 0x1p64)
+	mag := Uint256{lo: NewUint128(uint64(hi), uint64(m-hi*0x1p64))}
+	return f.fromMag(mag, x < 0)
+}
+
+// Raw returns the raw value of f.
+func (f Fixed128) Raw() Int128 { return f.raw }
+
+// Frac returns the number of fractional bits of f.
+func (f Fixed128) Frac() uint { return uint(f.frac) }
+
+// IsZero reports whether f is zero.
+func (f Fixed128) IsZero() bool { return f.raw.IsZero() }
+
+// Sign returns -1, 0 or +1 depending on the sign of f.
+func (f Fixed128) Sign() int { return f.raw.Sign() }
+
+// Neg returns -f and whether the result is representable.
+func (f Fixed128) Neg() (Fixed128, bool) {
+	return f.fromMag(Uint256{lo: f.raw.Abs()}, !f.raw.IsNeg())
+}
+
+// Cmp returns -1, 0 or +1 comparing f to x.
+// It panics if f and x have different formats.
+func (f Fixed128) Cmp(x Fixed128) int {
+	f.checkFormat(x)
+	return f.raw.Cmp(x.raw)
+}
+
+// Add returns f + x and whether the result is representable.
+// It panics if f and x have different formats.
+func (f Fixed128) Add(x Fixed128) (Fixed128, bool) {
+	f.checkFormat(x)
+	r := f.raw.Add(x.raw)
+	// Overflow iff operands have the same sign and the result sign differs.
+	ok := f.raw.IsNeg() != x.raw.IsNeg() || r.IsNeg() == f.raw.IsNeg()
+	return Fixed128{raw: r, frac: f.frac}, ok
+}
+
+// Sub returns f - x and whether the result is representable.
+// It panics if f and x have different formats.
+func (f Fixed128) Sub(x Fixed128) (Fixed128, bool) {
+	f.checkFormat(x)
+	r := f.raw.Sub(x.raw)
+	// Overflow iff operands have different signs and the result sign differs from f.
+	ok := f.raw.IsNeg() == x.raw.IsNeg() || r.IsNeg() == f.raw.IsNeg()
+	return Fixed128{raw: r, frac: f.frac}, ok
+}
+
+// Mul returns f * x rounded to the format of f and whether the result is representable.
+func (f Fixed128) Mul(x Fixed128) (Fixed128, bool) {
+	hi, lo := f.raw.Abs().MulFull(x.raw.Abs())
+	q := rshRound(Uint256{hi: hi, lo: lo}, uint(x.frac))
+	return f.fromMag(q, f.raw.IsNeg() != x.raw.IsNeg())
+}
+
+// Div returns f / x rounded to the format of f and whether the result is representable.
+// It panics if x is zero.
+func (f Fixed128) Div(x Fixed128) (Fixed128, bool) {
+	d := x.raw.Abs()
+	n := Uint256{lo: f.raw.Abs()}.Lsh(uint(x.frac))
+
+	q, r := div256by128(n, d)
+	// Round up if r > d - r or if r == d - r and q is odd.
+	if c := r.Cmp(d.Sub(r)); c > 0 || (c == 0 && q.lo.lo&1 == 1) {
+		q = q.Inc()
+	}
+	return f.fromMag(q, f.raw.IsNeg() != x.raw.IsNeg())
+}
+
+// Float64 returns the nearest float64 value of f.
+func (f Fixed128) Float64() float64 {
+	v, _ := f.bigFloat().Float64()
+	return v
+}
+
+// String returns the shortest decimal representation of f.
+func (f Fixed128) String() string {
+	return f.bigFloat().Text('g', -1)
+}
+
+func (f Fixed128) bigFloat() *big.Float {
+	v := new(big.Float).SetPrec(128).SetInt(f.raw.Big())
+	return v.SetMantExp(v, -int(f.frac))
+}
+
+// fromMag returns the fixed-point value with the given magnitude and sign
+// in the format of f and whether it is representable.
+func (f Fixed128) fromMag(mag Uint256, neg bool) (Fixed128, bool) {
+	m, ok := mag.Uint128()
+	limit := minInt128.u // 2**127
+	if c := m.Cmp(limit); c > 0 || (c == 0 && !neg) {
+		ok = false
+	}
+
+	raw := Int128{u: m}
+	if neg {
+		raw = raw.Neg()
+	}
+	return Fixed128{raw: raw, frac: f.frac}, ok
+}
+
+func (f Fixed128) checkFormat(x Fixed128) {
+	if f.frac != x.frac {
+		panic("mathx: mismatched fixed-point formats")
+	}
+}
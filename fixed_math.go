@@ -0,0 +1,151 @@
+package mathx
+
+// Constants in Q1.126 format.
+var (
+	fixedOne126   = Uint128{hi: 1 << 62}
+	fixedLn2126   = Uint128{hi: 0x2c5c85fdf473de6a, lo: 0xf278ece600fcbdab}
+	fixedLog2E126 = Uint128{hi: 0x5c551d94ae0bf85d, lo: 0xdf43ff68348e9f44}
+)
+
+// Log2Fixed returns the base-2 logarithm of x in the format of x
+// and whether the result is representable. x must be positive.
+// It uses integer arithmetic only.
+func Log2Fixed(x Fixed128) (Fixed128, bool) {
+	if x.raw.Sign() <= 0 {
+		return Fixed128{frac: x.frac}, false
+	}
+
+	neg, l := fixedLog2(x.raw.u, uint(x.frac))
+	var q Uint256
+	if x.frac <= 120 {
+		q = rshRound(Uint256{lo: l}, 120-uint(x.frac))
+	} else {
+		q = Uint256{lo: l}.Lsh(uint(x.frac) - 120)
+	}
+	return x.fromMag(q, neg)
+}
+
+// ExpFixed returns e**x in the format of x and whether the result is representable.
+// It uses integer arithmetic only.
+func ExpFixed(x Fixed128) (Fixed128, bool) {
+	hi, lo := x.raw.Abs().MulFull(fixedLog2E126)
+	return fixedExp2(x.raw.IsNeg(), Uint256{hi: hi, lo: lo}, uint(x.frac)+126, uint(x.frac))
+}
+
+// PowFixed returns x**y in the format of x and whether the result is representable.
+// x must be non-negative, and positive when y is not positive.
+// It uses integer arithmetic only.
+func PowFixed(x, y Fixed128) (Fixed128, bool) {
+	switch {
+	case x.raw.IsNeg():
+		return Fixed128{frac: x.frac}, false
+	case x.raw.IsZero():
+		return Fixed128{frac: x.frac}, y.raw.Sign() > 0
+	}
+
+	neg, l := fixedLog2(x.raw.u, uint(x.frac))
+	hi, lo := y.raw.Abs().MulFull(l)
+	return fixedExp2(neg != y.raw.IsNeg(), Uint256{hi: hi, lo: lo}, uint(y.frac)+120, uint(x.frac))
+}
+
+// fixedLog2 returns the sign and the magnitude in Q7.120 format
+// of the base-2 logarithm of the positive value raw / 2**frac.
+func fixedLog2(raw Uint128, frac uint) (bool, Uint128) {
+	n := 127 - raw.LeadingZeros()
+	ip := n - int(frac)
+
+	// Mantissa in [1, 2) as Q1.126.
+	var m Uint128
+	if n <= 126 {
+		m = raw.Lsh(uint(126 - n))
+	} else {
+		m = raw.Rsh(uint(n - 126))
+	}
+
+	// Fractional bits by repeated squaring.
+	var f Uint128
+	for i := 0; i < 120; i++ {
+		hi, lo := m.MulFull(m)
+		m = Uint256{hi: hi, lo: lo}.Rsh(126).lo
+		f = f.Lsh(1)
+		if m.hi>>63 == 1 {
+			m = m.Rsh(1)
+			f.lo |= 1
+		}
+	}
+
+	if ip >= 0 {
+		return false, Uint128FromUint64(uint64(ip)).Lsh(120).Or(f)
+	}
+	return true, Uint128FromUint64(uint64(-ip)).Lsh(120).Sub(f)
+}
+
+// fixedExp2 returns 2**(±mag / 2**magFrac) with outFrac fractional bits.
+func fixedExp2(neg bool, mag Uint256, magFrac, outFrac uint) (Fixed128, bool) {
+	out := Fixed128{frac: uint8(outFrac)}
+
+	ip := mag.Rsh(magFrac)
+	rem := mag.Sub(ip.Lsh(magFrac))
+	var f Uint128
+	if magFrac >= 126 {
+		f = rem.Rsh(magFrac - 126).lo
+	} else {
+		f = rem.Lsh(126 - magFrac).lo
+	}
+
+	k64, ok := ip.Uint64()
+	if !ok || k64 > 256 {
+		// Underflows to zero or overflows.
+		return out, neg
+	}
+	k := int(k64)
+	if neg {
+		if !f.IsZero() {
+			k++
+			f = fixedOne126.Sub(f)
+		}
+		k = -k
+	}
+
+	m := fixedExp2Frac(f)
+	switch shift := k + int(outFrac) - 126; {
+	case shift > 0:
+		return out, false
+	case shift < -127:
+		return out, true
+	default:
+		return out.fromMag(rshRound(Uint256{lo: m}, uint(-shift)), false)
+	}
+}
+
+// fixedExp2Frac returns 2**f for f in [0, 1), both in Q1.126 format.
+// It sums the Taylor series of e**(f*ln2).
+func fixedExp2Frac(f Uint128) Uint128 {
+	hi, lo := f.MulFull(fixedLn2126)
+	t := Uint256{hi: hi, lo: lo}.Rsh(126).lo
+
+	sum, term := fixedOne126, fixedOne126
+	for k := uint64(1); !term.IsZero(); k++ {
+		hi, lo := term.MulFull(t)
+		term, _ = Uint256{hi: hi, lo: lo}.Rsh(126).lo.QuoRem64(k)
+		sum = sum.Add(term)
+	}
+	return sum
+}
+
+// rshRound returns x >> s rounded to nearest with ties to even.
+func rshRound(x Uint256, s uint) Uint256 {
+	if s == 0 {
+		return x
+	}
+	if s >= 256 {
+		return Uint256{}
+	}
+	q := x.Rsh(s)
+	rem := x.Sub(q.Lsh(s))
+	half := Uint256FromUint64(1).Lsh(s - 1)
+	if c := rem.Cmp(half); c > 0 || (c == 0 && q.lo.lo&1 == 1) {
+		q = q.Inc()
+	}
+	return q
+}
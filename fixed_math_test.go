@@ -0,0 +1,78 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLog2Fixed(t *testing.T) {
+	for _, x := range []float64{1, 2, 8, 0.5, 0x1p-60, 0x1p62} {
+		fx, _ := Fixed128FromFloat64(x, 64)
+		got, ok := Log2Fixed(fx)
+		want, _ := Fixed128FromFloat64(math.Log2(x), 64)
+		if !ok || got != want {
+			t.Fatalf("unexpected Log2Fixed(%v); got %v; want %v", x, got, want)
+		}
+	}
+
+	for _, x := range []float64{3, 10, 0.1, 1.5, 12345.678} {
+		fx, _ := Fixed128FromFloat64(x, 64)
+		got, ok := Log2Fixed(fx)
+		if !ok || math.Abs(got.Float64()-math.Log2(x)) > 1e-15 {
+			t.Fatalf("unexpected Log2Fixed(%v); got %v; want %v", x, got, math.Log2(x))
+		}
+	}
+
+	if _, ok := Log2Fixed(Fixed128{frac: 64}); ok {
+		t.Fatal("Log2Fixed(0) must fail")
+	}
+}
+
+func TestExpFixed(t *testing.T) {
+	zero := Fixed128{frac: 64}
+	if got, ok := ExpFixed(zero); !ok || got.Float64() != 1 {
+		t.Fatalf("unexpected ExpFixed(0); got %v", got)
+	}
+
+	for _, x := range []float64{1, -1, 0.5, 10, -10, 30, 1e-9} {
+		fx, _ := Fixed128FromFloat64(x, 64)
+		got, ok := ExpFixed(fx)
+		want := math.Exp(x)
+		if !ok || math.Abs(got.Float64()-want) > 1e-14*want+0x1p-63 {
+			t.Fatalf("unexpected ExpFixed(%v); got %v; want %v", x, got, want)
+		}
+	}
+
+	big, _ := Fixed128FromInt64(50, 64)
+	if _, ok := ExpFixed(big); ok {
+		t.Fatal("ExpFixed(50) must overflow Q64.64")
+	}
+	small, _ := Fixed128FromInt64(-50, 64)
+	if got, ok := ExpFixed(small); !ok || !got.IsZero() {
+		t.Fatalf("ExpFixed(-50) must underflow to zero; got %v", got)
+	}
+}
+
+func TestPowFixed(t *testing.T) {
+	two, _ := Fixed128FromInt64(2, 64)
+	ten, _ := Fixed128FromInt64(10, 64)
+	if got, ok := PowFixed(two, ten); !ok || got.Float64() != 1024 {
+		t.Fatalf("unexpected PowFixed(2, 10); got %v", got)
+	}
+
+	x, _ := Fixed128FromFloat64(1.0001, 64)
+	y, _ := Fixed128FromFloat64(-1234.5, 64)
+	got, ok := PowFixed(x, y)
+	want := 0.883871288745956767916916595813831946 // math.Pow is off by 1e-14 here.
+	if !ok || math.Abs(got.Float64()-want) > 1e-16 {
+		t.Fatalf("unexpected PowFixed; got %v; want %v", got, want)
+	}
+
+	zero := Fixed128{frac: 64}
+	if got, ok := PowFixed(zero, two); !ok || !got.IsZero() {
+		t.Fatalf("unexpected PowFixed(0, 2); got %v", got)
+	}
+	if _, ok := PowFixed(zero, zero); ok {
+		t.Fatal("PowFixed(0, 0) must fail")
+	}
+}
@@ -0,0 +1,110 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestFixed128MulDiv(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		frac := uint(rng.Intn(100))
+		a := NewFixed128(NewInt128(rng.Int63n(1<<20)-1<<19, rng.Uint64()), frac)
+		b := NewFixed128(NewInt128(rng.Int63n(1<<20)-1<<19, rng.Uint64()), uint(rng.Intn(100)))
+
+		got, ok := a.Mul(b)
+		want, wantOK := refFixed(a, b, frac, false)
+		if ok != wantOK || (ok && got.raw != want) {
+			t.Fatalf("unexpected %v * %v; got %v (%v); want %v (%v)", a, b, got.raw, ok, want, wantOK)
+		}
+
+		if b.IsZero() {
+			continue
+		}
+		got, ok = a.Div(b)
+		want, wantOK = refFixed(a, b, frac, true)
+		if ok != wantOK || (ok && got.raw != want) {
+			t.Fatalf("unexpected %v / %v; got %v (%v); want %v (%v)", a, b, got.raw, ok, want, wantOK)
+		}
+	}
+}
+
+func TestFixed128(t *testing.T) {
+	half, _ := Fixed128FromFloat64(0.5, 64)
+	three, _ := Fixed128FromInt64(3, 64)
+
+	r, ok := three.Mul(half)
+	if !ok || r.Float64() != 1.5 || r.String() != "1.5" {
+		t.Fatalf("unexpected 3 * 0.5; got %v (%v)", r, ok)
+	}
+	r, ok = three.Div(half)
+	if !ok || r.Float64() != 6 {
+		t.Fatalf("unexpected 3 / 0.5; got %v (%v)", r, ok)
+	}
+	r, ok = half.Sub(three)
+	if !ok || r.Float64() != -2.5 || r.Sign() != -1 {
+		t.Fatalf("unexpected 0.5 - 3; got %v (%v)", r, ok)
+	}
+
+	// 1/3 in Q64.64 is rounded to nearest.
+	one, _ := Fixed128FromInt64(1, 64)
+	r, _ = one.Div(three)
+	if want := NewInt128(0, 0x5555555555555555); r.Raw() != want {
+		t.Fatalf("unexpected 1 / 3; got %v; want %v", r.Raw(), want)
+	}
+
+	large, ok := Fixed128FromInt64(math.MaxInt64, 64)
+	if !ok {
+		t.Fatal("max int64 must fit into Q64.64")
+	}
+	if _, ok := large.Add(large); ok {
+		t.Fatal("addition must overflow")
+	}
+	if _, ok := large.Mul(three); ok {
+		t.Fatal("multiplication must overflow")
+	}
+	if _, ok := Fixed128FromInt64(1, 127); ok {
+		t.Fatal("1 must not fit into Q1.127")
+	}
+	if v, ok := Fixed128FromInt64(-1, 127); !ok || v.Float64() != -1 {
+		t.Fatalf("-1 must fit into Q1.127; got %v (%v)", v, ok)
+	}
+	if _, ok := Fixed128FromFloat64(math.NaN(), 64); ok {
+		t.Fatal("NaN must not be representable")
+	}
+}
+
+// refFixed computes a*b or a/b in the format with frac bits using big.Rat.
+func refFixed(a, b Fixed128, frac uint, div bool) (Int128, bool) {
+	ra := new(big.Rat).SetFrac(a.raw.Big(), new(big.Int).Lsh(big.NewInt(1), a.Frac()))
+	rb := new(big.Rat).SetFrac(b.raw.Big(), new(big.Int).Lsh(big.NewInt(1), b.Frac()))
+	if div {
+		ra.Quo(ra, rb)
+	} else {
+		ra.Mul(ra, rb)
+	}
+	ra.Mul(ra, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), frac)))
+
+	// Round half to even.
+	q, r := new(big.Int).QuoRem(ra.Num(), ra.Denom(), new(big.Int))
+	r2 := new(big.Int).Abs(r)
+	r2.Lsh(r2, 1)
+	if c := r2.Cmp(ra.Denom()); c > 0 || (c == 0 && q.Bit(0) == 1) {
+		q.Add(q, big.NewInt(int64(ra.Sign())))
+	}
+
+	if q.Cmp(maxInt128.Big()) > 0 || q.Cmp(minInt128.Big()) < 0 {
+		return Int128{}, false
+	}
+	v := Int128{u: NewUint128(0, 0)}
+	abs := new(big.Int).Abs(q)
+	hi := new(big.Int).Rsh(abs, 64).Uint64()
+	lo := new(big.Int).And(abs, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	v.u = NewUint128(hi, lo)
+	if q.Sign() < 0 {
+		v = v.Neg()
+	}
+	return v, true
+}
@@ -0,0 +1,33 @@
+package mathx
+
+import "math"
+
+// Float64 returns the nearest float64 value of u, ties to even.
+func (u Uint128) Float64() float64 {
+	if u.hi == 0 {
+		return float64(u.lo)
+	}
+	// Keep the top 64 bits and fold the rest into a sticky bit,
+	// it is far below the rounding position of float64.
+	s := uint(64 - u.LeadingZeros())
+	top := u.Rsh(s).lo
+	if u.Lsh(128-s).lo|u.Lsh(128-s).hi != 0 {
+		top |= 1
+	}
+	return math.Ldexp(float64(top), int(s))
+}
+
+// Uint128FromFloat64 returns f truncated toward zero and whether
+// it is in the range of Uint128. NaN, infinities, values <= -1 and
+// values >= 2^128 give 0 and false.
+func Uint128FromFloat64(f float64) (Uint128, bool) {
+	if !(f > -1 && f < 0x1p128) {
+		return Uint128{}, false
+	}
+	f = math.Trunc(f)
+	if f < 0x1p64 {
+		return Uint128{lo: uint64(f)}, true
+	}
+	hi := math.Floor(f / 0x1p64)
+	return Uint128{hi: uint64(hi), lo: uint64(f - hi*0x1p64)}, true
+}
@@ -0,0 +1,52 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestUint128Float64(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	values := []Uint128{
+		{}, {lo: 1}, {lo: math.MaxUint64}, {hi: 1}, max, max.Dec(),
+		// Halfway cases: 2^64 + 2^11 is between two float64 values.
+		NewUint128(1, 1<<11), NewUint128(1, 1<<11+1), NewUint128(1, 3<<11), NewUint128(1<<53-1, 1<<63),
+		NewUint128(1<<53-1, 1<<63+1), NewUint128(1<<52, 1),
+	}
+	for _, u := range values {
+		want, _ := new(big.Float).SetInt(u.Big()).Float64()
+		if got := u.Float64(); got != want {
+			t.Fatalf("unexpected Float64(%v); got %v; want %v", u, got, want)
+		}
+	}
+}
+
+func TestUint128FromFloat64(t *testing.T) {
+	testCases := []struct {
+		f    float64
+		want Uint128
+		ok   bool
+	}{
+		{0, Uint128{}, true},
+		{-0.9, Uint128{}, true},
+		{1.9, Uint128{lo: 1}, true},
+		{0x1p64, Uint128{hi: 1}, true},
+		{0x1p64 + 0x1p12, Uint128{hi: 1, lo: 1 << 12}, true},
+		{0x1p127, Uint128{hi: 1 << 63}, true},
+		{math.Nextafter(0x1p128, 0), NewUint128(0xfffffffffffff800, 0), true},
+		{0x1p128, Uint128{}, false},
+		{-1, Uint128{}, false},
+		{NaN, Uint128{}, false},
+		{InfPos, Uint128{}, false},
+	}
+	for _, tc := range testCases {
+		got, ok := Uint128FromFloat64(tc.f)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("unexpected Uint128FromFloat64(%v); got %v, %v; want %v, %v", tc.f, got, ok, tc.want, tc.ok)
+		}
+		if ok && got.Float64() != math.Trunc(tc.f) {
+			t.Fatalf("roundtrip of %v failed; got %v", tc.f, got.Float64())
+		}
+	}
+}
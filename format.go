@@ -0,0 +1,20 @@
+package mathx
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Format implements fmt.Formatter. It accepts the same verbs and flags
+// as big.Int: 'b', 'o', 'O', 'd', 'x', 'X', 's' and 'v' with width,
+// precision and the '+', '-', ' ', '0' and '#' flags.
+func (u Uint128) Format(s fmt.State, ch rune) {
+	var b big.Int
+	u.SetBig(&b).Format(s, ch)
+}
+
+// Format implements fmt.Formatter, see Uint128.Format.
+func (u Uint256) Format(s fmt.State, ch rune) {
+	var b big.Int
+	u.SetBig(&b).Format(s, ch)
+}
@@ -0,0 +1,41 @@
+package mathx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUint128Format(t *testing.T) {
+	u := NewUint128(1, 0xff)
+	testCases := []struct {
+		format, want string
+	}{
+		{"%d", "18446744073709551871"},
+		{"%v", "18446744073709551871"},
+		{"%s", "18446744073709551871"},
+		{"%x", "100000000000000ff"},
+		{"%X", "100000000000000FF"},
+		{"%#x", "0x100000000000000ff"},
+		{"%o", "2000000000000000000377"},
+		{"%#o", "02000000000000000000377"},
+		{"%O", "0o2000000000000000000377"},
+		{"%b", "1" + fmt.Sprintf("%064b", 0xff)},
+		{"%25d", "     18446744073709551871"},
+		{"%-25d|", "18446744073709551871     |"},
+		{"%025d", "0000018446744073709551871"},
+		{"%+d", "+18446744073709551871"},
+		{"%#24x", "     0x100000000000000ff"},
+	}
+	for _, tc := range testCases {
+		if got := fmt.Sprintf(tc.format, u); got != tc.want {
+			t.Fatalf("unexpected %s; got %q; want %q", tc.format, got, tc.want)
+		}
+	}
+
+	if got := fmt.Sprintf("%x", NewUint256(Uint128FromUint64(1), Uint128{})); got != "1"+fmt.Sprintf("%032x", 0) {
+		t.Fatalf("unexpected Uint256 %%x; got %q", got)
+	}
+	if got := fmt.Sprint(Uint128{}); got != "0" {
+		t.Fatalf("unexpected Sprint; got %q", got)
+	}
+}
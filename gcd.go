@@ -0,0 +1,58 @@
+package mathx
+
+// GCD returns the greatest common divisor of u and x using the binary GCD algorithm.
+// GCD(0, x) is x.
+func (u Uint128) GCD(x Uint128) Uint128 {
+	if u.IsZero() {
+		return x
+	}
+	if x.IsZero() {
+		return u
+	}
+	shift := u.Or(x).TrailingZeros()
+	u = u.Rsh(uint(u.TrailingZeros()))
+	for !x.IsZero() {
+		x = x.Rsh(uint(x.TrailingZeros()))
+		if u.Cmp(x) > 0 {
+			u, x = x, u
+		}
+		x = x.Sub(u)
+	}
+	return u.Lsh(uint(shift))
+}
+
+// LCM returns the least common multiple of u and x wrapped around at 2^128
+// and whether it overflowed. LCM(0, x) is 0.
+func (u Uint128) LCM(x Uint128) (Uint128, bool) {
+	if u.IsZero() || x.IsZero() {
+		return Uint128{}, false
+	}
+	return u.Div(u.GCD(x)).MulOverflow(x)
+}
+
+// ModInverse returns x such that u*x ≡ 1 (mod m) and 0 <= x < m.
+// It returns ErrNoInverse if u and m are not coprime or m == 0.
+func (u Uint128) ModInverse(m Uint128) (Uint128, error) {
+	if m.IsZero() {
+		return Uint128{}, ErrNoInverse
+	}
+	// Extended Euclid on magnitudes: the coefficients alternate in sign
+	// and never exceed m, so they cannot overflow.
+	r0, r1 := m, u.Mod(m)
+	t0, t1 := Uint128{}, Uint128{lo: 1}
+	odd := false // t0 is positive after an odd number of steps
+	for !r1.IsZero() {
+		q, r := r0.DivMod(r1)
+		r0, r1 = r1, r
+		t0, t1 = t1, t0.Add(q.Mul(t1))
+		odd = !odd
+	}
+	switch {
+	case r0 != (Uint128{lo: 1}):
+		return Uint128{}, ErrNoInverse
+	case odd:
+		return t0, nil
+	default:
+		return m.Sub(t0).Mod(m), nil
+	}
+}
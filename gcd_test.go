@@ -0,0 +1,67 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestUint128GCD(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	values := []Uint128{
+		{}, {lo: 1}, {lo: 6}, {lo: 35}, {lo: 1 << 40}, {hi: 1}, {hi: 3, lo: 9},
+		NewUint128(0, 1).Lsh(127), max, max.Dec(), NewUint128(0x1234, 0x5678).Mul(NewUint128(0, 1<<20)),
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	for _, a := range values {
+		for _, b := range values {
+			want := new(big.Int).GCD(nil, nil, a.Big(), b.Big())
+			if a.IsZero() {
+				want = b.Big()
+			}
+			if b.IsZero() {
+				want = a.Big()
+			}
+			if got := a.GCD(b); got.Big().Cmp(want) != 0 {
+				t.Fatalf("unexpected GCD(%v, %v); got %v; want %v", a, b, got, want)
+			}
+
+			lcm, over := a.LCM(b)
+			wantLCM := new(big.Int)
+			if !a.IsZero() && !b.IsZero() {
+				wantLCM.Mul(a.Big(), b.Big()).Quo(wantLCM, want)
+			}
+			wantOver := wantLCM.Cmp(limit) >= 0
+			if over != wantOver || !wantOver && lcm.Big().Cmp(wantLCM) != 0 {
+				t.Fatalf("unexpected LCM(%v, %v); got %v, %v; want %v, %v", a, b, lcm, over, wantLCM, wantOver)
+			}
+		}
+	}
+}
+
+func TestUint128ModInverse(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	mods := []Uint128{{lo: 1}, {lo: 2}, {lo: 7}, {lo: 1000}, {hi: 1, lo: 51}, max, max.Dec(), NewUint128(0, 1).Lsh(127)}
+	values := []Uint128{{}, {lo: 1}, {lo: 3}, {lo: 10}, {hi: 5, lo: 3}, max, max.Dec()}
+	for _, m := range mods {
+		for _, a := range values {
+			got, err := a.ModInverse(m)
+			want := new(big.Int).ModInverse(a.Big(), m.Big())
+			if m == (Uint128{lo: 1}) {
+				want = new(big.Int)
+			}
+			if want == nil {
+				if err != ErrNoInverse {
+					t.Fatalf("unexpected ModInverse(%v, %v); got %v, %v; want %v", a, m, got, err, ErrNoInverse)
+				}
+				continue
+			}
+			if err != nil || got.Big().Cmp(want) != 0 {
+				t.Fatalf("unexpected ModInverse(%v, %v); got %v, %v; want %v", a, m, got, err, want)
+			}
+		}
+	}
+	if _, err := NewUint128(0, 3).ModInverse(Uint128{}); err != ErrNoInverse {
+		t.Fatalf("unexpected error for zero modulus; got %v", err)
+	}
+}
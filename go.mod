@@ -1,5 +1,5 @@
 module github.com/cristalhq/mathx
 
-go 1.17
+go 1.18
 
 require github.com/valyala/fastrand v1.1.0
@@ -0,0 +1,13 @@
+package mathx
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (u Uint128) GobEncode() ([]byte, error) { return u.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder.
+func (u *Uint128) GobDecode(b []byte) error { return u.UnmarshalBinary(b) }
+
+// GobEncode implements gob.GobEncoder using the binary encoding.
+func (u Uint256) GobEncode() ([]byte, error) { return u.MarshalBinary() }
+
+// GobDecode implements gob.GobDecoder.
+func (u *Uint256) GobDecode(b []byte) error { return u.UnmarshalBinary(b) }
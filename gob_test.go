@@ -0,0 +1,38 @@
+package mathx
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+)
+
+func TestGob(t *testing.T) {
+	type record struct {
+		A Uint128
+		B Uint256
+		C []Uint128
+	}
+	in := record{
+		A: NewUint128(1, 2),
+		B: MaxUint256,
+		C: []Uint128{{}, MaxUint128},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.A != in.A || out.B != in.B || len(out.C) != 2 || out.C[0] != in.C[0] || out.C[1] != in.C[1] {
+		t.Fatalf("unexpected gob roundtrip; got %v; want %v", out, in)
+	}
+
+	var u Uint128
+	if err := u.GobDecode([]byte{1, 2, 3}); !errors.Is(err, ErrInvalidEncoding) {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+}
@@ -1,10 +1,9 @@
 package mathx
 
 import (
+	"encoding/binary"
 	"math"
 	"sort"
-
-	"github.com/valyala/fastrand"
 )
 
 const maxSamples = 1000
@@ -12,45 +11,63 @@ const maxSamples = 1000
 // Histogram for floats.
 // Based on https://github.com/valyala/histogram with small changes.
 type Histogram struct {
-	max   float64
-	min   float64
-	count uint64
+	max float64
+	min float64
+
+	// Neumaier-compensated sum with its compensation, and the naive sum.
+	sum, sumComp, rawSum float64
 
-	vals []float64
+	res  Reservoir[float64]
 	tmp  []float64
-	rng  fastrand.RNG
+	unit string
 }
 
 // NewHistogram returns new Histogram histogram.
 func NewHistogram() *Histogram {
-	h := &Histogram{}
+	h := &Histogram{
+		res: Reservoir[float64]{size: maxSamples, seed: newSeed()},
+	}
 	h.Reset()
 	return h
 }
 
+// lazyInit makes the zero value of Histogram ready for use.
+func (h *Histogram) lazyInit() {
+	if h.res.size == 0 {
+		h.res = Reservoir[float64]{size: maxSamples, seed: newSeed()}
+		h.Reset()
+	}
+}
+
+// SetUnit sets the unit label of values, e.g. "seconds" or "bytes".
+// It is kept by Reset and used by MergeHistograms.
+func (h *Histogram) SetUnit(unit string) { h.unit = unit }
+
+// Unit returns the unit label of values, empty if not set.
+func (h *Histogram) Unit() string { return h.unit }
+
 // Reset resets the histogram.
 func (h *Histogram) Reset() {
 	h.max = InfNeg
 	h.min = InfPos
-	h.count = 0
+	h.sum, h.sumComp, h.rawSum = 0, 0, 0
 
-	if len(h.vals) > 0 {
-		h.vals = h.vals[:0]
+	if len(h.res.vals) > 0 {
 		h.tmp = h.tmp[:0]
 	} else {
 		// Free up memory occupied by unused histogram.
-		h.vals = nil
 		h.tmp = nil
 	}
 
-	// Reset rng state in order to get repeatable results
+	// Reservoir resets rng state in order to get repeatable results
 	// for the same sequence of values passed to Histogram.Update.
 	// See https://github.com/VictoriaMetrics/VictoriaMetrics/issues/1612
-	h.rng.Seed(1)
+	h.res.Reset()
 }
 
 // Update the histogram with v.
 func (h *Histogram) Update(v float64) {
+	h.lazyInit()
 	if v > h.max {
 		h.max = v
 	}
@@ -58,27 +75,48 @@ func (h *Histogram) Update(v float64) {
 		h.min = v
 	}
 
-	h.count++
-	if len(h.vals) < maxSamples {
-		h.vals = append(h.vals, v)
+	h.sum, h.sumComp = neumaierAdd(h.sum, h.sumComp, v)
+	h.rawSum += v
+	h.res.Add(v)
+}
+
+// Count returns the number of values passed to Update since the last Reset.
+func (h *Histogram) Count() uint64 { return h.res.count }
+
+// Sum returns the sum of values passed to Update since the last Reset.
+// It is computed with Neumaier's compensated summation, so it does not drift
+// over millions of values.
+func (h *Histogram) Sum() float64 { return h.sum + h.sumComp }
+
+// RawSum returns the sum of values like Sum but accumulated naively,
+// for comparison with Sum.
+func (h *Histogram) RawSum() float64 { return h.rawSum }
+
+// neumaierAdd adds v to the sum with compensation comp and returns both updated.
+func neumaierAdd(sum, comp, v float64) (float64, float64) {
+	t := sum + v
+	if math.IsInf(t, 0) || math.IsNaN(t) {
+		// The compensation of an infinite sum is Inf-Inf, keep the plain sum.
+		return t, comp
+	}
+	if math.Abs(sum) >= math.Abs(v) {
+		comp += (sum - t) + v
 	} else {
-		n := int(h.rng.Uint32n(uint32(h.count)))
-		if n < len(h.vals) {
-			h.vals[n] = v
-		}
+		comp += (v - t) + sum
 	}
+	return t, comp
 }
 
 // Quantile returns the quantile value for the given phi.
 func (h *Histogram) Quantile(phi float64) float64 {
-	h.tmp = append(h.tmp[:0], h.vals...)
+	h.tmp = append(h.tmp[:0], h.res.vals...)
 	sort.Float64s(h.tmp)
 	return h.quantile(phi)
 }
 
 // Quantiles appends quantile values to dst for the given phis.
 func (h *Histogram) Quantiles(dst, phis []float64) []float64 {
-	h.tmp = append(h.tmp[:0], h.vals...)
+	h.tmp = append(h.tmp[:0], h.res.vals...)
 	sort.Float64s(h.tmp)
 	return h.quantiles(dst, phis)
 }
@@ -92,34 +130,69 @@ func (h *Histogram) quantiles(dst, phis []float64) []float64 {
 }
 
 func (h *Histogram) quantile(phi float64) float64 {
+	return h.quantileOf(h.tmp, phi)
+}
+
+// quantileOf returns the quantile for phi of the sorted samples of h.
+func (h *Histogram) quantileOf(sorted []float64, phi float64) float64 {
 	switch {
-	case len(h.tmp) == 0 || math.IsNaN(phi):
+	case len(sorted) == 0 || math.IsNaN(phi):
 		return NaN
 	case phi <= 0:
 		return h.min
 	case phi >= 1:
 		return h.max
 	default:
-		idx := uint(phi*float64(len(h.tmp)-1) + 0.5)
-		if idx >= uint(len(h.tmp)) {
-			idx = uint(len(h.tmp) - 1)
+		idx := uint(phi*float64(len(sorted)-1) + 0.5)
+		if idx >= uint(len(sorted)) {
+			idx = uint(len(sorted) - 1)
 		}
-		return h.tmp[idx]
+		return sorted[idx]
 	}
 }
 
+// QuantilesMany returns quantile values for the given phis of each histogram,
+// result[i][j] is the quantile phis[j] of hs[i].
+// Every histogram is sorted once into a shared buffer and all results
+// share one allocation, which is much cheaper than Quantiles per histogram.
+func QuantilesMany(hs []*Histogram, phis []float64) [][]float64 {
+	out := make([][]float64, len(hs))
+	vals := make([]float64, len(hs)*len(phis))
+
+	var buf []float64
+	for i, h := range hs {
+		buf = append(buf[:0], h.res.vals...)
+		sort.Float64s(buf)
+
+		row := vals[i*len(phis) : (i+1)*len(phis) : (i+1)*len(phis)]
+		for j, phi := range phis {
+			row[j] = h.quantileOf(buf, phi)
+		}
+		out[i] = row
+	}
+	return out
+}
+
 // MergeHistograms returns 1 histogram built from the given.
+// The result has the unit label of the first histogram.
 func MergeHistograms(hs []*Histogram) *Histogram {
 	n := 0
 	for _, h := range hs {
-		n += len(h.vals)
+		n += len(h.res.vals)
 	}
 
 	t := NewHistogram()
-	t.vals = make([]float64, 0, n)
+	t.res.vals = make([]float64, 0, n)
+	if len(hs) > 0 {
+		t.unit = hs[0].unit
+	}
 
 	for _, h := range hs {
-		t.vals = append(t.vals, h.vals...)
+		t.res.vals = append(t.res.vals, h.res.vals...)
+		t.res.count += h.res.count
+		t.sum, t.sumComp = neumaierAdd(t.sum, t.sumComp, h.sum)
+		t.sumComp += h.sumComp
+		t.rawSum += h.rawSum
 		if t.max < h.max {
 			t.max = h.max
 		}
@@ -129,3 +202,59 @@ func MergeHistograms(hs []*Histogram) *Histogram {
 	}
 	return t
 }
+
+// Sizes of the fixed part of the binary encoding: min, max and count,
+// followed by the sums since envelope version 2.
+const (
+	histogramHeaderV1 = 24
+	histogramHeader   = 48
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The samples are stored exactly, along with min, max, the count and the sums of values.
+func (h *Histogram) MarshalBinary() ([]byte, error) {
+	b := make([]byte, histogramHeader+8*len(h.res.vals))
+	binary.BigEndian.PutUint64(b[0:], math.Float64bits(h.min))
+	binary.BigEndian.PutUint64(b[8:], math.Float64bits(h.max))
+	binary.BigEndian.PutUint64(b[16:], h.res.count)
+	binary.BigEndian.PutUint64(b[24:], math.Float64bits(h.sum))
+	binary.BigEndian.PutUint64(b[32:], math.Float64bits(h.sumComp))
+	binary.BigEndian.PutUint64(b[40:], math.Float64bits(h.rawSum))
+	for i, v := range h.res.vals {
+		binary.BigEndian.PutUint64(b[histogramHeader+8*i:], math.Float64bits(v))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (h *Histogram) UnmarshalBinary(b []byte) error {
+	return h.unmarshalBinary(b, histogramHeader)
+}
+
+// unmarshalBinary decodes b with the fixed part of the given size.
+// The sums are zero for the version 1 layout without them.
+func (h *Histogram) unmarshalBinary(b []byte, header int) error {
+	if len(b) < header || len(b)%8 != 0 {
+		return ErrInvalidEncoding
+	}
+	n := (len(b) - header) / 8
+	count := binary.BigEndian.Uint64(b[16:])
+	if uint64(n) > count {
+		return ErrInvalidEncoding
+	}
+
+	h.lazyInit()
+	h.Reset()
+	h.min = math.Float64frombits(binary.BigEndian.Uint64(b[0:]))
+	h.max = math.Float64frombits(binary.BigEndian.Uint64(b[8:]))
+	h.res.count = count
+	if header == histogramHeader {
+		h.sum = math.Float64frombits(binary.BigEndian.Uint64(b[24:]))
+		h.sumComp = math.Float64frombits(binary.BigEndian.Uint64(b[32:]))
+		h.rawSum = math.Float64frombits(binary.BigEndian.Uint64(b[40:]))
+	}
+	for i := 0; i < n; i++ {
+		h.res.vals = append(h.res.vals, math.Float64frombits(binary.BigEndian.Uint64(b[header+8*i:])))
+	}
+	return nil
+}
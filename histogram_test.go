@@ -80,6 +80,36 @@ func TestHistogramRepeatableResults(t *testing.T) {
 var sink float64
 var sinkLock sync.Mutex
 
+func TestQuantilesMany(t *testing.T) {
+	hs := make([]*Histogram, 3)
+	for i := range hs {
+		hs[i] = NewHistogram()
+		for j := 0; j < 5000; j++ {
+			hs[i].Update(float64(j * (i + 1)))
+		}
+	}
+	hs = append(hs, NewHistogram())
+
+	phis := []float64{0, 0.5, 0.99, 1}
+	got := QuantilesMany(hs, phis)
+	if len(got) != len(hs) {
+		t.Fatalf("unexpected rows; got %v; want %v", len(got), len(hs))
+	}
+	for i, h := range hs[:3] {
+		want := h.Quantiles(nil, phis)
+		for j := range phis {
+			if got[i][j] != want[j] {
+				t.Fatalf("unexpected quantile %v of histogram %d; got %v; want %v", phis[j], i, got[i][j], want[j])
+			}
+		}
+	}
+	for _, q := range got[3] {
+		if !math.IsNaN(q) {
+			t.Fatalf("unexpected quantile of empty histogram; got %v", q)
+		}
+	}
+}
+
 func BenchmarkHistogramUpdate(b *testing.B) {
 	b.ReportAllocs()
 	b.SetBytes(1)
@@ -127,3 +157,107 @@ func BenchmarkHistogramMerged(b *testing.B) {
 		b.Fatal("quant must be non-zero")
 	}
 }
+
+func BenchmarkQuantilesMany(b *testing.B) {
+	hs := make([]*Histogram, 100)
+	for i := range hs {
+		hs[i] = NewHistogram()
+		for j := 0; j < 2000; j++ {
+			hs[i].Update(float64(j % 777))
+		}
+	}
+	phis := []float64{0.5, 0.9, 0.99, 0.999}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		QuantilesMany(hs, phis)
+	}
+}
+
+func TestHistogramCount(t *testing.T) {
+	h := NewHistogram()
+	for i := 0; i < 2*maxSamples; i++ {
+		h.Update(float64(i))
+	}
+	if got := h.Count(); got != 2*maxSamples {
+		t.Fatalf("unexpected count; got %v; want %v", got, 2*maxSamples)
+	}
+	h.Reset()
+	if got := h.Count(); got != 0 {
+		t.Fatalf("unexpected count after Reset; got %v", got)
+	}
+}
+
+func TestHistogramZeroValue(t *testing.T) {
+	var h Histogram
+	for i := 1; i <= 10; i++ {
+		h.Update(float64(i))
+	}
+	if got := h.Count(); got != 10 {
+		t.Fatalf("unexpected count; got %v; want %v", got, 10)
+	}
+	if got := h.Quantile(0.5); got < 5 || got > 6 {
+		t.Fatalf("unexpected median; got %v; want in [5, 6]", got)
+	}
+	if got := h.Quantile(0); got != 1 {
+		t.Fatalf("unexpected min; got %v; want %v", got, 1)
+	}
+}
+
+func TestHistogramSum(t *testing.T) {
+	h := NewHistogram()
+	h.Update(1e16)
+	for i := 0; i < 1000; i++ {
+		h.Update(1)
+	}
+	h.Update(-1e16)
+	if got := h.Sum(); got != 1000 {
+		t.Fatalf("unexpected Sum; got %v; want %v", got, 1000)
+	}
+	if got := h.RawSum(); got != 0 {
+		t.Fatalf("unexpected RawSum; got %v; want %v", got, 0)
+	}
+
+	m := MergeHistograms([]*Histogram{h, h})
+	if got := m.Sum(); got != 2000 {
+		t.Fatalf("unexpected merged Sum; got %v; want %v", got, 2000)
+	}
+
+	b, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Histogram
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Count() != h.Count() || decoded.Sum() != 1000 || decoded.RawSum() != 0 {
+		t.Fatalf("unexpected decoded sums; got %v, %v, %v", decoded.Count(), decoded.Sum(), decoded.RawSum())
+	}
+
+	h.Reset()
+	if h.Sum() != 0 || h.RawSum() != 0 {
+		t.Fatalf("unexpected sums after Reset; got %v, %v", h.Sum(), h.RawSum())
+	}
+
+	h.Update(1)
+	h.Update(InfPos)
+	h.Update(1)
+	if h.Sum() != InfPos || h.RawSum() != InfPos {
+		t.Fatalf("unexpected sums with Inf; got %v, %v", h.Sum(), h.RawSum())
+	}
+}
+
+func TestHistogramUnit(t *testing.T) {
+	h := NewHistogram()
+	h.SetUnit("seconds")
+	h.Update(1)
+	h.Reset()
+	if h.Unit() != "seconds" {
+		t.Fatalf("unit must be kept by Reset; got %q", h.Unit())
+	}
+	if got := MergeHistograms([]*Histogram{h, NewHistogram()}).Unit(); got != "seconds" {
+		t.Fatalf("unexpected merged unit; got %q", got)
+	}
+}
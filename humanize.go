@@ -0,0 +1,70 @@
+package mathx
+
+import "strconv"
+
+var (
+	iecPrefixes = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi", "Ri", "Qi"}
+	siPrefixes  = []string{"", "k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q"}
+)
+
+// HumanizeBytes returns u as a short byte size with IEC prefixes, like "1.5 KiB".
+// Values are truncated to one decimal, so the shown size never exceeds u.
+func HumanizeBytes(u Uint128) string {
+	return string(appendScaled(nil, u.Uint256(), 1024, iecPrefixes, " ", "B"))
+}
+
+// HumanizeBytesSI returns u as a short byte size with SI prefixes, like "1.5 kB".
+// Values are truncated to one decimal, so the shown size never exceeds u.
+func HumanizeBytesSI(u Uint128) string {
+	return string(appendScaled(nil, u.Uint256(), 1000, siPrefixes, " ", "B"))
+}
+
+// HumanizeCount returns u as a short count with SI prefixes, like "1.5M".
+// Values are truncated to one decimal. Values of 1000Q (10^33) and above
+// are shown in scientific notation, like "1.1e77".
+func HumanizeCount(u Uint256) string {
+	if u.Cmp(sciThreshold) >= 0 {
+		return string(appendScientific(nil, u))
+	}
+	return string(appendScaled(nil, u, 1000, siPrefixes, "", ""))
+}
+
+// sciThreshold is 10^33, the first count not shown with an SI prefix.
+var sciThreshold = Uint256FromUint64(1e11).Mul(Uint256FromUint64(1e11)).Mul(Uint256FromUint64(1e11))
+
+// appendScaled appends u divided by the largest power of unit not above it,
+// with one decimal, followed by sep, the prefix and the suffix.
+func appendScaled(dst []byte, u Uint256, unit uint64, prefixes []string, sep, suffix string) []byte {
+	exp := 0
+	scale := Uint128FromUint64(1)
+	for exp+1 < len(prefixes) {
+		next, c := scale.mulAdd64(unit, 0)
+		if c != 0 || u.Cmp(next.Uint256()) < 0 {
+			break
+		}
+		scale = next
+		exp++
+	}
+
+	q, r := div256by128(u, scale)
+	if exp == 0 {
+		dst = q.AppendNumeric(dst)
+		return append(append(dst, sep...), suffix...)
+	}
+
+	tenth, _ := r.mulAdd64(10, 0)
+	t, _ := tenth.DivMod(scale)
+	dst = q.AppendNumeric(dst)
+	dst = append(dst, '.')
+	dst = strconv.AppendUint(dst, t.lo, 10)
+	dst = append(dst, sep...)
+	dst = append(dst, prefixes[exp]...)
+	return append(dst, suffix...)
+}
+
+// appendScientific appends u as d.de±N, truncated.
+func appendScientific(dst []byte, u Uint256) []byte {
+	s := u.AppendNumeric(nil)
+	dst = append(dst, s[0], '.', s[1], 'e')
+	return strconv.AppendInt(dst, int64(len(s)-1), 10)
+}
@@ -0,0 +1,46 @@
+package mathx
+
+import "testing"
+
+func TestHumanizeBytes(t *testing.T) {
+	testCases := []struct {
+		u       Uint128
+		iec, si string
+	}{
+		{Uint128FromUint64(0), "0 B", "0 B"},
+		{Uint128FromUint64(999), "999 B", "999 B"},
+		{Uint128FromUint64(1000), "1000 B", "1.0 kB"},
+		{Uint128FromUint64(1024), "1.0 KiB", "1.0 kB"},
+		{Uint128FromUint64(1536), "1.5 KiB", "1.5 kB"},
+		{Uint128FromUint64(1<<20 - 1), "1023.9 KiB", "1.0 MB"},
+		{Uint128FromUint64(1 << 60), "1.0 EiB", "1.1 EB"},
+		{MaxUint128, "268435455.9 QiB", "340282366.9 QB"},
+	}
+	for _, tc := range testCases {
+		if got := HumanizeBytes(tc.u); got != tc.iec {
+			t.Fatalf("unexpected HumanizeBytes(%v); got %q; want %q", tc.u, got, tc.iec)
+		}
+		if got := HumanizeBytesSI(tc.u); got != tc.si {
+			t.Fatalf("unexpected HumanizeBytesSI(%v); got %q; want %q", tc.u, got, tc.si)
+		}
+	}
+}
+
+func TestHumanizeCount(t *testing.T) {
+	testCases := []struct {
+		u    Uint256
+		want string
+	}{
+		{Uint256FromUint64(7), "7"},
+		{Uint256FromUint64(1234), "1.2k"},
+		{Uint256FromUint64(1e9), "1.0G"},
+		{sciThreshold.Dec(), "999.9Q"},
+		{sciThreshold, "1.0e33"},
+		{NewUint256(MaxUint128, MaxUint128), "1.1e77"},
+	}
+	for _, tc := range testCases {
+		if got := HumanizeCount(tc.u); got != tc.want {
+			t.Fatalf("unexpected HumanizeCount(%v); got %q; want %q", tc.u, got, tc.want)
+		}
+	}
+}
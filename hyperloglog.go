@@ -0,0 +1,124 @@
+package mathx
+
+import (
+	"math"
+	"math/bits"
+)
+
+// HyperLogLog estimates the number of distinct items in a stream.
+// Items are identified by their 64-bit hash.
+// The relative standard error is about 1.04/sqrt(2^precision).
+//
+// See: Flajolet, P., Fusy, É., Gandouet, O., Meunier, F. HyperLogLog: the analysis of a near-optimal cardinality estimation algorithm. https://doi.org/10.46298/dmtcs.3545
+type HyperLogLog struct {
+	p    uint8
+	regs []uint8
+}
+
+// NewHyperLogLog returns new HyperLogLog with 2^precision registers.
+// Precision must be in range [4, 18].
+func NewHyperLogLog(precision int) *HyperLogLog {
+	if precision < 4 || precision > 18 {
+		panic("mathx: hyperloglog precision must be in range [4, 18]")
+	}
+	return &HyperLogLog{
+		p:    uint8(precision),
+		regs: make([]uint8, 1<<precision),
+	}
+}
+
+// Precision of the estimator.
+func (h *HyperLogLog) Precision() int { return int(h.p) }
+
+// Reset resets the estimator.
+func (h *HyperLogLog) Reset() {
+	for i := range h.regs {
+		h.regs[i] = 0
+	}
+}
+
+// Add the item with the given hash.
+func (h *HyperLogLog) Add(hash uint64) {
+	hash = mix64(hash)
+	idx := hash >> (64 - h.p)
+	rank := uint8(bits.LeadingZeros64(hash<<h.p|1<<(h.p-1))) + 1
+	if rank > h.regs[idx] {
+		h.regs[idx] = rank
+	}
+}
+
+// Estimate returns the estimated number of distinct items.
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.regs))
+
+	var sum float64
+	var zeros int
+	for _, r := range h.regs {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	est := hllAlpha(len(h.regs)) * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// Small range correction, see linear counting.
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// Merge merges x into h.
+// Both estimators must have the same precision.
+func (h *HyperLogLog) Merge(x *HyperLogLog) error {
+	if h.p != x.p {
+		return ErrMismatch
+	}
+	for i, r := range x.regs {
+		if r > h.regs[i] {
+			h.regs[i] = r
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 1+len(h.regs))
+	b[0] = h.p
+	copy(b[1:], h.regs)
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (h *HyperLogLog) UnmarshalBinary(b []byte) error {
+	if len(b) < 1 {
+		return ErrInvalidEncoding
+	}
+	p := b[0]
+	if p < 4 || p > 18 || len(b) != 1+1<<p {
+		return ErrInvalidEncoding
+	}
+	for _, r := range b[1:] {
+		if r > 64-p+1 {
+			return ErrInvalidEncoding
+		}
+	}
+
+	h.p = p
+	h.regs = append(h.regs[:0], b[1:]...)
+	return nil
+}
+
+func hllAlpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
@@ -0,0 +1,63 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []uint64{10, 1000, 100000} {
+		h := NewHyperLogLog(14)
+		for i := uint64(0); i < n; i++ {
+			h.Add(i)
+			h.Add(i)
+		}
+
+		got := h.Estimate()
+		if err := math.Abs(float64(got)-float64(n)) / float64(n); err > 0.03 {
+			t.Fatalf("unexpected estimate for %v items; got %v (error %.3f)", n, got, err)
+		}
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a := NewHyperLogLog(12)
+	b := NewHyperLogLog(12)
+	for i := uint64(0); i < 20000; i++ {
+		a.Add(i)
+		b.Add(i + 10000)
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if got := a.Estimate(); got < 28000 || got > 32000 {
+		t.Fatalf("unexpected estimate; got %v; want about %v", got, 30000)
+	}
+	if err := a.Merge(NewHyperLogLog(10)); err != ErrMismatch {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+}
+
+func TestHyperLogLogBinary(t *testing.T) {
+	h := NewHyperLogLog(8)
+	for i := uint64(0); i < 500; i++ {
+		h.Add(i)
+	}
+
+	b, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got HyperLogLog
+	if err := got.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if got.Precision() != 8 || got.Estimate() != h.Estimate() {
+		t.Fatalf("unexpected estimator after decoding; got %v; want %v", got.Estimate(), h.Estimate())
+	}
+	if err := got.UnmarshalBinary(b[:10]); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+}
@@ -0,0 +1,110 @@
+package mathx
+
+// Index128 is a sorted map from Uint128 keys to values.
+// Keys are kept in a sorted slice, so lookups and scans are fast and compact,
+// while Insert and Delete move O(n) elements.
+// The zero value is an empty index ready to use.
+type Index128[V any] struct {
+	keys []Uint128
+	vals []V
+}
+
+// Len returns the number of entries.
+func (x *Index128[V]) Len() int { return len(x.keys) }
+
+// Reset removes all entries.
+func (x *Index128[V]) Reset() {
+	x.keys = x.keys[:0]
+	var zero V
+	for i := range x.vals {
+		x.vals[i] = zero // do not retain values.
+	}
+	x.vals = x.vals[:0]
+}
+
+// Get returns the value of key k and whether it exists.
+func (x *Index128[V]) Get(k Uint128) (V, bool) {
+	i := x.search(k)
+	if i < len(x.keys) && x.keys[i] == k {
+		return x.vals[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert sets the value of key k and reports whether the key is new.
+func (x *Index128[V]) Insert(k Uint128, v V) bool {
+	i := x.search(k)
+	if i < len(x.keys) && x.keys[i] == k {
+		x.vals[i] = v
+		return false
+	}
+
+	var zero V
+	x.keys = append(x.keys, Uint128{})
+	x.vals = append(x.vals, zero)
+	copy(x.keys[i+1:], x.keys[i:])
+	copy(x.vals[i+1:], x.vals[i:])
+	x.keys[i], x.vals[i] = k, v
+	return true
+}
+
+// Delete removes key k and reports whether it existed.
+func (x *Index128[V]) Delete(k Uint128) bool {
+	i := x.search(k)
+	if i == len(x.keys) || x.keys[i] != k {
+		return false
+	}
+
+	copy(x.keys[i:], x.keys[i+1:])
+	copy(x.vals[i:], x.vals[i+1:])
+	n := len(x.keys) - 1
+	var zero V
+	x.vals[n] = zero
+	x.keys, x.vals = x.keys[:n], x.vals[:n]
+	return true
+}
+
+// Seek returns the first entry with key >= k and whether there is one.
+func (x *Index128[V]) Seek(k Uint128) (Uint128, V, bool) {
+	i := x.search(k)
+	if i == len(x.keys) {
+		var zero V
+		return Uint128{}, zero, false
+	}
+	return x.keys[i], x.vals[i], true
+}
+
+// Ascend calls fn for entries with key >= from in ascending key order
+// until fn returns false.
+func (x *Index128[V]) Ascend(from Uint128, fn func(k Uint128, v V) bool) {
+	for i := x.search(from); i < len(x.keys); i++ {
+		if !fn(x.keys[i], x.vals[i]) {
+			return
+		}
+	}
+}
+
+// Scan calls fn for entries with keys in r in ascending key order
+// until fn returns false.
+func (x *Index128[V]) Scan(r Range128, fn func(k Uint128, v V) bool) {
+	for i := x.search(r.lo); i < len(x.keys) && x.keys[i].Cmp(r.hi) <= 0; i++ {
+		if !fn(x.keys[i], x.vals[i]) {
+			return
+		}
+	}
+}
+
+// search returns the index of the first key >= k.
+func (x *Index128[V]) search(k Uint128) int {
+	lo, hi := 0, len(x.keys)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if x.keys[m].Cmp(k) < 0 {
+			lo = m + 1
+		} else {
+			hi = m
+		}
+	}
+	return lo
+}
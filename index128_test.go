@@ -0,0 +1,98 @@
+package mathx
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestIndex128(t *testing.T) {
+	var x Index128[string]
+	u := Uint128FromUint64
+
+	for _, k := range []uint64{50, 10, 30, 20, 40} {
+		if !x.Insert(u(k), "v"+u(k).String()) {
+			t.Fatalf("key %d must be new", k)
+		}
+	}
+	if x.Insert(u(30), "thirty") {
+		t.Fatal("key 30 must exist")
+	}
+	if v, ok := x.Get(u(30)); !ok || v != "thirty" {
+		t.Fatalf("unexpected Get; got %v, %v", v, ok)
+	}
+	if _, ok := x.Get(u(35)); ok {
+		t.Fatal("unexpected Get of missing key")
+	}
+
+	if k, v, ok := x.Seek(u(31)); !ok || k != u(40) || v != "v40" {
+		t.Fatalf("unexpected Seek; got %v, %v, %v", k, v, ok)
+	}
+	if _, _, ok := x.Seek(u(51)); ok {
+		t.Fatal("unexpected Seek past the end")
+	}
+
+	var keys []Uint128
+	x.Scan(NewRange128(u(15), u(40)), func(k Uint128, _ string) bool {
+		keys = append(keys, k)
+		return true
+	})
+	if len(keys) != 3 || keys[0] != u(20) || keys[2] != u(40) {
+		t.Fatalf("unexpected Scan; got %v", keys)
+	}
+
+	if !x.Delete(u(10)) || x.Delete(u(10)) || x.Len() != 4 {
+		t.Fatal("unexpected Delete")
+	}
+
+	n := 0
+	x.Ascend(Uint128{}, func(Uint128, string) bool {
+		n++
+		return n < 2
+	})
+	if n != 2 {
+		t.Fatalf("unexpected Ascend calls; got %v; want %v", n, 2)
+	}
+
+	x.Reset()
+	if x.Len() != 0 {
+		t.Fatal("unexpected Len after Reset")
+	}
+}
+
+func TestIndex128Random(t *testing.T) {
+	var x Index128[int]
+	ref := map[Uint128]int{}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		k := NewUint128(uint64(rng.Intn(4)), uint64(rng.Intn(100)))
+		if rng.Intn(3) == 0 {
+			_, had := ref[k]
+			if x.Delete(k) != had {
+				t.Fatalf("unexpected Delete(%v)", k)
+			}
+			delete(ref, k)
+		} else {
+			x.Insert(k, i)
+			ref[k] = i
+		}
+	}
+
+	want := make([]Uint128, 0, len(ref))
+	for k := range ref {
+		want = append(want, k)
+	}
+	sort.Slice(want, func(i, j int) bool { return want[i].Cmp(want[j]) < 0 })
+
+	i := 0
+	x.Ascend(Uint128{}, func(k Uint128, v int) bool {
+		if k != want[i] || v != ref[k] {
+			t.Fatalf("unexpected entry %d; got %v=%v; want %v=%v", i, k, v, want[i], ref[want[i]])
+		}
+		i++
+		return true
+	})
+	if i != len(want) {
+		t.Fatalf("unexpected number of entries; got %v; want %v", i, len(want))
+	}
+}
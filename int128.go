@@ -0,0 +1,65 @@
+package mathx
+
+import "math/big"
+
+// Int128 represents an int128 in two's complement using Uint128 bits.
+type Int128 struct {
+	u Uint128
+	_ struct{}
+}
+
+func NewInt128(hi int64, lo uint64) Int128 {
+	return Int128{u: NewUint128(uint64(hi), lo)}
+}
+
+func Int128FromInt64(v int64) Int128 {
+	return NewInt128(v>>63, uint64(v))
+}
+
+func Int128FromUint64(v uint64) Int128 {
+	return NewInt128(0, v)
+}
+
+func (i Int128) Parts() (int64, uint64) { return int64(i.u.hi), i.u.lo }
+func (i Int128) IsZero() bool           { return i.u.IsZero() }
+func (i Int128) IsNeg() bool            { return int64(i.u.hi) < 0 }
+func (i Int128) Equals(x Int128) bool   { return i.u == x.u }
+func (i Int128) Add(x Int128) Int128    { return Int128{u: i.u.Add(x.u)} }
+func (i Int128) Sub(x Int128) Int128    { return Int128{u: i.u.Sub(x.u)} }
+func (i Int128) Neg() Int128            { return Int128{u: Uint128{}.Sub(i.u)} }
+
+func (i Int128) Cmp(x Int128) int {
+	// Flipping the sign bit maps two's complement order to unsigned order.
+	a := Uint128{hi: i.u.hi ^ 1<<63, lo: i.u.lo}
+	b := Uint128{hi: x.u.hi ^ 1<<63, lo: x.u.lo}
+	return a.Cmp(b)
+}
+
+func (i Int128) Sign() int {
+	switch {
+	case i.IsNeg():
+		return -1
+	case i.IsZero():
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Abs returns the absolute value of i.
+// Unlike Neg it does not overflow for the minimal Int128 value.
+func (i Int128) Abs() Uint128 {
+	if i.IsNeg() {
+		return Uint128{}.Sub(i.u)
+	}
+	return i.u
+}
+
+func (i Int128) Big() *big.Int { return i.SetBig(new(big.Int)) }
+
+func (i Int128) String() string {
+	if i.IsZero() {
+		return "0"
+	}
+	return i.Big().String()
+}
@@ -0,0 +1,66 @@
+package mathx
+
+import "math/big"
+
+// Int256 represents an int256 in two's complement using Uint256 bits.
+type Int256 struct {
+	u Uint256
+	_ struct{}
+}
+
+func NewInt256(hi Int128, lo Uint128) Int256 {
+	return Int256{u: NewUint256(hi.u, lo)}
+}
+
+func Int256FromInt64(v int64) Int256 {
+	return NewInt256(Int128FromInt64(v>>63), NewUint128(uint64(v>>63), uint64(v)))
+}
+
+func Int256FromUint64(v uint64) Int256 {
+	return Int256{u: Uint256FromUint64(v)}
+}
+
+func (i Int256) Parts() (Int128, Uint128) { return Int128{u: i.u.hi}, i.u.lo }
+func (i Int256) IsZero() bool             { return i.u.IsZero() }
+func (i Int256) IsNeg() bool              { return int64(i.u.hi.hi) < 0 }
+func (i Int256) Equals(x Int256) bool     { return i.u == x.u }
+func (i Int256) Add(x Int256) Int256      { return Int256{u: i.u.Add(x.u)} }
+func (i Int256) Sub(x Int256) Int256      { return Int256{u: i.u.Sub(x.u)} }
+func (i Int256) Neg() Int256              { return Int256{u: Uint256{}.Sub(i.u)} }
+
+func (i Int256) Cmp(x Int256) int {
+	// Flipping the sign bit maps two's complement order to unsigned order.
+	a, b := i.u, x.u
+	a.hi.hi ^= 1 << 63
+	b.hi.hi ^= 1 << 63
+	return a.Cmp(b)
+}
+
+func (i Int256) Sign() int {
+	switch {
+	case i.IsNeg():
+		return -1
+	case i.IsZero():
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Abs returns the absolute value of i.
+// Unlike Neg it does not overflow for the minimal Int256 value.
+func (i Int256) Abs() Uint256 {
+	if i.IsNeg() {
+		return Uint256{}.Sub(i.u)
+	}
+	return i.u
+}
+
+func (i Int256) Big() *big.Int { return i.SetBig(new(big.Int)) }
+
+func (i Int256) String() string {
+	if i.IsZero() {
+		return "0"
+	}
+	return i.Big().String()
+}
@@ -0,0 +1,70 @@
+package mathx
+
+import (
+	"net"
+	"net/netip"
+)
+
+// Uint128FromAddr returns the value of the 16-byte form of a and whether a is valid.
+// IPv4 addresses are converted to IPv4-mapped IPv6 addresses, zones are dropped.
+func Uint128FromAddr(a netip.Addr) (Uint128, bool) {
+	if !a.IsValid() {
+		return Uint128{}, false
+	}
+	b := a.As16()
+	return Uint128FromBytes(b[:]), true
+}
+
+// Addr returns u as an IPv6 address.
+func (u Uint128) Addr() netip.Addr { return netip.AddrFrom16(u.Bytes()) }
+
+// Uint128FromIP returns the value of the 16-byte form of ip and whether ip is
+// a valid 4-byte or 16-byte address. IPv4 addresses are converted to IPv4-mapped IPv6 addresses.
+func Uint128FromIP(ip net.IP) (Uint128, bool) {
+	if ip = ip.To16(); ip == nil {
+		return Uint128{}, false
+	}
+	return Uint128FromBytes(ip), true
+}
+
+// IP returns u as a 16-byte IPv6 address.
+func (u Uint128) IP() net.IP {
+	b := u.Bytes()
+	return net.IP(b[:])
+}
+
+// InRange reports whether lo <= u <= hi.
+func (u Uint128) InRange(lo, hi Uint128) bool {
+	return lo.Cmp(u) <= 0 && u.Cmp(hi) <= 0
+}
+
+// MaskPrefix returns u with all but the leading bits cleared, like the network address of a prefix.
+// bits is clamped to [0, 128].
+func (u Uint128) MaskPrefix(bits int) Uint128 { return u.And(prefixMask(bits)) }
+
+// Range128FromPrefix returns the range of addresses of p and whether p is valid.
+// IPv4 prefixes give ranges of IPv4-mapped IPv6 addresses.
+func Range128FromPrefix(p netip.Prefix) (Range128, bool) {
+	if !p.IsValid() {
+		return Range128{}, false
+	}
+	bits := p.Bits()
+	if p.Addr().Is4() {
+		bits += 96
+	}
+	u, _ := Uint128FromAddr(p.Addr())
+	mask := prefixMask(bits)
+	return Range128{lo: u.And(mask), hi: u.Or(mask.Not())}, true
+}
+
+// prefixMask returns the mask of the leading bits, clamped to [0, 128].
+func prefixMask(bits int) Uint128 {
+	switch {
+	case bits <= 0:
+		return Uint128{}
+	case bits >= 128:
+		return MaxUint128
+	default:
+		return MaxUint128.Lsh(uint(128 - bits))
+	}
+}
@@ -0,0 +1,83 @@
+package mathx
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestUint128Addr(t *testing.T) {
+	a := netip.MustParseAddr("2001:db8::1")
+	u, ok := Uint128FromAddr(a)
+	if want := NewUint128(0x20010db800000000, 1); !ok || u != want {
+		t.Fatalf("unexpected Uint128FromAddr; got %#x, %v; want %#x", u, ok, want)
+	}
+	if got := u.Addr(); got != a {
+		t.Fatalf("unexpected Addr; got %v; want %v", got, a)
+	}
+	if got := u.Add64(1).Addr().String(); got != "2001:db8::2" {
+		t.Fatalf("unexpected next address; got %v", got)
+	}
+
+	u, ok = Uint128FromAddr(netip.MustParseAddr("192.0.2.1"))
+	if want := NewUint128(0, 0xffffc0000201); !ok || u != want {
+		t.Fatalf("unexpected IPv4 address; got %#x, %v; want %#x", u, ok, want)
+	}
+	if got := u.Addr().Unmap().String(); got != "192.0.2.1" {
+		t.Fatalf("unexpected unmapped address; got %v", got)
+	}
+	if _, ok := Uint128FromAddr(netip.Addr{}); ok {
+		t.Fatalf("unexpected ok for invalid address")
+	}
+
+	ip := net.ParseIP("2001:db8::1")
+	u, ok = Uint128FromIP(ip)
+	if !ok || !u.IP().Equal(ip) {
+		t.Fatalf("unexpected IP roundtrip; got %v, %v; want %v", u.IP(), ok, ip)
+	}
+	if u, ok := Uint128FromIP(net.IPv4(192, 0, 2, 1).To4()); !ok || u != NewUint128(0, 0xffffc0000201) {
+		t.Fatalf("unexpected 4-byte IP; got %#x, %v", u, ok)
+	}
+	if _, ok := Uint128FromIP(net.IP{1, 2, 3}); ok {
+		t.Fatalf("unexpected ok for malformed IP")
+	}
+}
+
+func TestUint128Prefix(t *testing.T) {
+	u := NewUint128(0x20010db8aaaabbbb, 0xccccddddeeeeffff)
+	testCases := []struct {
+		bits int
+		want Uint128
+	}{
+		{-1, Uint128{}},
+		{0, Uint128{}},
+		{32, NewUint128(0x20010db800000000, 0)},
+		{64, NewUint128(0x20010db8aaaabbbb, 0)},
+		{72, NewUint128(0x20010db8aaaabbbb, 0xcc00000000000000)},
+		{128, u},
+		{200, u},
+	}
+	for _, tc := range testCases {
+		if got := u.MaskPrefix(tc.bits); got != tc.want {
+			t.Fatalf("unexpected MaskPrefix(%d); got %#x; want %#x", tc.bits, got, tc.want)
+		}
+	}
+
+	r, ok := Range128FromPrefix(netip.MustParsePrefix("2001:db8::/32"))
+	if !ok || r.Lo() != NewUint128(0x20010db800000000, 0) || r.Hi() != NewUint128(0x20010db8ffffffff, 1<<64-1) {
+		t.Fatalf("unexpected range; got %v, %v", r, ok)
+	}
+	if !u.InRange(r.Lo(), r.Hi()) || !r.Contains(u) || u.InRange(r.Hi().Inc(), MaxUint128) {
+		t.Fatalf("unexpected InRange of %#x", u)
+	}
+
+	r, ok = Range128FromPrefix(netip.MustParsePrefix("192.0.2.0/24"))
+	lo, _ := Uint128FromAddr(netip.MustParseAddr("192.0.2.0"))
+	hi, _ := Uint128FromAddr(netip.MustParseAddr("192.0.2.255"))
+	if !ok || r.Lo() != lo || r.Hi() != hi {
+		t.Fatalf("unexpected IPv4 range; got %v, %v", r, ok)
+	}
+	if _, ok := Range128FromPrefix(netip.Prefix{}); ok {
+		t.Fatalf("unexpected ok for invalid prefix")
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package mathx
+
+import "iter"
+
+// Samples returns an iterator over the samples of the reservoir in storage order.
+// The reservoir must not be modified during the iteration.
+func (r *Reservoir[T]) Samples() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.vals {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Samples returns an iterator over the samples of the histogram, unsorted.
+// The histogram must not be modified during the iteration.
+func (h *Histogram) Samples() iter.Seq[float64] { return h.res.Samples() }
+
+// All returns an iterator over the centroids sorted by mean.
+func (t *TDigest) All() iter.Seq[Centroid] {
+	t.compress()
+	return func(yield func(Centroid) bool) {
+		for _, c := range t.centroids {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the values and their counts sorted by value.
+// Compacted values are not included.
+func (h *CounterHistogram) All() iter.Seq2[int64, uint64] {
+	return func(yield func(int64, uint64) bool) {
+		for _, b := range h.Buckets(nil) {
+			if !yield(b.Value, b.Count) {
+				return
+			}
+		}
+	}
+}
+
+// Bins returns an iterator over the bins of the sketch sorted by value:
+// the representative value of each bin, as returned by Quantile, and its count.
+// The zero bin is included only if it has a count.
+func (s *DDSketch) Bins() iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		for _, k := range sortedKeys(s.neg, true) {
+			if !yield(-s.value(k), s.neg[k]) {
+				return
+			}
+		}
+		if s.zero != 0 && !yield(0, s.zero) {
+			return
+		}
+		for _, k := range sortedKeys(s.pos, false) {
+			if !yield(s.value(k), s.pos[k]) {
+				return
+			}
+		}
+	}
+}
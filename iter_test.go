@@ -0,0 +1,65 @@
+//go:build go1.23
+
+package mathx
+
+import "testing"
+
+func TestIterators(t *testing.T) {
+	h := NewHistogram()
+	var want float64
+	for i := 1; i <= 10; i++ {
+		h.Update(float64(i))
+		want += float64(i)
+	}
+	var sum float64
+	for v := range h.Samples() {
+		sum += v
+	}
+	if sum != want {
+		t.Fatalf("unexpected sum of samples; got %v; want %v", sum, want)
+	}
+	for range h.Samples() {
+		break
+	}
+
+	c := NewCounterHistogram(0)
+	c.Add(404, 2)
+	c.Add(200, 5)
+	var got []CounterBucket
+	for v, n := range c.All() {
+		got = append(got, CounterBucket{v, n})
+	}
+	if len(got) != 2 || got[0] != (CounterBucket{200, 5}) || got[1] != (CounterBucket{404, 2}) {
+		t.Fatalf("unexpected buckets; got %v", got)
+	}
+
+	td := NewTDigest(100)
+	for i := 0; i < 10; i++ {
+		td.Add(float64(i))
+	}
+	prev, count := InfNeg, 0.0
+	for c := range td.All() {
+		if c.Mean < prev {
+			t.Fatalf("centroids must be sorted; got %v after %v", c.Mean, prev)
+		}
+		prev, count = c.Mean, count+c.Weight
+	}
+	if count != 10 {
+		t.Fatalf("unexpected total weight; got %v", count)
+	}
+
+	s := NewDDSketch(0.01)
+	for _, v := range []float64{-5, -1, 0, 0, 1, 100} {
+		s.Add(v)
+	}
+	prev, count = InfNeg, 0
+	for v, n := range s.Bins() {
+		if v <= prev {
+			t.Fatalf("bins must be sorted; got %v after %v", v, prev)
+		}
+		prev, count = v, count+n
+	}
+	if count != s.Count() {
+		t.Fatalf("unexpected total count; got %v; want %v", count, s.Count())
+	}
+}
@@ -0,0 +1,51 @@
+package mathx
+
+// MarshalJSON implements json.Marshaler.
+// The value is encoded as a quoted decimal string, because JSON numbers
+// above 2^53 are not represented exactly by most decoders.
+func (u Uint128) MarshalJSON() ([]byte, error) {
+	b := append(make([]byte, 0, 42), '"')
+	b = u.AppendNumeric(b)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a number or a string like Uint128FromString, so a quoted
+// decimal or a quoted 0x-prefixed hex string. null is a no-op.
+func (u *Uint128) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	quoted := len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"'
+	if quoted {
+		s = s[1 : len(s)-1]
+	} else if len(s) > 1 && s[0] == '0' {
+		// JSON numbers have no leading zeros and no prefixes.
+		return &SyntaxError{Offset: 1}
+	}
+	v, err := Uint128FromString(s)
+	if err != nil {
+		if e, ok := err.(*SyntaxError); ok && quoted {
+			e.Offset++ // the opening quote
+		}
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// HexUint128 is a Uint128 encoded in JSON as a quoted hex string like "0x1f".
+type HexUint128 Uint128
+
+// MarshalJSON implements json.Marshaler.
+func (h HexUint128) MarshalJSON() ([]byte, error) {
+	b := append(make([]byte, 0, 36), `"0x`...)
+	b = Uint128(h).Append(b, 16)
+	return append(b, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, see Uint128.UnmarshalJSON.
+func (h *HexUint128) UnmarshalJSON(b []byte) error {
+	return (*Uint128)(h).UnmarshalJSON(b)
+}
@@ -0,0 +1,55 @@
+package mathx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUint128JSON(t *testing.T) {
+	type payload struct {
+		A Uint128    `json:"a"`
+		B HexUint128 `json:"b"`
+		C *Uint128   `json:"c"`
+	}
+
+	p := payload{A: MaxUint128, B: HexUint128(Uint128FromUint64(31))}
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":"340282366920938463463374607431768211455","b":"0x1f","c":null}`
+	if string(b) != want {
+		t.Fatalf("unexpected JSON; got %s; want %s", b, want)
+	}
+
+	var got payload
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != p {
+		t.Fatalf("unexpected roundtrip; got %+v; want %+v", got, p)
+	}
+
+	testCases := []struct {
+		in   string
+		want uint64
+	}{
+		{`123`, 123},
+		{`"456"`, 456},
+		{`"0xff"`, 255},
+		{`0`, 0},
+	}
+	for _, tc := range testCases {
+		var u Uint128
+		if err := json.Unmarshal([]byte(tc.in), &u); err != nil || u != Uint128FromUint64(tc.want) {
+			t.Fatalf("unexpected Unmarshal(%s); got %v, %v; want %v", tc.in, u, err, tc.want)
+		}
+	}
+
+	for _, in := range []string{`"abc"`, `-1`, `1.5`, `"340282366920938463463374607431768211456"`, `0x10`, `"`} {
+		var u Uint128
+		if err := u.UnmarshalJSON([]byte(in)); err == nil {
+			t.Fatalf("unexpected success for %s", in)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package mathx
+
+import "math"
+
+// Sinc returns the normalized sinc function sin(πx) / (πx), 1 at x == 0.
+// The argument is reduced exactly, so Sinc is 0 at every nonzero integer.
+func Sinc(x float64) float64 {
+	switch {
+	case math.IsInf(x, 0):
+		return 0
+	case math.Abs(x) < 1e-4:
+		// Taylor series, the next term is below 1e-21.
+		px2 := (math.Pi * x) * (math.Pi * x)
+		return 1 - px2/6*(1-px2/20)
+	}
+	return sinPi(x) / (math.Pi * x)
+}
+
+// Sincs appends Sinc of each xs value to dst.
+func Sincs(dst, xs []float64) []float64 {
+	for _, x := range xs {
+		dst = append(dst, Sinc(x))
+	}
+	return dst
+}
+
+// Gaussian returns the normal probability density with mean mu
+// and standard deviation sigma at x. It is NaN for sigma <= 0.
+func Gaussian(x, mu, sigma float64) float64 {
+	if !(sigma > 0) {
+		return NaN
+	}
+	z := (x - mu) / sigma
+	return math.Exp(-0.5*z*z) / (sigma * sqrt2Pi)
+}
+
+// Gaussians appends Gaussian of each xs value to dst.
+func Gaussians(dst, xs []float64, mu, sigma float64) []float64 {
+	for _, x := range xs {
+		dst = append(dst, Gaussian(x, mu, sigma))
+	}
+	return dst
+}
+
+// Logistic returns the logistic function 1 / (1 + e^(-k(x-x0)))
+// with steepness k and midpoint x0. It never overflows.
+func Logistic(x, k, x0 float64) float64 {
+	t := k * (x - x0)
+	if t >= 0 {
+		return 1 / (1 + math.Exp(-t))
+	}
+	e := math.Exp(t)
+	return e / (1 + e)
+}
+
+// Logistics appends Logistic of each xs value to dst.
+func Logistics(dst, xs []float64, k, x0 float64) []float64 {
+	for _, x := range xs {
+		dst = append(dst, Logistic(x, k, x0))
+	}
+	return dst
+}
+
+const sqrt2Pi = 2.50662827463100050241576528481104525300698674060993831662992357
+
+// sinPi returns sin(πx) with exact argument reduction.
+func sinPi(x float64) float64 {
+	r := math.Mod(x, 2) // exact, in (-2, 2)
+	s := 1.0
+	if r < 0 {
+		r, s = -r, -1
+	}
+	if r > 1 {
+		r, s = r-1, -s
+	}
+	if r > 0.5 {
+		r = 1 - r
+	}
+	return s * math.Sin(math.Pi*r)
+}
@@ -0,0 +1,71 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSinc(t *testing.T) {
+	testCases := []struct {
+		x, want float64
+	}{
+		{0, 1},
+		{1, 0},
+		{-3, 0},
+		{1e15, 0},
+		{0.5, 2 / math.Pi},
+		{-0.5, 2 / math.Pi},
+		{1.5, -2 / (3 * math.Pi)},
+		{1e-9, 1},
+		{InfPos, 0},
+	}
+	for _, tc := range testCases {
+		if got := Sinc(tc.x); math.Abs(got-tc.want) > 1e-16 {
+			t.Fatalf("unexpected Sinc(%v); got %v; want %v", tc.x, got, tc.want)
+		}
+	}
+
+	// the series and direct formulas must agree at the switch point.
+	x := 1e-4
+	direct := math.Sin(math.Pi*x) / (math.Pi * x)
+	if got := Sinc(x); math.Abs(got-direct) > 1e-15 {
+		t.Fatalf("unexpected Sinc(%v); got %v; want %v", x, got, direct)
+	}
+}
+
+func TestGaussian(t *testing.T) {
+	if got, want := Gaussian(0, 0, 1), 1/math.Sqrt(2*math.Pi); math.Abs(got-want) > 1e-16 {
+		t.Fatalf("unexpected Gaussian; got %v; want %v", got, want)
+	}
+	if got, want := Gaussian(3, 1, 2), math.Exp(-0.5)/(2*math.Sqrt(2*math.Pi)); math.Abs(got-want) > 1e-16 {
+		t.Fatalf("unexpected Gaussian; got %v; want %v", got, want)
+	}
+	if got := Gaussian(1e300, 0, 1e-300); got != 0 {
+		t.Fatalf("unexpected Gaussian; got %v; want %v", got, 0)
+	}
+	if got := Gaussian(0, 0, 0); !math.IsNaN(got) {
+		t.Fatalf("unexpected Gaussian; got %v; want NaN", got)
+	}
+}
+
+func TestLogistic(t *testing.T) {
+	testCases := []struct {
+		x, k, x0, want float64
+	}{
+		{0, 1, 0, 0.5},
+		{5, 2, 5, 0.5},
+		{1000, 1, 0, 1},
+		{-1000, 1, 0, 0},
+		{-10, 1, 0, 1 / (1 + math.Exp(10))},
+	}
+	for _, tc := range testCases {
+		if got := Logistic(tc.x, tc.k, tc.x0); math.Abs(got-tc.want) > 1e-16 {
+			t.Fatalf("unexpected Logistic(%v, %v, %v); got %v; want %v", tc.x, tc.k, tc.x0, got, tc.want)
+		}
+	}
+
+	got := Logistics(nil, []float64{-1000, 0, 1000}, 1, 0)
+	if len(got) != 3 || got[0] != 0 || got[1] != 0.5 || got[2] != 1 {
+		t.Fatalf("unexpected Logistics; got %v", got)
+	}
+}
@@ -0,0 +1,32 @@
+package mathx
+
+// Log2 returns the floor of the base-2 logarithm of u
+// and whether u is an exact power of two.
+// For u == 0 it returns -1, false.
+func (u Uint128) Log2() (int, bool) {
+	return u.BitLen() - 1, u.OnesCount() == 1
+}
+
+// Log10 returns the floor of the base-10 logarithm of u
+// and whether u is an exact power of ten.
+// For u == 0 it returns -1, false.
+func (u Uint128) Log10() (int, bool) {
+	if u.IsZero() {
+		return -1, false
+	}
+	// log10(2) ~ 1233/4096, the estimate is exact or one too large.
+	n := (u.BitLen() * 1233) >> 12
+	if u.Cmp(pow10Uint128[n]) < 0 {
+		n--
+	}
+	return n, u == pow10Uint128[n]
+}
+
+// pow10Uint128 contains powers of ten that fit into Uint128.
+var pow10Uint128 = func() (tab [39]Uint128) {
+	tab[0] = Uint128{lo: 1}
+	for i := 1; i < len(tab); i++ {
+		tab[i], _ = tab[i-1].mulAdd64(10, 0)
+	}
+	return tab
+}()
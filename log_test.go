@@ -0,0 +1,51 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUint128Log2(t *testing.T) {
+	if n, exact := (Uint128{}).Log2(); n != -1 || exact {
+		t.Fatalf("unexpected Log2(0); got %v, %v", n, exact)
+	}
+	for i := uint(0); i < 128; i++ {
+		p := NewUint128(0, 1).Lsh(i)
+		if n, exact := p.Log2(); n != int(i) || !exact {
+			t.Fatalf("unexpected Log2(2**%d); got %v, %v", i, n, exact)
+		}
+		if i > 1 {
+			if n, exact := p.Dec().Log2(); n != int(i)-1 || exact {
+				t.Fatalf("unexpected Log2(2**%d-1); got %v, %v", i, n, exact)
+			}
+		}
+	}
+}
+
+func TestUint128Log10(t *testing.T) {
+	if n, exact := (Uint128{}).Log10(); n != -1 || exact {
+		t.Fatalf("unexpected Log10(0); got %v, %v", n, exact)
+	}
+	p := NewUint128(0, 1)
+	for i := 0; i <= 38; i++ {
+		if n, exact := p.Log10(); n != i || !exact {
+			t.Fatalf("unexpected Log10(10**%d); got %v, %v", i, n, exact)
+		}
+		if n, exact := p.Inc().Log10(); i > 0 && (n != i || exact) {
+			t.Fatalf("unexpected Log10(10**%d+1); got %v, %v", i, n, exact)
+		}
+		if n, exact := p.Dec().Log10(); i > 0 && (n != i-1 || exact) {
+			t.Fatalf("unexpected Log10(10**%d-1); got %v, %v", i, n, exact)
+		}
+		p = p.Mul(NewUint128(0, 10))
+	}
+	if n, _ := NewUint128(math.MaxUint64, math.MaxUint64).Log10(); n != 38 {
+		t.Fatalf("unexpected Log10(max); got %v; want 38", n)
+	}
+	for i := uint(0); i < 128; i++ {
+		u := NewUint128(0, 1).Lsh(i)
+		if n, _ := u.Log10(); n != len(u.String())-1 {
+			t.Fatalf("unexpected Log10(2**%d); got %v; want %v", i, n, len(u.String())-1)
+		}
+	}
+}
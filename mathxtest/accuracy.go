@@ -0,0 +1,80 @@
+package mathxtest
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+
+	"github.com/cristalhq/mathx"
+)
+
+// BigFloatFunc is a reference model of a unary float function, z = f(x).
+type BigFloatFunc func(z, x *big.Float) *big.Float
+
+// BigFloatSqrt is the reference model of Sqrt2.
+var BigFloatSqrt BigFloatFunc = (*big.Float).Sqrt
+
+// Domain generates inputs of a function under test.
+type Domain func(r *rand.Rand) mathx.Double
+
+// UniformDomain returns a Domain of Doubles uniformly distributed in [lo, hi),
+// with random low parts so all 106 bits are exercised.
+func UniformDomain(lo, hi float64) Domain {
+	return func(r *rand.Rand) mathx.Double {
+		x := lo + (hi-lo)*r.Float64()
+		return mathx.DoubleFromSum(x, x*0x1p-53*(r.Float64()-0.5))
+	}
+}
+
+// AccuracyReport summarizes the errors of a function in units of the last place
+// of a Double, which has 106 significant bits.
+type AccuracyReport struct {
+	N          int          // number of inputs
+	MaxULP     float64      // largest error, +Inf for NaN or wrong zero results
+	MeanULP    float64      // mean error
+	WorstInput mathx.Double // input with the largest error
+}
+
+func (r AccuracyReport) String() string {
+	return fmt.Sprintf("n=%d max=%.3g ulp mean=%.3g ulp worst input=%v", r.N, r.MaxULP, r.MeanULP, r.WorstInput)
+}
+
+// CheckAccuracy compares fn against the reference ref on n inputs from domain
+// and returns the error report. Inputs are generated from a fixed seed,
+// so reports are comparable between runs, e.g. to catch accuracy regressions in CI.
+func CheckAccuracy(fn func(x mathx.Double) mathx.Double, ref BigFloatFunc, domain Domain, n int) AccuracyReport {
+	r := rand.New(rand.NewSource(1))
+	report := AccuracyReport{N: n}
+	var sum float64
+	for i := 0; i < n; i++ {
+		x := domain(r)
+		ulps := ulpError(fn(x), ref(newFloat(), DoubleBig(x)))
+		sum += ulps
+		if ulps > report.MaxULP || i == 0 {
+			report.MaxULP, report.WorstInput = ulps, x
+		}
+	}
+	if n > 0 {
+		report.MeanULP = sum / float64(n)
+	}
+	return report
+}
+
+// ulpError returns |got - want| in units of the last place of want in Double precision.
+func ulpError(got mathx.Double, want *big.Float) float64 {
+	if hi, lo := got.Parts(); math.IsNaN(hi) || math.IsNaN(lo) || math.IsInf(hi, 0) {
+		return math.Inf(1)
+	}
+	diff := newFloat().Sub(DoubleBig(got), want)
+	if want.Sign() == 0 {
+		if diff.Sign() == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	// want = mant * 2**exp with mant in [0.5, 1), its ulp is 2**(exp-106).
+	exp := want.MantExp(nil)
+	ulps, _ := diff.Abs(diff).SetMantExp(diff, 106-exp).Float64()
+	return ulps
+}
@@ -0,0 +1,50 @@
+package mathxtest
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/cristalhq/mathx"
+)
+
+func TestCheckAccuracy(t *testing.T) {
+	report := CheckAccuracy(mathx.Sqrt2, BigFloatSqrt, UniformDomain(0.5, 1e6), 1000)
+	if report.N != 1000 || report.MaxULP > 4 || report.MeanULP > report.MaxULP {
+		t.Fatalf("unexpected Sqrt2 accuracy; got %v", report)
+	}
+
+	// A float64 square root is off by about 2**53 Double ulps.
+	naive := func(x mathx.Double) mathx.Double {
+		hi, _ := x.Parts()
+		return mathx.DoubleFromFloat(math.Sqrt(hi))
+	}
+	report = CheckAccuracy(naive, BigFloatSqrt, UniformDomain(0.5, 1e6), 1000)
+	if report.MaxULP < 0x1p40 {
+		t.Fatalf("unexpected naive sqrt accuracy; got %v", report)
+	}
+	if got := ulpError(naive(report.WorstInput), BigFloatSqrt(newFloat(), DoubleBig(report.WorstInput))); got != report.MaxULP {
+		t.Fatalf("unexpected worst input error; got %v; want %v", got, report.MaxULP)
+	}
+}
+
+func TestULPError(t *testing.T) {
+	one := mathx.DoubleFromFloat(1)
+	testCases := []struct {
+		got  mathx.Double
+		want *big.Float
+		ulps float64
+	}{
+		{one, newFloat().SetFloat64(1), 0},
+		{mathx.DoubleFromSum(1, 0x1p-105), newFloat().SetFloat64(1), 1},
+		{mathx.DoubleFromSum(2, -0x1p-102), newFloat().SetFloat64(2), 4},
+		{mathx.DoubleZero, newFloat(), 0},
+		{one, newFloat(), math.Inf(1)},
+		{mathx.DoubleNaN, newFloat().SetFloat64(1), math.Inf(1)},
+	}
+	for _, tc := range testCases {
+		if got := ulpError(tc.got, tc.want); got != tc.ulps {
+			t.Fatalf("unexpected ulpError(%v, %v); got %v; want %v", tc.got, tc.want, got, tc.ulps)
+		}
+	}
+}
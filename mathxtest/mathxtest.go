@@ -0,0 +1,134 @@
+// Package mathxtest provides reference-model checkers for mathx types.
+//
+// Operations on Uint128 and Uint256 are compared against math/big.Int
+// with results reduced modulo 2^128 and 2^256, operations on Double
+// are compared against math/big.Float. Use them in tests and fuzz targets
+// of code built on top of mathx. CheckAccuracy reports ulp errors of Double
+// functions for tracking accuracy regressions.
+package mathxtest
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/cristalhq/mathx"
+)
+
+// BigIntOp is a reference model of a binary integer operation, z = x op y.
+type BigIntOp func(z, x, y *big.Int) *big.Int
+
+// BigFloatOp is a reference model of a binary float operation, z = x op y.
+type BigFloatOp func(z, x, y *big.Float) *big.Float
+
+// Reference models of common operations.
+var (
+	BigAdd BigIntOp = (*big.Int).Add
+	BigSub BigIntOp = (*big.Int).Sub
+	BigMul BigIntOp = (*big.Int).Mul
+	BigAnd BigIntOp = (*big.Int).And
+	BigOr  BigIntOp = (*big.Int).Or
+	BigXor BigIntOp = (*big.Int).Xor
+
+	BigFloatAdd BigFloatOp = (*big.Float).Add
+	BigFloatSub BigFloatOp = (*big.Float).Sub
+	BigFloatMul BigFloatOp = (*big.Float).Mul
+	BigFloatQuo BigFloatOp = (*big.Float).Quo
+)
+
+// CheckUint128 reports an error to tb if op(x, y) differs from ref(x, y) mod 2^128.
+func CheckUint128(tb testing.TB, name string, op func(x, y mathx.Uint128) mathx.Uint128, ref BigIntOp, x, y mathx.Uint128) {
+	tb.Helper()
+	if err := compareInt(op(x, y).Big(), ref(new(big.Int), x.Big(), y.Big()), 128); err != nil {
+		tb.Errorf("%s(%v, %v): %v", name, x, y, err)
+	}
+}
+
+// CheckUint256 reports an error to tb if op(x, y) differs from ref(x, y) mod 2^256.
+func CheckUint256(tb testing.TB, name string, op func(x, y mathx.Uint256) mathx.Uint256, ref BigIntOp, x, y mathx.Uint256) {
+	tb.Helper()
+	if err := compareInt(op(x, y).Big(), ref(new(big.Int), x.Big(), y.Big()), 256); err != nil {
+		tb.Errorf("%s(%v, %v): %v", name, x, y, err)
+	}
+}
+
+// CheckDouble reports an error to tb if op(x, y) differs from ref(x, y)
+// by more than relTol relative error. Double arithmetic is accurate to about 1e-31.
+func CheckDouble(tb testing.TB, name string, op func(x, y mathx.Double) mathx.Double, ref BigFloatOp, x, y mathx.Double, relTol float64) {
+	tb.Helper()
+	got := DoubleBig(op(x, y))
+	want := ref(newFloat(), DoubleBig(x), DoubleBig(y))
+	if err := compareFloat(got, want, relTol); err != nil {
+		tb.Errorf("%s(%v, %v): %v", name, x, y, err)
+	}
+}
+
+// DoubleBig returns d as an exact big.Float.
+func DoubleBig(d mathx.Double) *big.Float {
+	hi, lo := d.Parts()
+	f := newFloat().SetFloat64(hi)
+	return f.Add(f, newFloat().SetFloat64(lo))
+}
+
+// RandUint128 returns a random Uint128 biased towards edge cases:
+// zero, small values and values near limb boundaries.
+func RandUint128(r *rand.Rand) mathx.Uint128 {
+	return mathx.NewUint128(randLimb(r), randLimb(r))
+}
+
+// RandUint256 returns a random Uint256 biased towards edge cases.
+func RandUint256(r *rand.Rand) mathx.Uint256 {
+	return mathx.NewUint256(RandUint128(r), RandUint128(r))
+}
+
+// RandDouble returns a random normalized Double with magnitude in [2^-64, 2^64).
+func RandDouble(r *rand.Rand) mathx.Double {
+	hi := (r.Float64() + 0.5) * float64(uint64(1)<<r.Intn(64)) / float64(uint64(1)<<r.Intn(64))
+	if r.Intn(2) == 0 {
+		hi = -hi
+	}
+	lo := hi * 0x1p-53 * (r.Float64() - 0.5)
+	return mathx.DoubleFromSum(hi, lo)
+}
+
+func randLimb(r *rand.Rand) uint64 {
+	switch r.Intn(8) {
+	case 0:
+		return 0
+	case 1:
+		return uint64(r.Intn(16))
+	case 2:
+		return ^uint64(r.Intn(16))
+	case 3:
+		return 1<<63 + uint64(r.Intn(16)) - 8
+	default:
+		return r.Uint64()
+	}
+}
+
+func compareInt(got, want *big.Int, bits uint) error {
+	mod := new(big.Int).Lsh(big.NewInt(1), bits)
+	want = new(big.Int).Mod(want, mod)
+	if got.Cmp(want) != 0 {
+		return fmt.Errorf("got %v; want %v", got, want)
+	}
+	return nil
+}
+
+func compareFloat(got, want *big.Float, relTol float64) error {
+	if want.Sign() == 0 {
+		if got.Sign() != 0 {
+			return fmt.Errorf("got %v; want 0", got)
+		}
+		return nil
+	}
+	diff := newFloat().Sub(got, want)
+	diff.Quo(diff, want)
+	if d, _ := diff.Abs(diff).Float64(); d > relTol {
+		return fmt.Errorf("got %v; want %v; relative error %g", got, want, d)
+	}
+	return nil
+}
+
+func newFloat() *big.Float { return new(big.Float).SetPrec(2048) }
@@ -0,0 +1,56 @@
+package mathxtest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/cristalhq/mathx"
+)
+
+func TestUint128(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		x, y := RandUint128(r), RandUint128(r)
+		CheckUint128(t, "Add", mathx.Uint128.Add, BigAdd, x, y)
+		CheckUint128(t, "Sub", mathx.Uint128.Sub, BigSub, x, y)
+		CheckUint128(t, "Mul", mathx.Uint128.Mul, BigMul, x, y)
+		CheckUint128(t, "Xor", mathx.Uint128.Xor, BigXor, x, y)
+	}
+}
+
+func TestUint256(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		x, y := RandUint256(r), RandUint256(r)
+		CheckUint256(t, "Add", mathx.Uint256.Add, BigAdd, x, y)
+		CheckUint256(t, "Sub", mathx.Uint256.Sub, BigSub, x, y)
+		CheckUint256(t, "Mul", mathx.Uint256.Mul, BigMul, x, y)
+		CheckUint256(t, "And", mathx.Uint256.And, BigAnd, x, y)
+	}
+}
+
+func TestDouble(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		x, y := RandDouble(r), RandDouble(r)
+		CheckDouble(t, "Mul", mathx.Double.Mul, BigFloatMul, x, y, 1e-30)
+		CheckDouble(t, "Div", mathx.Double.Div, BigFloatQuo, x, y, 1e-30)
+	}
+}
+
+func TestCheckerFails(t *testing.T) {
+	ft := &fakeTB{TB: t}
+	wrong := func(x, y mathx.Uint128) mathx.Uint128 { return x.Add(y).Inc() }
+	CheckUint128(ft, "Add", wrong, BigAdd, mathx.Uint128FromUint64(1), mathx.Uint128FromUint64(2))
+	if !ft.failed {
+		t.Fatal("checker must report a mismatch")
+	}
+}
+
+type fakeTB struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeTB) Helper()                       {}
+func (f *fakeTB) Errorf(string, ...interface{}) { f.failed = true }
@@ -0,0 +1,57 @@
+package mathx
+
+// Midpoint returns (a+b)/2 rounded toward negative infinity, without overflow.
+func Midpoint[T Integer](a, b T) T {
+	return a&b + (a^b)>>1
+}
+
+// Average returns (a+b)/2 rounded according to mode, without overflow.
+// RoundDown and RoundUp round toward and away from zero.
+func Average[T Integer](a, b T, mode RoundingMode) T {
+	m := Midpoint(a, b)
+	if (a^b)&1 == 0 {
+		return m
+	}
+	// The exact value is m + 1/2.
+	var up bool
+	switch mode {
+	case RoundUp, RoundHalfUp:
+		up = m >= 0
+	case RoundHalfEven:
+		up = m&1 == 1
+	default:
+		up = m < 0
+	}
+	if up {
+		m++
+	}
+	return m
+}
+
+// Midpoint returns (u+x)/2 rounded down, without overflow.
+func (u Uint128) Midpoint(x Uint128) Uint128 {
+	return u.And(x).Add(u.Xor(x).Rsh(1))
+}
+
+// Average returns (u+x)/2 rounded according to mode, without overflow.
+func (u Uint128) Average(x Uint128, mode RoundingMode) Uint128 {
+	m := u.Midpoint(x)
+	if (u.lo^x.lo)&1 == 1 && mode.roundUp(1, 2, m.lo) {
+		m = m.Inc()
+	}
+	return m
+}
+
+// Midpoint returns (u+x)/2 rounded down, without overflow.
+func (u Uint256) Midpoint(x Uint256) Uint256 {
+	return u.And(x).Add(u.Xor(x).Rsh(1))
+}
+
+// Average returns (u+x)/2 rounded according to mode, without overflow.
+func (u Uint256) Average(x Uint256, mode RoundingMode) Uint256 {
+	m := u.Midpoint(x)
+	if (u.lo.lo^x.lo.lo)&1 == 1 && mode.roundUp(1, 2, m.lo.lo) {
+		m = m.Inc()
+	}
+	return m
+}
@@ -0,0 +1,75 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMidpoint(t *testing.T) {
+	if got := Midpoint[int64](math.MaxInt64, math.MaxInt64-2); got != math.MaxInt64-1 {
+		t.Fatalf("unexpected Midpoint; got %v", got)
+	}
+	if got := Midpoint[int8](-128, 127); got != -1 {
+		t.Fatalf("unexpected Midpoint; got %v; want -1", got)
+	}
+	if got := Midpoint[uint8](255, 254); got != 254 {
+		t.Fatalf("unexpected Midpoint; got %v; want 254", got)
+	}
+
+	// Compare with exact arithmetic on all int8 pairs.
+	for a := -128; a <= 127; a++ {
+		for b := -128; b <= 127; b++ {
+			sum := a + b
+			floor := int(math.Floor(float64(sum) / 2))
+			if got := int(Midpoint(int8(a), int8(b))); got != floor {
+				t.Fatalf("unexpected Midpoint(%d, %d); got %v; want %v", a, b, got, floor)
+			}
+			for _, mode := range []RoundingMode{RoundDown, RoundUp, RoundHalfUp, RoundHalfEven} {
+				want := floor
+				if sum%2 != 0 {
+					switch mode {
+					case RoundDown:
+						want = int(math.Trunc(float64(sum) / 2))
+					case RoundUp, RoundHalfUp:
+						want = int(math.Round(float64(sum) / 2))
+					case RoundHalfEven:
+						want = int(math.RoundToEven(float64(sum) / 2))
+					}
+				}
+				if got := int(Average(int8(a), int8(b), mode)); got != want {
+					t.Fatalf("unexpected Average(%d, %d, %d); got %v; want %v", a, b, mode, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestUint128Midpoint(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	if got := max.Midpoint(max.Dec().Dec()); got != max.Dec() {
+		t.Fatalf("unexpected Midpoint; got %v; want %v", got, max.Dec())
+	}
+	if got := max.Midpoint(Uint128{}); got != NewUint128(math.MaxInt64, math.MaxUint64) {
+		t.Fatalf("unexpected Midpoint; got %v", got)
+	}
+	if got := max.Average(max.Dec(), RoundUp); got != max {
+		t.Fatalf("unexpected Average; got %v; want %v", got, max)
+	}
+	if got := NewUint128(0, 5).Average(NewUint128(0, 2), RoundHalfEven); got != NewUint128(0, 4) {
+		t.Fatalf("unexpected Average; got %v; want 4", got)
+	}
+	if got := NewUint128(0, 5).Average(NewUint128(0, 0), RoundHalfEven); got != NewUint128(0, 2) {
+		t.Fatalf("unexpected Average; got %v; want 2", got)
+	}
+
+	max256 := NewUint256(max, max)
+	if got := max256.Midpoint(max256); got != max256 {
+		t.Fatalf("unexpected Midpoint; got %v; want %v", got, max256)
+	}
+	if got := max256.Average(max256.Dec(), RoundDown); got != max256.Dec() {
+		t.Fatalf("unexpected Average; got %v; want %v", got, max256.Dec())
+	}
+	if got := max256.Average(max256.Dec(), RoundHalfUp); got != max256 {
+		t.Fatalf("unexpected Average; got %v; want %v", got, max256)
+	}
+}
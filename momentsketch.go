@@ -0,0 +1,328 @@
+package mathx
+
+import "math"
+
+// MomentSketch keeps the count, min, max and the first k power sums of values,
+// which is k+4 floats and merges exactly. Quantiles are estimated by the
+// maximum entropy density matching the moments.
+// The power sums are taken about the first added value, so data far from zero
+// keeps its precision. Orders of 5-12 are a good choice.
+//
+// See: Gan, E., Ding, J., Tai, K.S., Sharan, V., Bailis, P. Moment-Based Quantile Sketches for Efficient High Cardinality Aggregation Queries. https://arxiv.org/abs/1803.01969
+type MomentSketch struct {
+	count    float64
+	min, max float64
+	shift    float64   // the first added value
+	sums     []float64 // sums[i] is the sum of (x-shift)**(i+1)
+}
+
+// NewMomentSketch returns new MomentSketch of the given order k in [1, 16].
+func NewMomentSketch(k int) *MomentSketch {
+	if k < 1 || k > 16 {
+		panic("mathx: moment sketch order must be in range [1, 16]")
+	}
+	s := &MomentSketch{sums: make([]float64, k)}
+	s.Reset()
+	return s
+}
+
+// Order returns the number of power sums of the sketch.
+func (s *MomentSketch) Order() int { return len(s.sums) }
+
+// Count returns the number of added values.
+func (s *MomentSketch) Count() float64 { return s.count }
+
+// Min returns the smallest added value, +Inf if the sketch is empty.
+func (s *MomentSketch) Min() float64 { return s.min }
+
+// Max returns the largest added value, -Inf if the sketch is empty.
+func (s *MomentSketch) Max() float64 { return s.max }
+
+// Mean returns the mean of added values, NaN if the sketch is empty.
+func (s *MomentSketch) Mean() float64 {
+	if s.count == 0 {
+		return NaN
+	}
+	return s.shift + s.sums[0]/s.count
+}
+
+// Reset resets the sketch.
+func (s *MomentSketch) Reset() {
+	s.count = 0
+	s.min, s.max = InfPos, InfNeg
+	s.shift = 0
+	for i := range s.sums {
+		s.sums[i] = 0
+	}
+}
+
+// Add the value x. Non-finite values are ignored.
+func (s *MomentSketch) Add(x float64) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return
+	}
+	if s.count == 0 {
+		s.shift = x
+	}
+	s.count++
+	s.min, s.max = math.Min(s.min, x), math.Max(s.max, x)
+	d, p := x-s.shift, 1.
+	for i := range s.sums {
+		p *= d
+		s.sums[i] += p
+	}
+}
+
+// Merge adds all values of x into s.
+// It returns ErrMismatch if the sketches have different orders.
+func (s *MomentSketch) Merge(x *MomentSketch) error {
+	if len(s.sums) != len(x.sums) {
+		return ErrMismatch
+	}
+	if x.count == 0 {
+		return nil
+	}
+	if s.count == 0 {
+		s.shift = x.shift
+	}
+
+	// Move the power sums of x to the shift of s by the binomial expansion
+	// of ((x-x.shift) + delta)**j.
+	delta := x.shift - s.shift
+	for j := 1; j <= len(x.sums); j++ {
+		sum := math.Pow(delta, float64(j)) * x.count
+		binom := 1.
+		for i := 1; i <= j; i++ {
+			binom = binom * float64(j-i+1) / float64(i)
+			sum += binom * math.Pow(delta, float64(j-i)) * x.sums[i-1]
+		}
+		s.sums[j-1] += sum
+	}
+	s.count += x.count
+	s.min, s.max = math.Min(s.min, x.min), math.Max(s.max, x.max)
+	return nil
+}
+
+// Quantile returns the estimated quantile value for the given phi.
+// It returns NaN if the sketch is empty.
+func (s *MomentSketch) Quantile(phi float64) float64 {
+	switch {
+	case s.count == 0 || math.IsNaN(phi):
+		return NaN
+	case phi <= 0 || s.min == s.max:
+		return s.min
+	case phi >= 1:
+		return s.max
+	}
+
+	f := maxEntropyDensity(s.chebyshevMoments())
+	var total float64
+	for _, v := range f {
+		total += v
+	}
+
+	// Invert the CDF of the density on the grid cells over [-1, 1].
+	target, cum := phi*total, 0.
+	y := 1.
+	for i, v := range f {
+		if cum+v >= target {
+			y = -1 + (float64(i)+(target-cum)/v)*2/float64(len(f))
+			break
+		}
+		cum += v
+	}
+	c, r := (s.max+s.min)/2, (s.max-s.min)/2
+	return math.Min(math.Max(c+r*y, s.min), s.max)
+}
+
+// chebyshevMoments returns the means of T_0..T_k of the values scaled to [-1, 1].
+func (s *MomentSketch) chebyshevMoments() []float64 {
+	k := len(s.sums)
+	// The center is relative to the shift of the power sums.
+	c, r := (s.max+s.min)/2-s.shift, (s.max-s.min)/2
+
+	// Moments of x-shift, then of the scaled y = (x-c)/r by the binomial expansion.
+	mx := make([]float64, k+1)
+	mx[0] = 1
+	for i, v := range s.sums {
+		mx[i+1] = v / s.count
+	}
+	my := make([]float64, k+1)
+	for j := range my {
+		var sum float64
+		binom := 1.
+		for i := 0; i <= j; i++ {
+			sum += binom * mx[i] * math.Pow(-c, float64(j-i))
+			binom = binom * float64(j-i) / float64(i+1)
+		}
+		my[j] = sum / math.Pow(r, float64(j))
+	}
+
+	// Coefficients of T_j in powers of y by T_{j+1} = 2y*T_j - T_{j-1}.
+	moments := make([]float64, k+1)
+	prev, cur := make([]float64, k+2), make([]float64, k+2)
+	prev[0], cur[1] = 1, 1
+	moments[0], moments[1] = 1, my[1]
+	for j := 2; j <= k; j++ {
+		next := make([]float64, k+2)
+		for i := 0; i <= j; i++ {
+			if i > 0 {
+				next[i] = 2 * cur[i-1]
+			}
+			next[i] -= prev[i]
+		}
+		var m float64
+		for i := 0; i <= j; i++ {
+			m += next[i] * my[i]
+		}
+		moments[j] = m
+		prev, cur = cur, next
+	}
+	return moments
+}
+
+// maxEntropyGrid is the number of cells of the quadrature over [-1, 1].
+const maxEntropyGrid = 1024
+
+// maxEntropyDensity returns the cell masses of the maximum entropy density
+// exp(sum theta_j T_j(y)) on [-1, 1] whose Chebyshev moments match moments.
+// The dual is minimized by damped Newton's method.
+func maxEntropyDensity(moments []float64) []float64 {
+	k := len(moments)
+	const h = 2. / maxEntropyGrid
+
+	// Chebyshev polynomials at the cell midpoints.
+	ts := make([][]float64, maxEntropyGrid)
+	for m := range ts {
+		y := -1 + (float64(m)+0.5)*h
+		t := make([]float64, k)
+		t[0] = 1
+		if k > 1 {
+			t[1] = y
+		}
+		for j := 2; j < k; j++ {
+			t[j] = 2*y*t[j-1] - t[j-2]
+		}
+		ts[m] = t
+	}
+
+	density := func(theta []float64, f []float64) float64 {
+		var total float64
+		for m, t := range ts {
+			var e float64
+			for j, th := range theta {
+				e += th * t[j]
+			}
+			f[m] = math.Exp(e) * h
+			total += f[m]
+		}
+		// The dual objective.
+		obj := total
+		for j, th := range theta {
+			obj -= th * moments[j]
+		}
+		return obj
+	}
+
+	theta := make([]float64, k)
+	theta[0] = math.Log(0.5) // uniform density
+	f := make([]float64, maxEntropyGrid)
+	obj := density(theta, f)
+
+	grad := make([]float64, k)
+	hess := make([][]float64, k)
+	for i := range hess {
+		hess[i] = make([]float64, k)
+	}
+	next := make([]float64, k)
+	nf := make([]float64, maxEntropyGrid)
+
+	for iter := 0; iter < 100; iter++ {
+		for i := range grad {
+			grad[i] = -moments[i]
+			for j := range hess[i] {
+				hess[i][j] = 0
+			}
+		}
+		for m, t := range ts {
+			for i := range grad {
+				grad[i] += t[i] * f[m]
+				for j := 0; j <= i; j++ {
+					hess[i][j] += t[i] * t[j] * f[m]
+				}
+			}
+		}
+		var norm float64
+		for i := range grad {
+			norm += grad[i] * grad[i]
+			for j := 0; j < i; j++ {
+				hess[j][i] = hess[i][j]
+			}
+		}
+		if norm < 1e-20 {
+			break
+		}
+
+		step, ok := solveSymmetric(hess, grad)
+		if !ok {
+			break
+		}
+		improved := false
+		for alpha := 1.; alpha > 1e-6; alpha /= 2 {
+			for i := range next {
+				next[i] = theta[i] - alpha*step[i]
+			}
+			if o := density(next, nf); o < obj {
+				obj, improved = o, true
+				theta, next = next, theta
+				f, nf = nf, f
+				break
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return f
+}
+
+// solveSymmetric solves a*x = b for a symmetric positive definite a
+// by Cholesky decomposition. It reports false if a is not positive definite.
+func solveSymmetric(a [][]float64, b []float64) ([]float64, bool) {
+	n := len(b)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for p := 0; p < j; p++ {
+				sum -= l[i][p] * l[j][p]
+			}
+			if i == j {
+				if !(sum > 0) {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := b[i]
+		for p := 0; p < i; p++ {
+			sum -= l[i][p] * x[p]
+		}
+		x[i] = sum / l[i][i]
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := x[i]
+		for p := i + 1; p < n; p++ {
+			sum -= l[p][i] * x[p]
+		}
+		x[i] = sum / l[i][i]
+	}
+	return x, true
+}
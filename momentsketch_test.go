@@ -0,0 +1,101 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMomentSketch(t *testing.T) {
+	testCases := []struct {
+		name string
+		gen  func(p float64) float64 // inverse CDF
+	}{
+		{"uniform", func(p float64) float64 { return 10 + 20*p }},
+		{"normal", func(p float64) float64 { return NormInvCDF(p) }},
+		{"exponential", func(p float64) float64 { return -math.Log(1 - p) }},
+	}
+
+	const n = 10000
+	for _, tc := range testCases {
+		s := NewMomentSketch(10)
+		for i := 0; i < n; i++ {
+			s.Add(tc.gen((float64(i) + 0.5) / n))
+		}
+		if s.Count() != n {
+			t.Fatalf("unexpected count for %s; got %v; want %v", tc.name, s.Count(), n)
+		}
+
+		width := s.Max() - s.Min()
+		for _, phi := range []float64{0.1, 0.25, 0.5, 0.75, 0.9} {
+			got, want := s.Quantile(phi), tc.gen(phi)
+			if math.Abs(got-want) > 0.01*width {
+				t.Fatalf("unexpected %s quantile %v; got %v; want %v", tc.name, phi, got, want)
+			}
+		}
+		if s.Quantile(0) != s.Min() || s.Quantile(1) != s.Max() {
+			t.Fatalf("unexpected %s extremes; got %v, %v", tc.name, s.Quantile(0), s.Quantile(1))
+		}
+	}
+}
+
+func TestMomentSketchMerge(t *testing.T) {
+	a, b, all := NewMomentSketch(6), NewMomentSketch(6), NewMomentSketch(6)
+	for i := 0; i < 1000; i++ {
+		x := float64(i%97) / 97
+		if i%2 == 0 {
+			a.Add(x)
+		} else {
+			b.Add(x)
+		}
+		all.Add(x)
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if a.Count() != all.Count() || a.Min() != all.Min() || a.Max() != all.Max() || !approxEqual(a.Mean(), all.Mean()) {
+		t.Fatalf("unexpected merge; got %v; want %v", a, all)
+	}
+	if got, want := a.Quantile(0.5), all.Quantile(0.5); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("unexpected merged median; got %v; want %v", got, want)
+	}
+
+	if err := a.Merge(NewMomentSketch(5)); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+
+	a.Reset()
+	if !math.IsNaN(a.Quantile(0.5)) || !math.IsNaN(a.Mean()) {
+		t.Fatalf("unexpected quantile of empty sketch; got %v", a.Quantile(0.5))
+	}
+	a.Add(3)
+	a.Add(math.NaN())
+	if a.Count() != 1 || a.Quantile(0.5) != 3 {
+		t.Fatalf("unexpected single value sketch; got %v, %v", a.Count(), a.Quantile(0.5))
+	}
+}
+
+func TestMomentSketchOffset(t *testing.T) {
+	const n = 100000
+	a, b := NewMomentSketch(10), NewMomentSketch(10)
+	for i := 0; i < n; i++ {
+		x := 1000 + NormInvCDF((float64(i)+0.5)/n)
+		if i%3 == 0 {
+			a.Add(x)
+		} else {
+			b.Add(x) // the shift of b differs from a
+		}
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(a.Mean()-1000) > 1e-9 {
+		t.Fatalf("unexpected mean; got %v; want %v", a.Mean(), 1000)
+	}
+	for _, phi := range []float64{0.1, 0.5, 0.9} {
+		got, want := a.Quantile(phi), 1000+NormInvCDF(phi)
+		if math.Abs(got-want) > 0.05 {
+			t.Fatalf("unexpected quantile %v; got %v; want %v", phi, got, want)
+		}
+	}
+}
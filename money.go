@@ -0,0 +1,177 @@
+package mathx
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseMoney parses a decimal amount like "-12.34" into minor units
+// with the given number of minor digits, e.g. ParseMoney("12.34", 2) == 1234.
+// It never uses floating-point arithmetic.
+//
+// Extra fractional digits are accepted only when they are zeros,
+// otherwise ErrInexact is returned. ErrOverflow is returned when
+// the amount does not fit into int64 and a *SyntaxError for malformed input.
+func ParseMoney(s string, minorDigits int) (int64, error) {
+	checkMinorDigits(minorDigits)
+
+	neg, off := false, 0
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg, off = s[0] == '-', 1
+		s = s[1:]
+	}
+
+	whole, frac := s, ""
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			whole, frac = s[:i], s[i+1:]
+			if frac == "" {
+				return 0, &SyntaxError{Offset: off + len(s)}
+			}
+			break
+		}
+	}
+	if whole == "" {
+		return 0, &SyntaxError{Offset: off}
+	}
+
+	var v uint64
+	overflow, inexact := false, false
+	add := func(d byte) {
+		if v > (math.MaxUint64-9)/10 {
+			overflow = true
+		}
+		v = v*10 + uint64(d)
+	}
+
+	for i := 0; i < len(whole); i++ {
+		c := whole[i]
+		if c < '0' || c > '9' {
+			return 0, &SyntaxError{Offset: off + i}
+		}
+		add(c - '0')
+	}
+	for i := 0; i < len(frac); i++ {
+		c := frac[i]
+		if c < '0' || c > '9' {
+			return 0, &SyntaxError{Offset: off + len(whole) + 1 + i}
+		}
+		if i >= minorDigits {
+			// Keep checking the syntax of the remaining digits.
+			inexact = inexact || c != '0'
+			continue
+		}
+		add(c - '0')
+	}
+	for i := len(frac); i < minorDigits; i++ {
+		add(0)
+	}
+
+	switch {
+	case inexact:
+		return 0, ErrInexact
+	case overflow:
+		return 0, ErrOverflow
+	case neg && v > 1<<63:
+		return 0, ErrOverflow
+	case !neg && v > math.MaxInt64:
+		return 0, ErrOverflow
+	case neg:
+		return int64(-v), nil
+	default:
+		return int64(v), nil
+	}
+}
+
+// FormatMoney formats an amount in minor units as a decimal string
+// with the given number of minor digits, e.g. FormatMoney(-1234, 2) == "-12.34".
+func FormatMoney(v int64, minorDigits int) string {
+	checkMinorDigits(minorDigits)
+
+	m := uint64(v)
+	if v < 0 {
+		m = -m
+	}
+
+	s := strconv.FormatUint(m, 10)
+	if minorDigits > 0 {
+		if pad := minorDigits + 1 - len(s); pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+		n := len(s) - minorDigits
+		s = s[:n] + "." + s[n:]
+	}
+
+	if v < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+func checkMinorDigits(minorDigits int) {
+	if minorDigits < 0 || minorDigits > 18 {
+		panic("mathx: minor digits must be in range [0, 18]")
+	}
+}
+
+// MoneyAccumulator sums amounts in int64 minor units exactly.
+// The sum stays in int64 on the fast path and escalates to Int128
+// once it overflows, so no partial sum is ever lost.
+// The zero value is ready to use.
+type MoneyAccumulator struct {
+	sum  int64
+	wide Int128
+	big  bool // sum is kept in wide
+}
+
+// Add the amount v.
+func (a *MoneyAccumulator) Add(v int64) {
+	if !a.big {
+		s := a.sum + v
+		if (s > a.sum) == (v > 0) {
+			a.sum = s
+			return
+		}
+		a.wide, a.big = Int128FromInt64(a.sum), true
+	}
+	a.wide = a.wide.Add(Int128FromInt64(v))
+}
+
+// AddAll adds all amounts of vs.
+func (a *MoneyAccumulator) AddAll(vs []int64) {
+	for _, v := range vs {
+		a.Add(v)
+	}
+}
+
+// Merge adds the total of x into a.
+func (a *MoneyAccumulator) Merge(x *MoneyAccumulator) {
+	if !x.big {
+		a.Add(x.sum)
+		return
+	}
+	if !a.big {
+		a.wide, a.big = Int128FromInt64(a.sum), true
+	}
+	a.wide = a.wide.Add(x.wide)
+}
+
+// Reset resets the total to 0.
+func (a *MoneyAccumulator) Reset() { *a = MoneyAccumulator{} }
+
+// Total returns the exact total.
+func (a *MoneyAccumulator) Total() Int128 {
+	if a.big {
+		return a.wide
+	}
+	return Int128FromInt64(a.sum)
+}
+
+// Int64 returns the total and whether it fits into int64.
+func (a *MoneyAccumulator) Int64() (int64, bool) {
+	if a.big {
+		return a.wide.Int64()
+	}
+	return a.sum, true
+}
@@ -0,0 +1,114 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		s     string
+		minor int
+		want  int64
+		err   error
+	}{
+		{"12.34", 2, 1234, nil},
+		{"12.3", 2, 1230, nil},
+		{"12", 2, 1200, nil},
+		{"-0.05", 2, -5, nil},
+		{"+7.500", 2, 750, nil},
+		{"12.345", 3, 12345, nil},
+		{"12.345", 2, 0, ErrInexact},
+		{"9223372036854775807", 0, math.MaxInt64, nil},
+		{"-9223372036854775808", 0, math.MinInt64, nil},
+		{"9223372036854775808", 0, 0, ErrOverflow},
+		{"92233720368547758.08", 2, 0, ErrOverflow},
+		{"99999999999999999999999", 0, 0, ErrOverflow},
+		{"", 2, 0, ErrSyntax},
+		{"-", 2, 0, ErrSyntax},
+		{".5", 2, 0, ErrSyntax},
+		{"5.", 2, 0, ErrSyntax},
+		{"1e3", 2, 0, ErrSyntax},
+		{"1,5", 2, 0, ErrSyntax},
+		{"12.345x", 2, 0, ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		got, err := ParseMoney(tc.s, tc.minor)
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("unexpected error for %q; got %v; want %v", tc.s, err, tc.err)
+		}
+		if got != tc.want {
+			t.Fatalf("unexpected value for %q; got %v; want %v", tc.s, got, tc.want)
+		}
+	}
+
+	var synErr *SyntaxError
+	if _, err := ParseMoney("12.345x", 2); !errors.As(err, &synErr) || synErr.Offset != 6 {
+		t.Fatalf("unexpected error; got %v; want offset 6", err)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	testCases := []struct {
+		v     int64
+		minor int
+		want  string
+	}{
+		{1234, 2, "12.34"},
+		{-5, 2, "-0.05"},
+		{0, 3, "0.000"},
+		{42, 0, "42"},
+		{math.MinInt64, 2, "-92233720368547758.08"},
+	}
+
+	for _, tc := range testCases {
+		got := FormatMoney(tc.v, tc.minor)
+		if got != tc.want {
+			t.Fatalf("unexpected value for %v; got %q; want %q", tc.v, got, tc.want)
+		}
+
+		back, err := ParseMoney(got, tc.minor)
+		if err != nil || back != tc.v {
+			t.Fatalf("round trip failed for %v; got %v (%v)", tc.v, back, err)
+		}
+	}
+}
+
+func TestMoneyAccumulator(t *testing.T) {
+	var a MoneyAccumulator
+	a.AddAll([]int64{1234, -34, 100})
+	if v, ok := a.Int64(); !ok || v != 1300 {
+		t.Fatalf("unexpected total; got %v, %v; want 1300", v, ok)
+	}
+
+	a.Add(math.MaxInt64)
+	if _, ok := a.Int64(); ok {
+		t.Fatal("total must not fit into int64")
+	}
+	if got, want := a.Total().String(), "9223372036854777107"; got != want {
+		t.Fatalf("unexpected total; got %v; want %v", got, want)
+	}
+
+	// Coming back into range after escalation.
+	a.Add(-2000)
+	if v, ok := a.Int64(); !ok || v != math.MaxInt64-700 {
+		t.Fatalf("unexpected total; got %v, %v; want %v", v, ok, int64(math.MaxInt64-700))
+	}
+
+	var b MoneyAccumulator
+	b.AddAll([]int64{math.MinInt64, math.MinInt64, -1})
+	if got, want := b.Total().String(), "-18446744073709551617"; got != want {
+		t.Fatalf("unexpected total; got %v; want %v", got, want)
+	}
+	b.Merge(&a)
+	if got, want := b.Total().String(), "-9223372036854776510"; got != want {
+		t.Fatalf("unexpected merged total; got %v; want %v", got, want)
+	}
+
+	b.Reset()
+	if v, ok := b.Int64(); !ok || v != 0 {
+		t.Fatalf("unexpected total after Reset; got %v, %v", v, ok)
+	}
+}
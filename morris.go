@@ -0,0 +1,103 @@
+package mathx
+
+import (
+	"math"
+
+	"github.com/valyala/fastrand"
+)
+
+// MorrisCounter is a probabilistic counter that stores only the logarithm
+// of the count. The estimate is (base^v - 1) / (base - 1) for the stored level v,
+// so a 32-bit level covers counts far beyond uint64.
+// Base 2 is the original Morris counter, bases closer to 1 need more levels
+// but are more accurate: the relative standard error is about sqrt((base-1)/2).
+//
+// See: Morris, R. Counting large numbers of events in small registers. https://doi.org/10.1145/359619.359627
+type MorrisCounter struct {
+	base float64
+	v    uint32
+	seed uint32
+	rng  fastrand.RNG
+}
+
+// NewMorrisCounter returns new MorrisCounter with the given base > 1.
+func NewMorrisCounter(base float64) *MorrisCounter {
+	if !(base > 1) || math.IsInf(base, 0) {
+		panic("mathx: morris counter base must be greater than 1")
+	}
+	c := &MorrisCounter{base: base, seed: newSeed()}
+	c.Reset()
+	return c
+}
+
+// Base of the counter.
+func (c *MorrisCounter) Base() float64 { return c.base }
+
+// Level returns the stored level.
+func (c *MorrisCounter) Level() uint32 { return c.v }
+
+// Seed sets the seed of the counter RNG and resets the counter.
+func (c *MorrisCounter) Seed(seed uint32) {
+	c.seed = seed
+	c.Reset()
+}
+
+// Reset resets the counter.
+func (c *MorrisCounter) Reset() {
+	c.v = 0
+	c.rng.Seed(rngSeed(c.seed))
+}
+
+// Inc adds 1 to the counter.
+func (c *MorrisCounter) Inc() { c.Add(1) }
+
+// Add adds n to the counter in constant time.
+func (c *MorrisCounter) Add(n uint64) {
+	if n > 0 {
+		c.set(c.Estimate() + float64(n))
+	}
+}
+
+// Estimate returns the estimated count.
+func (c *MorrisCounter) Estimate() float64 { return c.estimate(c.v) }
+
+// Merge adds the count of x into c.
+// Both counters must have the same base.
+func (c *MorrisCounter) Merge(x *MorrisCounter) error {
+	if c.base != x.base {
+		return ErrMismatch
+	}
+	if x.v > 0 {
+		c.set(c.Estimate() + x.Estimate())
+	}
+	return nil
+}
+
+func (c *MorrisCounter) estimate(v uint32) float64 {
+	return (math.Pow(c.base, float64(v)) - 1) / (c.base - 1)
+}
+
+// set sets the level so that the expected estimate equals target:
+// it picks one of the two adjacent levels with probability
+// proportional to the distance of target from the other.
+func (c *MorrisCounter) set(target float64) {
+	lv := math.Floor(math.Log1p(target*(c.base-1)) / math.Log(c.base))
+	if lv >= math.MaxUint32 {
+		c.v = math.MaxUint32
+		return
+	}
+	v := uint32(lv)
+	// Correct rounding errors of the logarithm.
+	for v > 0 && c.estimate(v) > target {
+		v--
+	}
+	for c.estimate(v+1) <= target {
+		v++
+	}
+
+	lo, hi := c.estimate(v), c.estimate(v+1)
+	if p := (target - lo) / (hi - lo); float64(c.rng.Uint32()) < p*(1<<32) {
+		v++
+	}
+	c.v = v
+}
@@ -0,0 +1,56 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMorrisCounter(t *testing.T) {
+	c := NewMorrisCounter(1.001)
+	for i := 0; i < 100000; i++ {
+		c.Inc()
+	}
+	if est := c.Estimate(); math.Abs(est-100000) > 0.1*100000 {
+		t.Fatalf("unexpected estimate; got %v; want about %v", est, 100000)
+	}
+
+	c2 := NewMorrisCounter(2)
+	if c2.Estimate() != 0 {
+		t.Fatal("new counter must be zero")
+	}
+	c2.Inc()
+	if c2.Level() != 1 || c2.Estimate() != 1 {
+		t.Fatalf("first increment is exact; got level %v", c2.Level())
+	}
+}
+
+func TestMorrisCounterUnbiased(t *testing.T) {
+	const n, runs = 1000, 2000
+	var sum float64
+	for r := 0; r < runs; r++ {
+		c := NewMorrisCounter(2)
+		c.Seed(uint32(r + 1))
+		for i := 0; i < n; i++ {
+			c.Inc()
+		}
+		sum += c.Estimate()
+	}
+	if mean := sum / runs; math.Abs(mean-n) > 0.05*n {
+		t.Fatalf("unexpected mean estimate; got %v; want about %v", mean, n)
+	}
+}
+
+func TestMorrisCounterAddMerge(t *testing.T) {
+	a, b := NewMorrisCounter(1.01), NewMorrisCounter(1.01)
+	a.Add(1e6)
+	b.Add(3e6)
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if est := a.Estimate(); math.Abs(est-4e6) > 0.02*4e6 {
+		t.Fatalf("unexpected estimate; got %v; want about %v", est, 4e6)
+	}
+	if err := a.Merge(NewMorrisCounter(2)); err != ErrMismatch {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+}
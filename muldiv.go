@@ -0,0 +1,71 @@
+package mathx
+
+// MulDiv returns floor(a*b/c) and whether it fits into Uint128.
+// The product is computed in full 256-bit width, so it never overflows.
+// It panics for c == 0 (division by zero).
+func MulDiv(a, b, c Uint128) (Uint128, bool) {
+	return MulDivRound(a, b, c, RoundDown)
+}
+
+// MulDivRound returns a*b/c rounded according to mode and whether it fits into Uint128.
+// It panics for c == 0 (division by zero).
+func MulDivRound(a, b, c Uint128, mode RoundingMode) (Uint128, bool) {
+	hi, lo := a.MulFull(b)
+	q, r := div256by128(Uint256{hi: hi, lo: lo}, c)
+	if mode.roundUpCmp(!r.IsZero(), r.Cmp(c.Sub(r)), q.lo.lo&1 == 1) {
+		q = q.Inc()
+	}
+	return q.lo, q.hi.IsZero()
+}
+
+// MulDiv256 returns floor(a*b/c) and whether it fits into Uint256.
+// The product is computed in full 512-bit width, so it never overflows.
+// It panics for c == 0 (division by zero).
+func MulDiv256(a, b, c Uint256) (Uint256, bool) {
+	return MulDivRound256(a, b, c, RoundDown)
+}
+
+// MulDivRound256 returns a*b/c rounded according to mode and whether it fits into Uint256.
+// It panics for c == 0 (division by zero).
+func MulDivRound256(a, b, c Uint256, mode RoundingMode) (Uint256, bool) {
+	if c.IsZero() {
+		panic("mathx: division by zero")
+	}
+	hi, lo := a.MulFull(b)
+	n := [8]uint64{lo.lo.lo, lo.lo.hi, lo.hi.lo, lo.hi.hi, hi.lo.lo, hi.lo.hi, hi.hi.lo, hi.hi.hi}
+	d := [4]uint64{c.lo.lo, c.lo.hi, c.hi.lo, c.hi.hi}
+	var q [8]uint64
+	var r [4]uint64
+	divLimbs(q[:], r[:], trimLimbs(n[:]), trimLimbs(d[:]))
+
+	quo := Uint256{hi: Uint128{hi: q[3], lo: q[2]}, lo: Uint128{hi: q[1], lo: q[0]}}
+	rem := Uint256{hi: Uint128{hi: r[3], lo: r[2]}, lo: Uint128{hi: r[1], lo: r[0]}}
+	fits := q[4]|q[5]|q[6]|q[7] == 0
+	if mode.roundUpCmp(!rem.IsZero(), rem.Cmp(c.Sub(rem)), q[0]&1 == 1) {
+		var carry uint64
+		quo, carry = quo.AddCarry(Uint256{lo: Uint128{lo: 1}}, 0)
+		fits = fits && carry == 0
+	}
+	return quo, fits
+}
+
+// MulMod returns a*b mod m.
+// The product is computed in full 256-bit width, so it never overflows.
+// It panics for m == 0 (division by zero).
+func MulMod(a, b, m Uint128) Uint128 {
+	hi, lo := a.MulFull(b)
+	_, r := div256by128(Uint256{hi: hi, lo: lo}, m)
+	return r
+}
+
+// AddMod returns (a+b) mod m.
+// The sum is computed with the carry, so it never overflows.
+// It panics for m == 0 (division by zero).
+func AddMod(a, b, m Uint128) Uint128 {
+	a, b = a.Mod(m), b.Mod(m)
+	sum, carry := a.AddCarry(b, 0)
+	if carry != 0 || sum.Cmp(m) >= 0 {
+		sum = sum.Sub(m)
+	}
+	return sum
+}
@@ -0,0 +1,90 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMulDiv(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	values := []Uint128{{lo: 1}, {lo: 3}, {lo: 1e18}, {hi: 1}, {hi: 1 << 40, lo: 7}, max.Dec(), max}
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	for _, a := range values {
+		for _, b := range values {
+			for _, c := range values {
+				want := new(big.Int).Mul(a.Big(), b.Big())
+				want.Quo(want, c.Big())
+				got, ok := MulDiv(a, b, c)
+				if wantOK := want.Cmp(limit) < 0; ok != wantOK || ok && got.Big().Cmp(want) != 0 {
+					t.Fatalf("unexpected MulDiv(%v, %v, %v); got %v, %v; want %v", a, b, c, got, ok, want)
+				}
+			}
+		}
+	}
+
+	if got, ok := MulDivRound(NewUint128(0, 10), NewUint128(0, 1), NewUint128(0, 4), RoundHalfEven); !ok || got != NewUint128(0, 2) {
+		t.Fatalf("unexpected MulDivRound; got %v, %v; want 2", got, ok)
+	}
+	if got, ok := MulDivRound(max, max, max, RoundUp); !ok || got != max {
+		t.Fatalf("unexpected MulDivRound; got %v, %v; want %v", got, ok, max)
+	}
+}
+
+func TestMulDiv256(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	max256 := NewUint256(max, max)
+	values := []Uint256{
+		Uint256FromUint64(1), Uint256FromUint64(3), NewUint256(Uint128{}, max),
+		NewUint256(NewUint128(0, 1), NewUint128(5, 0)), NewUint256(max, Uint128{}), max256.Dec(), max256,
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), 256)
+	for _, a := range values {
+		for _, b := range values {
+			for _, c := range values {
+				q, r := new(big.Int).QuoRem(new(big.Int).Mul(a.Big(), b.Big()), c.Big(), new(big.Int))
+				got, ok := MulDiv256(a, b, c)
+				if wantOK := q.Cmp(limit) < 0; ok != wantOK || ok && got.Big().Cmp(q) != 0 {
+					t.Fatalf("unexpected MulDiv256(%v, %v, %v); got %v, %v; want %v", a, b, c, got, ok, q)
+				}
+
+				if r.Lsh(r, 1).Cmp(c.Big()) >= 0 {
+					q.Add(q, big.NewInt(1))
+				}
+				got, ok = MulDivRound256(a, b, c, RoundHalfUp)
+				if wantOK := q.Cmp(limit) < 0; ok != wantOK || ok && got.Big().Cmp(q) != 0 {
+					t.Fatalf("unexpected MulDivRound256(%v, %v, %v); got %v, %v; want %v", a, b, c, got, ok, q)
+				}
+			}
+		}
+	}
+}
+
+func TestMulModAddMod(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	values := []Uint128{{lo: 1}, {lo: 3}, {lo: 1e18}, {hi: 1}, {hi: 1 << 40, lo: 7}, max.Dec(), max}
+	for _, a := range values {
+		for _, b := range values {
+			for _, m := range values {
+				want := new(big.Int).Mul(a.Big(), b.Big())
+				want.Mod(want, m.Big())
+				if got := MulMod(a, b, m); got.Big().Cmp(want) != 0 {
+					t.Fatalf("unexpected MulMod(%v, %v, %v); got %v; want %v", a, b, m, got, want)
+				}
+
+				want.Add(a.Big(), b.Big())
+				want.Mod(want, m.Big())
+				if got := AddMod(a, b, m); got.Big().Cmp(want) != 0 {
+					t.Fatalf("unexpected AddMod(%v, %v, %v); got %v; want %v", a, b, m, got, want)
+				}
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for zero modulus")
+		}
+	}()
+	MulMod(max, max, Uint128{})
+}
@@ -0,0 +1,67 @@
+package mathx
+
+import "math"
+
+// NormPDF returns the standard normal probability density at x.
+func NormPDF(x float64) float64 {
+	return math.Exp(-0.5*x*x) * invSqrt2Pi
+}
+
+// NormCDF returns the standard normal cumulative distribution at x.
+// It is accurate in both tails.
+func NormCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// NormInvCDF returns the quantile of the standard normal distribution,
+// such that NormCDF(NormInvCDF(p)) == p.
+// It is -Inf for p == 0, +Inf for p == 1 and NaN outside [0, 1].
+//
+// It uses the rational approximation by Peter Acklam
+// refined with a Halley step, relative error is about 1e-15.
+func NormInvCDF(p float64) float64 {
+	switch {
+	case !(p >= 0 && p <= 1):
+		return NaN
+	case p == 0:
+		return InfNeg
+	case p == 1:
+		return InfPos
+	case p > 0.5:
+		// 1-p is exact for p >= 0.5.
+		return -normInvLower(1 - p)
+	default:
+		return normInvLower(p)
+	}
+}
+
+const invSqrt2Pi = 0.398942280401432677939946059934381868475858631164934657665925829
+
+// normInvLower returns NormInvCDF(p) for p in (0, 0.5].
+func normInvLower(p float64) float64 {
+	const (
+		a0, a1, a2, a3, a4, a5 = -3.969683028665376e+01, 2.209460984245205e+02, -2.759285104469687e+02, 1.383577518672690e+02, -3.066479806614716e+01, 2.506628277459239e+00
+		b0, b1, b2, b3, b4     = -5.447609879822406e+01, 1.615858368580409e+02, -1.556989798598866e+02, 6.680131188771972e+01, -1.328068155288572e+01
+		c0, c1, c2, c3, c4, c5 = -7.784894002430293e-03, -3.223964580411365e-01, -2.400758277161838e+00, -2.549732539343734e+00, 4.374664141464968e+00, 2.938163982698783e+00
+		d0, d1, d2, d3         = 7.784695709041462e-03, 3.224671290700398e-01, 2.445134137142996e+00, 3.754408661907416e+00
+
+		pLow = 0.02425
+	)
+
+	var x float64
+	if p < pLow {
+		q := math.Sqrt(-2 * math.Log(p))
+		x = (((((c0*q+c1)*q+c2)*q+c3)*q+c4)*q + c5) /
+			((((d0*q+d1)*q+d2)*q+d3)*q + 1)
+	} else {
+		q := p - 0.5
+		r := q * q
+		x = (((((a0*r+a1)*r+a2)*r+a3)*r+a4)*r + a5) * q /
+			(((((b0*r+b1)*r+b2)*r+b3)*r+b4)*r + 1)
+	}
+
+	// Halley step, the lower tail of erfc keeps relative precision.
+	e := NormCDF(x) - p
+	u := e * sqrt2Pi * math.Exp(0.5*x*x)
+	return x - u/(1+0.5*x*u)
+}
@@ -0,0 +1,61 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormCDF(t *testing.T) {
+	testCases := []struct {
+		x, want float64
+	}{
+		{0, 0.5},
+		{1.959963984540054, 0.975},
+		{-1, 0.15865525393145705},
+		{-10, 7.619853024160593e-24},
+		{InfPos, 1},
+		{InfNeg, 0},
+	}
+	for _, tc := range testCases {
+		if got := NormCDF(tc.x); math.Abs(got-tc.want) > 1e-15*tc.want {
+			t.Fatalf("unexpected NormCDF(%v); got %v; want %v", tc.x, got, tc.want)
+		}
+	}
+
+	if got, want := NormPDF(0), 0.3989422804014327; got != want {
+		t.Fatalf("unexpected NormPDF(0); got %v; want %v", got, want)
+	}
+}
+
+func TestNormInvCDF(t *testing.T) {
+	testCases := []struct {
+		p, want float64
+	}{
+		{0.5, 0},
+		{0.975, 1.959963984540054},
+		{0.025, -1.959963984540054},
+		{0.99, 2.3263478740408408},
+		{1e-10, -6.361340902404056},
+		{1e-300, -37.0470962993612},
+		{0, InfNeg},
+		{1, InfPos},
+	}
+	for _, tc := range testCases {
+		got := NormInvCDF(tc.p)
+		if got != tc.want && math.Abs(got-tc.want) > 1e-15*math.Abs(tc.want) {
+			t.Fatalf("unexpected NormInvCDF(%v); got %v; want %v", tc.p, got, tc.want)
+		}
+	}
+
+	for _, p := range []float64{-0.1, 1.1, NaN} {
+		if got := NormInvCDF(p); !math.IsNaN(got) {
+			t.Fatalf("unexpected NormInvCDF(%v); got %v; want NaN", p, got)
+		}
+	}
+
+	for p := 1e-5; p < 1; p += 0.001 {
+		if got := NormCDF(NormInvCDF(p)); math.Abs(got-p) > 1e-14*p {
+			t.Fatalf("unexpected roundtrip for %v; got %v", p, got)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package mathx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// AppendNumeric appends the SQL NUMERIC text form of u to dst.
+// The form has no sign, exponent or leading zeros and is accepted
+// by Postgres and MySQL for NUMERIC and DECIMAL columns.
+func (u Uint128) AppendNumeric(dst []byte) []byte {
+	if u.hi == 0 {
+		return strconv.AppendUint(dst, u.lo, 10)
+	}
+	q, r := u.QuoRem64(1e19)
+	dst = q.AppendNumeric(dst)
+	return appendDigits19(dst, r)
+}
+
+// ParseNumeric sets u to the value of the SQL NUMERIC text b.
+// A leading sign and a fractional part of zeros are accepted,
+// so "+42", "42.000" and "-0" are all valid.
+// It returns a *SyntaxError for malformed text, ErrNegative for negative values,
+// ErrInexact for a non-zero fractional part and ErrOverflow if the value does not fit.
+func (u *Uint128) ParseNumeric(b []byte) error {
+	digits, err := numericDigits(b)
+	if err != nil {
+		return err
+	}
+	v, err := decimalUint128(digits)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (u *Uint128) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return u.ParseNumeric(src)
+	case string:
+		return u.ParseNumeric([]byte(src))
+	case int64:
+		if src < 0 {
+			return ErrOverflow
+		}
+		*u = Uint128FromUint64(uint64(src))
+		return nil
+	default:
+		return fmt.Errorf("mathx: cannot scan %T into Uint128", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (u Uint128) Value() (driver.Value, error) {
+	return string(u.AppendNumeric(nil)), nil
+}
+
+// AppendNumeric appends the SQL NUMERIC text form of u to dst.
+// See Uint128.AppendNumeric for details.
+func (u Uint256) AppendNumeric(dst []byte) []byte {
+	if u.hi.IsZero() {
+		return u.lo.AppendNumeric(dst)
+	}
+	q, r := u.QuoRem64(1e19)
+	dst = q.AppendNumeric(dst)
+	return appendDigits19(dst, r)
+}
+
+// ParseNumeric sets u to the value of the SQL NUMERIC text b.
+// See Uint128.ParseNumeric for details.
+func (u *Uint256) ParseNumeric(b []byte) error {
+	digits, err := numericDigits(b)
+	if err != nil {
+		return err
+	}
+
+	var v Uint256
+	for len(digits) > 0 {
+		n := len(digits)
+		if n > 19 {
+			n = 19
+		}
+		chunk := digitsUint64(digits[:n])
+
+		var carry uint64
+		v, carry = v.mulAdd64(pow10u64[n], chunk)
+		if carry != 0 {
+			return ErrOverflow
+		}
+		digits = digits[n:]
+	}
+	*u = v
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (u *Uint256) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		return u.ParseNumeric(src)
+	case string:
+		return u.ParseNumeric([]byte(src))
+	case int64:
+		if src < 0 {
+			return ErrOverflow
+		}
+		*u = Uint256FromUint64(uint64(src))
+		return nil
+	default:
+		return fmt.Errorf("mathx: cannot scan %T into Uint256", src)
+	}
+}
+
+// Value implements driver.Valuer.
+func (u Uint256) Value() (driver.Value, error) {
+	return string(u.AppendNumeric(nil)), nil
+}
+
+// pow10u64 contains powers of ten that fit into uint64.
+var pow10u64 = [...]uint64{
+	1e00, 1e01, 1e02, 1e03, 1e04, 1e05, 1e06, 1e07, 1e08, 1e09,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+}
+
+// appendDigits19 appends v as exactly 19 decimal digits.
+func appendDigits19(dst []byte, v uint64) []byte {
+	var buf [19]byte
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = byte('0' + v%10)
+		v /= 10
+	}
+	return append(dst, buf[:]...)
+}
+
+// decimalUint128 returns the value of validated decimal digits.
+func decimalUint128(digits []byte) (Uint128, error) {
+	var v Uint128
+	for len(digits) > 0 {
+		n := len(digits)
+		if n > 19 {
+			n = 19
+		}
+		chunk := digitsUint64(digits[:n])
+
+		var carry uint64
+		v, carry = v.mulAdd64(pow10u64[n], chunk)
+		if carry != 0 {
+			return Uint128{}, ErrOverflow
+		}
+		digits = digits[n:]
+	}
+	return v, nil
+}
+
+// digitsUint64 returns the value of at most 19 decimal digits.
+func digitsUint64(digits []byte) uint64 {
+	var v uint64
+	for _, c := range digits {
+		v = v*10 + uint64(c-'0')
+	}
+	return v
+}
+
+// numericDigits validates NUMERIC text and returns its integer digits.
+func numericDigits(b []byte) ([]byte, error) {
+	neg, off := false, 0
+	if len(b) > 0 && (b[0] == '-' || b[0] == '+') {
+		neg, off = b[0] == '-', 1
+		b = b[1:]
+	}
+
+	n := 0
+	for n < len(b) && b[n] >= '0' && b[n] <= '9' {
+		n++
+	}
+	if n == 0 {
+		return nil, &SyntaxError{Offset: off}
+	}
+	digits, frac := b[:n], b[n:]
+
+	if len(frac) > 0 {
+		if frac[0] != '.' {
+			return nil, &SyntaxError{Offset: off + n}
+		}
+		for i, c := range frac[1:] {
+			switch {
+			case c == '0':
+			case c >= '1' && c <= '9':
+				return nil, ErrInexact
+			default:
+				return nil, &SyntaxError{Offset: off + n + 1 + i}
+			}
+		}
+	}
+
+	for len(digits) > 1 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	if neg && !(len(digits) == 1 && digits[0] == '0') {
+		return nil, ErrNegative
+	}
+	return digits, nil
+}
@@ -0,0 +1,113 @@
+package mathx
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestUint128Numeric(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		u := NewUint128(rng.Uint64()>>uint(rng.Intn(64)), rng.Uint64())
+
+		b := u.AppendNumeric(nil)
+		if want := u.Big().String(); string(b) != want {
+			t.Fatalf("unexpected text; got %s; want %s", b, want)
+		}
+
+		var got Uint128
+		if err := got.ParseNumeric(b); err != nil {
+			t.Fatal(err)
+		}
+		if got != u {
+			t.Fatalf("unexpected value; got %v; want %v", got, u)
+		}
+	}
+}
+
+func TestUint256Numeric(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		u := NewUint256(
+			NewUint128(rng.Uint64()>>uint(rng.Intn(64)), rng.Uint64()),
+			NewUint128(rng.Uint64(), rng.Uint64()),
+		)
+
+		b := u.AppendNumeric(nil)
+		if want := u.Big().String(); string(b) != want {
+			t.Fatalf("unexpected text; got %s; want %s", b, want)
+		}
+
+		var got Uint256
+		if err := got.ParseNumeric(b); err != nil {
+			t.Fatal(err)
+		}
+		if got != u {
+			t.Fatalf("unexpected value; got %v; want %v", got, u)
+		}
+	}
+}
+
+func TestParseNumeric(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), 128)
+	max.Sub(max, big.NewInt(1))
+
+	testCases := []struct {
+		s    string
+		want string
+		err  error
+	}{
+		{"0", "0", nil},
+		{"-0", "0", nil},
+		{"+42", "42", nil},
+		{"42.000", "42", nil},
+		{"00042", "42", nil},
+		{max.String(), max.String(), nil},
+		{new(big.Int).Add(max, big.NewInt(1)).String(), "", ErrOverflow},
+		{"-1", "", ErrNegative},
+		{"42.5", "", ErrInexact},
+		{"", "", ErrSyntax},
+		{"+", "", ErrSyntax},
+		{".5", "", ErrSyntax},
+		{"1e10", "", ErrSyntax},
+		{"12a", "", ErrSyntax},
+		{"NaN", "", ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		var u Uint128
+		err := u.ParseNumeric([]byte(tc.s))
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("unexpected error for %q; got %v; want %v", tc.s, err, tc.err)
+		}
+		if err == nil && u.String() != tc.want {
+			t.Fatalf("unexpected value for %q; got %v; want %v", tc.s, u, tc.want)
+		}
+	}
+}
+
+func TestUint128SQL(t *testing.T) {
+	u := NewUint128(1, 2)
+
+	v, err := u.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Uint128
+	if err := got.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if got != u {
+		t.Fatalf("unexpected value; got %v; want %v", got, u)
+	}
+
+	if err := got.Scan(int64(7)); err != nil || got != Uint128FromUint64(7) {
+		t.Fatalf("unexpected scan of int64; got %v (%v)", got, err)
+	}
+	if err := got.Scan(1.5); err == nil {
+		t.Fatal("scan of float64 must fail")
+	}
+}
@@ -0,0 +1,75 @@
+package mathx
+
+import "sync"
+
+// Observer records observed values.
+type Observer interface {
+	Observe(v float64)
+}
+
+// Quantiler returns quantiles of observed values.
+type Quantiler interface {
+	Quantile(phi float64) float64
+}
+
+// Sample records values and returns their quantiles.
+// It is implemented by Histogram and SyncHistogram.
+type Sample interface {
+	Observer
+	Quantiler
+}
+
+// ObserverFunc is an adapter to allow the use of ordinary functions as Observer.
+type ObserverFunc func(v float64)
+
+// Observe calls f(v).
+func (f ObserverFunc) Observe(v float64) { f(v) }
+
+// Observe is an alias for Update.
+func (h *Histogram) Observe(v float64) { h.Update(v) }
+
+// SyncHistogram is a Histogram safe for concurrent use.
+type SyncHistogram struct {
+	mu sync.Mutex
+	h  *Histogram
+}
+
+// NewSyncHistogram returns new SyncHistogram.
+func NewSyncHistogram() *SyncHistogram {
+	return &SyncHistogram{h: NewHistogram()}
+}
+
+// Observe the histogram with v.
+func (s *SyncHistogram) Observe(v float64) {
+	s.mu.Lock()
+	s.h.Update(v)
+	s.mu.Unlock()
+}
+
+// Quantile returns the quantile value for the given phi.
+func (s *SyncHistogram) Quantile(phi float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Quantile(phi)
+}
+
+// Quantiles appends quantile values to dst for the given phis.
+func (s *SyncHistogram) Quantiles(dst, phis []float64) []float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Quantiles(dst, phis)
+}
+
+// Reset resets the histogram.
+func (s *SyncHistogram) Reset() {
+	s.mu.Lock()
+	s.h.Reset()
+	s.mu.Unlock()
+}
+
+// Do calls fn with the underlying histogram while holding the lock.
+func (s *SyncHistogram) Do(fn func(h *Histogram)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.h)
+}
@@ -0,0 +1,46 @@
+package mathx
+
+import (
+	"sync"
+	"testing"
+)
+
+var (
+	_ Sample = (*Histogram)(nil)
+	_ Sample = (*SyncHistogram)(nil)
+)
+
+func TestSyncHistogram(t *testing.T) {
+	s := NewSyncHistogram()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.Observe(float64(j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	var count uint64
+	s.Do(func(h *Histogram) { count = h.res.Count() })
+	if count != 400 {
+		t.Fatalf("unexpected count; got %v; want %v", count, 400)
+	}
+	if q := s.Quantile(1); q != 99 {
+		t.Fatalf("unexpected max; got %v; want %v", q, 99)
+	}
+}
+
+func TestObserverFunc(t *testing.T) {
+	var got []float64
+	var o Observer = ObserverFunc(func(v float64) { got = append(got, v) })
+
+	StartTimer(o).ObserveDuration()
+	if len(got) != 1 || got[0] < 0 {
+		t.Fatalf("unexpected observations: %v", got)
+	}
+}
@@ -0,0 +1,107 @@
+package mathx
+
+import "math"
+
+// OnlineStats maintains the running count, mean, variance, min and max
+// of a stream of values in constant memory using Welford's algorithm.
+// The zero value is ready to use.
+type OnlineStats struct {
+	n        uint64
+	mean, m2 float64
+	min, max float64
+}
+
+// Add the value x, NaN values are ignored.
+func (s *OnlineStats) Add(x float64) {
+	if x != x {
+		return
+	}
+	s.n++
+	if s.n == 1 {
+		s.min, s.max = x, x
+	} else {
+		s.min, s.max = math.Min(s.min, x), math.Max(s.max, x)
+	}
+	d := x - s.mean
+	s.mean += d / float64(s.n)
+	s.m2 += d * (x - s.mean)
+}
+
+// Observe is an alias for Add.
+func (s *OnlineStats) Observe(x float64) { s.Add(x) }
+
+// Merge adds all values of x into s using the parallel algorithm by Chan et al.
+func (s *OnlineStats) Merge(x *OnlineStats) {
+	switch {
+	case x.n == 0:
+		return
+	case s.n == 0:
+		*s = *x
+		return
+	}
+	n := s.n + x.n
+	d := x.mean - s.mean
+	s.mean += d * float64(x.n) / float64(n)
+	s.m2 += x.m2 + d*d*float64(s.n)*float64(x.n)/float64(n)
+	s.min, s.max = math.Min(s.min, x.min), math.Max(s.max, x.max)
+	s.n = n
+}
+
+// Reset resets the stats.
+func (s *OnlineStats) Reset() { *s = OnlineStats{} }
+
+// Count returns the number of added values.
+func (s *OnlineStats) Count() uint64 { return s.n }
+
+// Mean returns the mean, NaN if there are no values.
+func (s *OnlineStats) Mean() float64 {
+	if s.n == 0 {
+		return NaN
+	}
+	return s.mean
+}
+
+// Variance returns the population variance, NaN if there are no values.
+func (s *OnlineStats) Variance() float64 {
+	if s.n == 0 {
+		return NaN
+	}
+	return s.m2 / float64(s.n)
+}
+
+// SampleVariance returns the unbiased sample variance, NaN if there are less than 2 values.
+func (s *OnlineStats) SampleVariance() float64 {
+	if s.n < 2 {
+		return NaN
+	}
+	return s.m2 / float64(s.n-1)
+}
+
+// StdDev returns the population standard deviation.
+func (s *OnlineStats) StdDev() float64 { return math.Sqrt(s.Variance()) }
+
+// Min returns the minimum value, NaN if there are no values.
+func (s *OnlineStats) Min() float64 {
+	if s.n == 0 {
+		return NaN
+	}
+	return s.min
+}
+
+// Max returns the maximum value, NaN if there are no values.
+func (s *OnlineStats) Max() float64 {
+	if s.n == 0 {
+		return NaN
+	}
+	return s.max
+}
+
+// ZScore returns (x - mean) / stddev, NaN if there are no values
+// and ±Inf for a different x if the standard deviation is 0.
+func (s *OnlineStats) ZScore(x float64) float64 {
+	d := x - s.Mean()
+	if d == 0 {
+		return 0
+	}
+	return d / s.StdDev()
+}
@@ -0,0 +1,50 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOnlineStats(t *testing.T) {
+	var s OnlineStats
+	if !math.IsNaN(s.Mean()) || !math.IsNaN(s.Variance()) || !math.IsNaN(s.Min()) {
+		t.Fatal("empty stats must be NaN")
+	}
+
+	xs := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	for _, x := range xs {
+		s.Add(x)
+	}
+	s.Add(NaN)
+	if s.Count() != 8 || s.Mean() != 5 || s.Variance() != 4 || s.StdDev() != 2 {
+		t.Fatalf("unexpected stats; got n=%v mean=%v var=%v", s.Count(), s.Mean(), s.Variance())
+	}
+	if s.SampleVariance() != 32.0/7 || s.Min() != 2 || s.Max() != 9 {
+		t.Fatalf("unexpected stats; got svar=%v min=%v max=%v", s.SampleVariance(), s.Min(), s.Max())
+	}
+	if z := s.ZScore(9); z != 2 {
+		t.Fatalf("unexpected z-score; got %v; want 2", z)
+	}
+
+	var a, b OnlineStats
+	for i, x := range xs {
+		if i < 3 {
+			a.Add(x)
+		} else {
+			b.Add(x)
+		}
+	}
+	a.Merge(&b)
+	if a.Count() != s.Count() || math.Abs(a.Mean()-s.Mean()) > 1e-12 || math.Abs(a.Variance()-s.Variance()) > 1e-12 || a.Min() != 2 || a.Max() != 9 {
+		t.Fatalf("unexpected merged stats; got %+v; want %+v", a, s)
+	}
+
+	// Large offset must not lose precision.
+	var big OnlineStats
+	for _, x := range xs {
+		big.Add(1e9 + x)
+	}
+	if math.Abs(big.Variance()-4) > 1e-6 {
+		t.Fatalf("unexpected variance with offset; got %v; want 4", big.Variance())
+	}
+}
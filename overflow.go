@@ -0,0 +1,50 @@
+package mathx
+
+import "math/bits"
+
+// AddOverflow returns u+x wrapped around at 2^128 and whether the addition overflowed.
+func (u Uint128) AddOverflow(x Uint128) (Uint128, bool) {
+	sum, carry := u.AddCarry(x, 0)
+	return sum, carry != 0
+}
+
+// SubOverflow returns u-x wrapped around at 2^128 and whether the subtraction overflowed, i.e. x > u.
+func (u Uint128) SubOverflow(x Uint128) (Uint128, bool) {
+	diff, borrow := u.SubBorrow(x, 0)
+	return diff, borrow != 0
+}
+
+// MulOverflow returns u*x wrapped around at 2^128 and whether the multiplication overflowed.
+func (u Uint128) MulOverflow(x Uint128) (Uint128, bool) {
+	hi, lo := bits.Mul64(u.lo, x.lo)
+	c1, m1 := bits.Mul64(u.hi, x.lo)
+	c2, m2 := bits.Mul64(u.lo, x.hi)
+	hi, c3 := bits.Add64(hi, m1, 0)
+	hi, c4 := bits.Add64(hi, m2, 0)
+	overflow := u.hi != 0 && x.hi != 0 || c1|c2|c3|c4 != 0
+	return Uint128{hi: hi, lo: lo}, overflow
+}
+
+// AddSat returns u+x or the maximum Uint128 value if the addition overflows.
+func (u Uint128) AddSat(x Uint128) Uint128 {
+	if sum, over := u.AddOverflow(x); !over {
+		return sum
+	}
+	return MaxUint128
+}
+
+// SubSat returns u-x or 0 if x > u.
+func (u Uint128) SubSat(x Uint128) Uint128 {
+	if diff, over := u.SubOverflow(x); !over {
+		return diff
+	}
+	return Uint128{}
+}
+
+// MulSat returns u*x or the maximum Uint128 value if the multiplication overflows.
+func (u Uint128) MulSat(x Uint128) Uint128 {
+	if p, over := u.MulOverflow(x); !over {
+		return p
+	}
+	return MaxUint128
+}
@@ -0,0 +1,67 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUint128Overflow(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	one := NewUint128(0, 1)
+	testCases := []struct {
+		a, b                      Uint128
+		addOver, subOver, mulOver bool
+	}{
+		{Uint128{}, Uint128{}, false, false, false},
+		{max, one, true, false, false},
+		{one, max, true, true, false},
+		{max, max, true, false, true},
+		{NewUint128(1, 0), NewUint128(1, 0), false, false, true},
+		{NewUint128(0, 1<<63), NewUint128(2, 0), false, true, true},
+		{NewUint128(0, 1<<63), NewUint128(1, 0), false, true, false},
+		{NewUint128(1<<32, 0), NewUint128(0, 1<<32), false, false, true},
+		{NewUint128(1<<32-1, 0), NewUint128(0, 1<<32), false, false, false},
+		{NewUint128(0, math.MaxUint64), NewUint128(0, math.MaxUint64), false, false, false},
+	}
+	for _, tc := range testCases {
+		if got, over := tc.a.AddOverflow(tc.b); got != tc.a.Add(tc.b) || over != tc.addOver {
+			t.Fatalf("unexpected AddOverflow(%v, %v); got %v, %v; want %v", tc.a, tc.b, got, over, tc.addOver)
+		}
+		if got, over := tc.a.SubOverflow(tc.b); got != tc.a.Sub(tc.b) || over != tc.subOver {
+			t.Fatalf("unexpected SubOverflow(%v, %v); got %v, %v; want %v", tc.a, tc.b, got, over, tc.subOver)
+		}
+		if got, over := tc.a.MulOverflow(tc.b); got != tc.a.Mul(tc.b) || over != tc.mulOver {
+			t.Fatalf("unexpected MulOverflow(%v, %v); got %v, %v; want %v", tc.a, tc.b, got, over, tc.mulOver)
+		}
+		if _, over := tc.b.MulOverflow(tc.a); over != tc.mulOver {
+			t.Fatalf("MulOverflow must be commutative for %v, %v", tc.a, tc.b)
+		}
+	}
+}
+
+func TestUint128Sat(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	one := NewUint128(0, 1)
+	two := NewUint128(0, 2)
+	if got := max.AddSat(one); got != max {
+		t.Fatalf("unexpected AddSat; got %v; want %v", got, max)
+	}
+	if got := one.AddSat(one); got != two {
+		t.Fatalf("unexpected AddSat; got %v; want %v", got, two)
+	}
+	if got := one.SubSat(two); !got.IsZero() {
+		t.Fatalf("unexpected SubSat; got %v; want 0", got)
+	}
+	if got := two.SubSat(one); got != one {
+		t.Fatalf("unexpected SubSat; got %v; want %v", got, one)
+	}
+	if got := max.MulSat(two); got != max {
+		t.Fatalf("unexpected MulSat; got %v; want %v", got, max)
+	}
+	if got := max.MulSat(one); got != max {
+		t.Fatalf("unexpected MulSat; got %v; want %v", got, max)
+	}
+	if got := two.MulSat(two); got != NewUint128(0, 4) {
+		t.Fatalf("unexpected MulSat; got %v; want 4", got)
+	}
+}
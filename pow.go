@@ -0,0 +1,30 @@
+package mathx
+
+// Pow returns u**n wrapped around at 2^128, 0**0 is 1.
+func (u Uint128) Pow(n uint) Uint128 {
+	r := Uint128{lo: 1}
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r = r.Mul(u)
+		}
+		u = u.Mul(u)
+	}
+	return r
+}
+
+// PowOverflow returns u**n wrapped around at 2^128 and whether the result overflowed.
+func (u Uint128) PowOverflow(n uint) (Uint128, bool) {
+	r := Uint128{lo: 1}
+	var overflow, over bool
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			r, over = r.MulOverflow(u)
+			overflow = overflow || over
+		}
+		if n > 1 { // the square is used by a higher bit of n
+			u, over = u.MulOverflow(u)
+			overflow = overflow || over
+		}
+	}
+	return r, overflow
+}
@@ -0,0 +1,82 @@
+package mathx
+
+import "math"
+
+// pow10tab contains powers of ten that are exact in float64.
+var pow10tab = [...]float64{
+	1e00, 1e01, 1e02, 1e03, 1e04, 1e05, 1e06, 1e07, 1e08, 1e09,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+	1e20, 1e21, 1e22,
+}
+
+// maxPow10 is the largest n for which x * 10**n may be finite and x / 10**n
+// may be non-zero for a finite non-zero float64 x.
+const maxPow10 = 650
+
+// MulPow10 returns x * 10**n.
+//
+// The product is computed in Double precision and rounded once,
+// so the result is correctly rounded except for rare near-halfway cases
+// and subnormal values. Use it to convert between unit scales
+// (seconds to milliseconds, dollars to cents) without drift.
+// Results out of the float64 range are ±Inf or ±0.
+func MulPow10(x float64, n int) float64 {
+	switch {
+	case x == 0 || math.IsInf(x, 0) || math.IsNaN(x):
+		return x
+	case n > maxPow10:
+		return math.Copysign(math.Inf(1), x)
+	case n < -maxPow10:
+		return math.Copysign(0, x)
+	case n < 0:
+		return scalePow10(x, -n, true)
+	}
+	return scalePow10(x, n, false)
+}
+
+// DivPow10 returns x / 10**n.
+//
+// See MulPow10 for accuracy guarantees.
+func DivPow10(x float64, n int) float64 {
+	switch {
+	case x == 0 || math.IsInf(x, 0) || math.IsNaN(x):
+		return x
+	case n > maxPow10:
+		return math.Copysign(0, x)
+	case n < -maxPow10:
+		return math.Copysign(math.Inf(1), x)
+	case n < 0:
+		return scalePow10(x, -n, false)
+	}
+	return scalePow10(x, n, true)
+}
+
+// scalePow10 returns x * 10**n, or x / 10**n if div is set,
+// for a finite non-zero x and 0 <= n <= maxPow10.
+func scalePow10(x float64, n int, div bool) float64 {
+	d, k := DoubleFromFloat(x), 0
+	for {
+		// Keep d in the range where Veltkamp's splitting neither overflows
+		// nor underflows, the powers of two are applied in the final rounding.
+		switch a := math.Abs(d.hi); {
+		case a > 0x1p900:
+			d, k = mulDFpow2(d, -128), k+128
+		case a < 0x1p-900:
+			d, k = mulDFpow2(d, 128), k-128
+		}
+
+		f := 1e22
+		if n <= 22 {
+			f = pow10tab[n]
+		}
+		if div {
+			d = divDF(d, f)
+		} else {
+			d = mulDF(d, f)
+		}
+		if n <= 22 {
+			return math.Ldexp(d.ToFloat64(), k)
+		}
+		n -= 22
+	}
+}
@@ -0,0 +1,123 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestMulPow10(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		x := math.Ldexp(rng.Float64()+0.5, rng.Intn(200)-100)
+		n := rng.Intn(60) - 30
+
+		want := refPow10(x, n)
+		if got := MulPow10(x, n); got != want {
+			t.Fatalf("unexpected MulPow10(%v, %v); got %v; want %v", x, n, got, want)
+		}
+		if got := DivPow10(x, -n); got != want {
+			t.Fatalf("unexpected DivPow10(%v, %v); got %v; want %v", x, -n, got, want)
+		}
+	}
+}
+
+func TestMulPow10Special(t *testing.T) {
+	testCases := []struct {
+		x    float64
+		n    int
+		want float64
+	}{
+		{0, 5, 0},
+		{InfPos, 3, InfPos},
+		{InfNeg, -3, InfNeg},
+		{1.5e300, 5, refPow10(1.5e300, 5)},
+		{1e300, 10, InfPos},
+		{0.29, 2, refPow10(0.29, 2)},
+		{-1234.5678, -4, refPow10(-1234.5678, -4)},
+	}
+
+	for _, tc := range testCases {
+		if got := MulPow10(tc.x, tc.n); got != tc.want {
+			t.Fatalf("unexpected MulPow10(%v, %v); got %v; want %v", tc.x, tc.n, got, tc.want)
+		}
+	}
+	if got := MulPow10(NaN, 1); !math.IsNaN(got) {
+		t.Fatalf("unexpected MulPow10(NaN, 1); got %v", got)
+	}
+}
+
+func TestMulPow10Range(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		x := math.Ldexp(rng.Float64()+0.5, rng.Intn(2000)-1000)
+		n := rng.Intn(1400) - 700
+
+		want := refPow10(x, n)
+		if want != 0 && math.Abs(want) < 0x1p-1022 {
+			continue // subnormal results may be rounded twice
+		}
+		if got := MulPow10(x, n); got != want {
+			t.Fatalf("unexpected MulPow10(%v, %v); got %v; want %v", x, n, got, want)
+		}
+		if got := DivPow10(x, -n); got != want {
+			t.Fatalf("unexpected DivPow10(%v, %v); got %v; want %v", x, -n, got, want)
+		}
+	}
+}
+
+func TestMulPow10Extreme(t *testing.T) {
+	testCases := []struct {
+		x    float64
+		n    int
+		want float64
+	}{
+		{1e250, 100, InfPos},
+		{-1e250, 100, InfNeg},
+		{1.5e307, 1, 1.5e308},
+		{math.MaxFloat64, 1, InfPos},
+		{3, 1 << 40, InfPos},
+		{3, math.MaxInt, InfPos},
+		{-3, math.MaxInt, InfNeg},
+		{3, math.MinInt, 0},
+		{1e-300, -100, 0},
+		{5e-324, 631, refPow10(5e-324, 631)},
+		{math.MaxFloat64, -631, refPow10(math.MaxFloat64, -631)},
+	}
+
+	for _, tc := range testCases {
+		if got := MulPow10(tc.x, tc.n); got != tc.want {
+			t.Fatalf("unexpected MulPow10(%v, %v); got %v; want %v", tc.x, tc.n, got, tc.want)
+		}
+	}
+	if got := MulPow10(-3, math.MinInt); got != 0 || !math.Signbit(got) {
+		t.Fatalf("unexpected MulPow10(-3, MinInt); got %v; want -0", got)
+	}
+	if got := DivPow10(3, math.MinInt); got != InfPos {
+		t.Fatalf("unexpected DivPow10(3, MinInt); got %v; want %v", got, InfPos)
+	}
+	if got := DivPow10(3, math.MaxInt); got != 0 {
+		t.Fatalf("unexpected DivPow10(3, MaxInt); got %v; want 0", got)
+	}
+}
+
+func refPow10(x float64, n int) float64 {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(n))), nil)
+	f := new(big.Float).SetPrec(2000).SetFloat64(x)
+	pf := new(big.Float).SetPrec(2000).SetInt(p)
+	if n >= 0 {
+		f.Mul(f, pf)
+	} else {
+		f.Quo(f, pf)
+	}
+	r, _ := f.Float64()
+	return r
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
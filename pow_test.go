@@ -0,0 +1,32 @@
+package mathx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestUint128Pow(t *testing.T) {
+	max := new(big.Int).Lsh(big.NewInt(1), 128)
+	for _, base := range []Uint128{{}, {lo: 1}, {lo: 2}, {lo: 3}, {lo: 10}, {hi: 1}, {lo: 1<<64 - 1}} {
+		for n := uint(0); n <= 130; n++ {
+			want := new(big.Int).Exp(base.Big(), big.NewInt(int64(n)), nil)
+			over := want.Cmp(max) >= 0
+			want.Mod(want, max)
+
+			if got := base.Pow(n); got.Big().Cmp(want) != 0 {
+				t.Fatalf("unexpected %v**%d; got %v; want %v", base, n, got, want)
+			}
+			got, gotOver := base.PowOverflow(n)
+			if got.Big().Cmp(want) != 0 || gotOver != over {
+				t.Fatalf("unexpected PowOverflow %v**%d; got %v, %v; want %v, %v", base, n, got, gotOver, want, over)
+			}
+		}
+	}
+
+	if got, over := NewUint128(0, 10).PowOverflow(38); over || got.String() != "100000000000000000000000000000000000000" {
+		t.Fatalf("unexpected 10**38; got %v, %v", got, over)
+	}
+	if _, over := NewUint128(0, 10).PowOverflow(39); !over {
+		t.Fatal("10**39 must overflow")
+	}
+}
@@ -0,0 +1,106 @@
+package mathx
+
+// Range128 is an inclusive range [lo, hi] of Uint128 values.
+// The zero value is the range containing only 0.
+type Range128 struct {
+	lo, hi Uint128
+}
+
+// NewRange128 returns the range [lo, hi]. It panics if lo > hi.
+func NewRange128(lo, hi Uint128) Range128 {
+	if lo.Cmp(hi) > 0 {
+		panic("mathx: range lo must not exceed hi")
+	}
+	return Range128{lo: lo, hi: hi}
+}
+
+// Lo returns the first value of the range.
+func (r Range128) Lo() Uint128 { return r.lo }
+
+// Hi returns the last value of the range.
+func (r Range128) Hi() Uint128 { return r.hi }
+
+// Span returns hi - lo, the number of values in the range minus one.
+// Unlike the number of values it never overflows.
+func (r Range128) Span() Uint128 { return r.hi.Sub(r.lo) }
+
+// Contains reports whether u is in the range.
+func (r Range128) Contains(u Uint128) bool {
+	return r.lo.Cmp(u) <= 0 && u.Cmp(r.hi) <= 0
+}
+
+// ContainsRange reports whether x is entirely in the range.
+func (r Range128) ContainsRange(x Range128) bool {
+	return r.lo.Cmp(x.lo) <= 0 && x.hi.Cmp(r.hi) <= 0
+}
+
+// Overlaps reports whether the ranges have common values.
+func (r Range128) Overlaps(x Range128) bool {
+	return r.lo.Cmp(x.hi) <= 0 && x.lo.Cmp(r.hi) <= 0
+}
+
+// Intersect returns the common values of the ranges and whether there are any.
+func (r Range128) Intersect(x Range128) (Range128, bool) {
+	if !r.Overlaps(x) {
+		return Range128{}, false
+	}
+	lo, hi := r.lo, r.hi
+	if x.lo.Cmp(lo) > 0 {
+		lo = x.lo
+	}
+	if x.hi.Cmp(hi) < 0 {
+		hi = x.hi
+	}
+	return Range128{lo: lo, hi: hi}, true
+}
+
+// Split divides the range into n consecutive ranges of sizes that differ by at most 1,
+// the larger ones first. If the range has fewer than n values,
+// it returns one range per value. It panics if n <= 0.
+func (r Range128) Split(n int) []Range128 {
+	if n <= 0 {
+		panic("mathx: split count must be positive")
+	}
+	span := r.Span()
+	if span.Cmp(Uint128FromUint64(uint64(n))) < 0 {
+		n = int(span.lo) + 1
+	}
+
+	// size*n + rem == span + 1, computed without overflow for the full range.
+	size, rem := span.QuoRem64(uint64(n))
+	if rem++; rem == uint64(n) {
+		size, rem = size.Inc(), 0
+	}
+
+	parts := make([]Range128, n)
+	lo := r.lo
+	for i := range parts {
+		last := size
+		if uint64(i) >= rem {
+			last = last.Dec()
+		}
+		parts[i] = Range128{lo: lo, hi: lo.Add(last)}
+		lo = parts[i].hi.Inc()
+	}
+	return parts
+}
+
+// Step calls fn for lo, lo+step, lo+2*step and so on while the value is in the range
+// and fn returns true. It panics if step is zero.
+func (r Range128) Step(step Uint128, fn func(u Uint128) bool) {
+	if step.IsZero() {
+		panic("mathx: step must be positive")
+	}
+	for u := r.lo; fn(u); {
+		next, carry := u.AddCarry(step, 0)
+		if carry != 0 || next.Cmp(r.hi) > 0 {
+			return
+		}
+		u = next
+	}
+}
+
+// String returns the range as "[lo, hi]".
+func (r Range128) String() string {
+	return "[" + r.lo.String() + ", " + r.hi.String() + "]"
+}
@@ -0,0 +1,91 @@
+package mathx
+
+import "testing"
+
+func TestRange128(t *testing.T) {
+	u := Uint128FromUint64
+	r := NewRange128(u(10), u(20))
+
+	if !r.Contains(u(10)) || !r.Contains(u(20)) || r.Contains(u(9)) || r.Contains(u(21)) {
+		t.Fatal("unexpected Contains")
+	}
+	if !r.ContainsRange(NewRange128(u(12), u(20))) || r.ContainsRange(NewRange128(u(12), u(21))) {
+		t.Fatal("unexpected ContainsRange")
+	}
+	if !r.Overlaps(NewRange128(u(20), u(30))) || r.Overlaps(NewRange128(u(21), u(30))) {
+		t.Fatal("unexpected Overlaps")
+	}
+
+	got, ok := r.Intersect(NewRange128(u(15), u(30)))
+	if !ok || got != NewRange128(u(15), u(20)) {
+		t.Fatalf("unexpected Intersect; got %v", got)
+	}
+	if _, ok := r.Intersect(NewRange128(u(0), u(9))); ok {
+		t.Fatal("unexpected Intersect of disjoint ranges")
+	}
+	if s := r.String(); s != "[10, 20]" {
+		t.Fatalf("unexpected String; got %v", s)
+	}
+}
+
+func TestRange128Split(t *testing.T) {
+	u := Uint128FromUint64
+	parts := NewRange128(u(0), u(9)).Split(3)
+	want := []Range128{NewRange128(u(0), u(3)), NewRange128(u(4), u(6)), NewRange128(u(7), u(9))}
+	if len(parts) != len(want) {
+		t.Fatalf("unexpected parts; got %v; want %v", parts, want)
+	}
+	for i := range want {
+		if parts[i] != want[i] {
+			t.Fatalf("unexpected parts; got %v; want %v", parts, want)
+		}
+	}
+
+	if parts := NewRange128(u(5), u(6)).Split(10); len(parts) != 2 || parts[1] != NewRange128(u(6), u(6)) {
+		t.Fatalf("unexpected parts; got %v", parts)
+	}
+
+	full := NewRange128(Uint128{}, MaxUint128)
+	parts = full.Split(4)
+	if parts[0].Hi() != NewUint128(1<<62-1, 1<<64-1) || parts[3].Hi() != MaxUint128 {
+		t.Fatalf("unexpected parts of full range; got %v", parts)
+	}
+	for i := 1; i < len(parts); i++ {
+		if parts[i].Lo() != parts[i-1].Hi().Inc() {
+			t.Fatalf("parts are not consecutive: %v", parts)
+		}
+	}
+	if parts := full.Split(1); parts[0] != full {
+		t.Fatalf("unexpected parts; got %v", parts)
+	}
+}
+
+func TestRange128Step(t *testing.T) {
+	u := Uint128FromUint64
+	var got []Uint128
+	NewRange128(u(1), u(10)).Step(u(3), func(v Uint128) bool {
+		got = append(got, v)
+		return true
+	})
+	if len(got) != 4 || got[3] != u(10) {
+		t.Fatalf("unexpected steps; got %v", got)
+	}
+
+	got = got[:0]
+	NewRange128(MaxUint128.Dec(), MaxUint128).Step(u(1), func(v Uint128) bool {
+		got = append(got, v)
+		return true
+	})
+	if len(got) != 2 {
+		t.Fatalf("unexpected steps near max; got %v", got)
+	}
+
+	n := 0
+	NewRange128(u(0), u(100)).Step(u(1), func(Uint128) bool {
+		n++
+		return n < 5
+	})
+	if n != 5 {
+		t.Fatalf("unexpected number of calls; got %v; want %v", n, 5)
+	}
+}
@@ -0,0 +1,117 @@
+package mathx
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// RateSnapshot is the event rate over a window.
+type RateSnapshot struct {
+	Window time.Duration
+	Count  uint64
+	Rate   float64 // events per second
+}
+
+// RateCounter tracks events per second over sliding windows.
+// Events are counted in per-second buckets of a ring, every bucket
+// packs its second and count into one word, so Add is a single CAS loop.
+// Rates are computed over completed seconds only.
+// RateCounter is safe for concurrent use.
+type RateCounter struct {
+	windows []time.Duration
+	buckets []uint64 // second<<32 | count
+	now     func() time.Time
+}
+
+// NewRateCounter returns new RateCounter for the given windows,
+// by default 1s, 10s and 1m. Windows are rounded up to whole seconds.
+func NewRateCounter(windows ...time.Duration) *RateCounter {
+	if len(windows) == 0 {
+		windows = []time.Duration{time.Second, 10 * time.Second, time.Minute}
+	}
+	r := &RateCounter{now: time.Now}
+	var longest int64
+	for _, w := range windows {
+		if w <= 0 {
+			panic("mathx: rate window must be positive")
+		}
+		w = (w + time.Second - 1).Truncate(time.Second)
+		r.windows = append(r.windows, w)
+		if s := int64(w / time.Second); s > longest {
+			longest = s
+		}
+	}
+	r.buckets = make([]uint64, longest+1)
+	return r
+}
+
+// Windows returns the windows of the counter.
+func (r *RateCounter) Windows() []time.Duration {
+	return append([]time.Duration(nil), r.windows...)
+}
+
+// Inc adds 1 event.
+func (r *RateCounter) Inc() { r.Add(1) }
+
+// Add adds n events at the current time.
+// Counts saturate at 2^32-1 events per second.
+func (r *RateCounter) Add(n uint64) {
+	sec := r.now().Unix()
+	b := &r.buckets[uint64(sec)%uint64(len(r.buckets))]
+	stamp := uint64(uint32(sec)) << 32
+	for {
+		old := atomic.LoadUint64(b)
+		count := n
+		if old&^math.MaxUint32 == stamp {
+			count += old & math.MaxUint32
+		}
+		if count > math.MaxUint32 || count < n {
+			count = math.MaxUint32
+		}
+		if atomic.CompareAndSwapUint64(b, old, stamp|count) {
+			return
+		}
+	}
+}
+
+// Rate returns events per second over the last completed seconds of the window w.
+func (r *RateCounter) Rate(w time.Duration) float64 {
+	return r.snapshot(r.now().Unix(), w).Rate
+}
+
+// Snapshot appends the rates of all windows to dst.
+func (r *RateCounter) Snapshot(dst []RateSnapshot) []RateSnapshot {
+	sec := r.now().Unix()
+	for _, w := range r.windows {
+		dst = append(dst, r.snapshot(sec, w))
+	}
+	return dst
+}
+
+// Reset resets the counter.
+func (r *RateCounter) Reset() {
+	for i := range r.buckets {
+		atomic.StoreUint64(&r.buckets[i], 0)
+	}
+}
+
+func (r *RateCounter) snapshot(now int64, w time.Duration) RateSnapshot {
+	secs := int64((w + time.Second - 1) / time.Second)
+	if secs <= 0 || secs >= int64(len(r.buckets)) {
+		secs = int64(len(r.buckets)) - 1
+	}
+
+	var count uint64
+	for sec := now - secs; sec < now; sec++ {
+		v := atomic.LoadUint64(&r.buckets[uint64(sec)%uint64(len(r.buckets))])
+		if v>>32 == uint64(uint32(sec)) {
+			count += v & math.MaxUint32
+		}
+	}
+	return RateSnapshot{
+		Window: time.Duration(secs) * time.Second,
+		Count:  count,
+		Rate:   float64(count) / float64(secs),
+	}
+}
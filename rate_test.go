@@ -0,0 +1,71 @@
+package mathx
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateCounter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := NewRateCounter()
+	r.now = func() time.Time { return now }
+
+	for i := 0; i < 20; i++ {
+		r.Add(uint64(i))
+		now = now.Add(time.Second)
+	}
+	r.Add(1000) // current second is not counted yet
+
+	got := r.Snapshot(nil)
+	want := []RateSnapshot{
+		{Window: time.Second, Count: 19, Rate: 19},
+		{Window: 10 * time.Second, Count: 145, Rate: 14.5},
+		{Window: time.Minute, Count: 190, Rate: 190.0 / 60},
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected snapshot %d; got %+v; want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Stale buckets from a previous lap of the ring are ignored.
+	now = now.Add(time.Minute)
+	if rate := r.Rate(time.Minute); rate != 1000.0/60 {
+		t.Fatalf("unexpected rate; got %v; want %v", rate, 1000.0/60)
+	}
+	now = now.Add(time.Hour)
+	if rate := r.Rate(time.Minute); rate != 0 {
+		t.Fatalf("unexpected rate; got %v; want 0", rate)
+	}
+}
+
+func TestRateCounterConcurrent(t *testing.T) {
+	now := time.Unix(1000, 0)
+	r := NewRateCounter(2500 * time.Millisecond)
+	r.now = func() time.Time { return now }
+	if w := r.Windows(); len(w) != 1 || w[0] != 3*time.Second {
+		t.Fatalf("unexpected windows; got %v", w)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				r.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+
+	now = now.Add(time.Second)
+	if s := r.Snapshot(nil)[0]; s.Count != 8000 {
+		t.Fatalf("unexpected count; got %v; want %v", s.Count, 8000)
+	}
+	r.Reset()
+	if rate := r.Rate(3 * time.Second); rate != 0 {
+		t.Fatalf("unexpected rate after Reset; got %v", rate)
+	}
+}
@@ -0,0 +1,259 @@
+package mathx
+
+import (
+	"math"
+	"math/bits"
+)
+
+// ContinuedFraction appends up to n terms of the continued fraction expansion of x to dst.
+// The first term is floor(x), the others are positive. The expansion stops early
+// when the remainder is zero or a term does not fit into int64; for non-finite x no terms are appended.
+func ContinuedFraction(dst []int64, x float64, n int) []int64 {
+	return ContinuedFractionDouble(dst, DoubleFromFloat(x), n)
+}
+
+// ContinuedFractionDouble is like ContinuedFraction for x in Double precision.
+// The terms are computed in Double precision, they are exact while the denominators
+// of the convergents are below about 2**50, so about twice as many as with float64.
+func ContinuedFractionDouble(dst []int64, x Double, n int) []int64 {
+	for i := 0; i < n; i++ {
+		a, ok := floorInt64(x)
+		if !ok {
+			break
+		}
+		dst = append(dst, a)
+		frac := x.Sub(doubleFromInt64(a))
+		if frac.hi == 0 {
+			break
+		}
+		x = frac.Inv()
+	}
+	return dst
+}
+
+// BestRational returns the fraction num/den closest to x with 0 < den <= maxDen,
+// ties choose the smaller denominator. It panics if maxDen < 1.
+// It uses ContinuedFractionDouble, so denominators above about 2**50 may be inexact.
+// For non-finite x or |x| >= 2**63 the result is 0/0.
+func BestRational(x float64, maxDen int64) (num, den int64) {
+	return BestRationalDouble(DoubleFromFloat(x), maxDen)
+}
+
+// BestRationalDouble is like BestRational for x in Double precision.
+func BestRationalDouble(x Double, maxDen int64) (num, den int64) {
+	if maxDen < 1 {
+		panic("mathx: max denominator must be positive")
+	}
+	neg := x.hi < 0
+	x = x.Abs()
+	if !(x.hi < 0x1p63) {
+		return 0, 0
+	}
+
+	// Convergents h/k of the continued fraction, starting with 0/1 and 1/0.
+	h0, k0, h1, k1 := int64(0), int64(1), int64(1), int64(0)
+	for y := x; ; {
+		a, _ := floorInt64(y)
+
+		// The largest term that keeps the denominator and the numerator in range.
+		limit := int64(math.MaxInt64)
+		if k1 != 0 {
+			limit = (maxDen - k0) / k1
+		}
+		if h1 != 0 {
+			if l := (math.MaxInt64 - h0) / h1; l < limit {
+				limit = l
+			}
+		}
+		if a > limit {
+			// The semiconvergent with the largest term may be closer than the last convergent.
+			if t := limit; t >= 1 {
+				h, k := t*h1+h0, t*k1+k0
+				if fracDist(x, h, k).LT(fracDist(x, h1, k1)) {
+					h1, k1 = h, k
+				}
+			}
+			break
+		}
+
+		h0, k0, h1, k1 = h1, k1, a*h1+h0, a*k1+k0
+		frac := y.Sub(doubleFromInt64(a))
+		if frac.hi <= 0 {
+			break
+		}
+		y = frac.Inv()
+	}
+
+	if neg {
+		h1 = -h1
+	}
+	return h1, k1
+}
+
+// fracDist returns |x - h/k| for k > 0.
+func fracDist(x Double, h, k int64) Double {
+	return x.Sub(doubleFromInt64(h).Div(doubleFromInt64(k))).Abs()
+}
+
+// floorInt64 returns floor(x) and whether it fits into int64.
+func floorInt64(x Double) (int64, bool) {
+	f := math.Floor(x.hi)
+	if !(f >= -0x1p63 && f < 0x1p63) {
+		return 0, false
+	}
+	a := int64(f)
+	// For integral hi the floor depends on the sign of lo.
+	if f == x.hi && x.lo < 0 {
+		if a == math.MinInt64 {
+			return 0, false
+		}
+		a--
+	}
+	return a, true
+}
+
+// doubleFromInt64 returns v exactly in Double precision.
+func doubleFromInt64(v int64) Double {
+	high := v >> 32 << 32
+	return DoubleFromSum(float64(high), float64(v-high))
+}
+
+// Mediant returns the mediant (a+c)/(b+d) of the fractions a/b and c/d.
+// For positive denominators it lies strictly between distinct fractions.
+// For Farey neighbors it is the fraction with the smallest denominator between them,
+// the step of the Stern-Brocot tree.
+// It reports false if the result does not fit into int64.
+func Mediant(a, b, c, d int64) (num, den int64, ok bool) {
+	num, ok1 := addInt64(a, c)
+	den, ok2 := addInt64(b, d)
+	if !ok1 || !ok2 {
+		return 0, 0, false
+	}
+	return num, den, true
+}
+
+// CmpFrac returns -1 if a/b < c/d, 0 if a/b == c/d and +1 if a/b > c/d.
+// The products are computed in 128 bits, so it is exact for all int64 values.
+// It panics if b or d is not positive.
+func CmpFrac(a, b, c, d int64) int {
+	if b <= 0 || d <= 0 {
+		panic("mathx: denominator must be positive")
+	}
+	sa, sc := sign64(a), sign64(c)
+	switch {
+	case sa != sc:
+		if sa < sc {
+			return -1
+		}
+		return 1
+	case sa == 0:
+		return 0
+	}
+	var x, y Uint128
+	x.hi, x.lo = bits.Mul64(absInt64(a), uint64(d))
+	y.hi, y.lo = bits.Mul64(absInt64(c), uint64(b))
+	return sa * x.Cmp(y)
+}
+
+// FareyNeighbors returns the neighbors of num/den in the Farey sequence of order maxDen:
+// the closest fractions below and above it with denominators up to maxDen.
+// The fraction is reduced first. It reports false if a neighbor does not fit into int64.
+// It panics if den < 1 or the reduced den is above maxDen.
+func FareyNeighbors(num, den, maxDen int64) (lnum, lden, rnum, rden int64, ok bool) {
+	if den < 1 {
+		panic("mathx: denominator must be positive")
+	}
+	g := int64(Uint128FromUint64(absInt64(num)).GCD(Uint128FromUint64(uint64(den))).lo)
+	num, den = num/g, den/g
+	if den > maxDen {
+		panic("mathx: denominator must not be above the order")
+	}
+
+	// num/den = f + r/den with 0 <= r < den, neighbors of r/den are shifted by f.
+	f, r := num/den, num%den
+	if r < 0 {
+		f, r = f-1, r+den
+	}
+
+	// The neighbors p/q and n/m solve r*q - den*p = 1 and den*n - r*m = 1
+	// with the largest q, m <= maxDen.
+	q0 := modInverse64(r, den)
+	m0 := (den - q0) % den
+	q := q0 + (maxDen-q0)/den*den
+	m := m0 + (maxDen-m0)/den*den
+
+	p, n := int64(-1), int64(1) // for den == 1
+	if r != 0 {
+		hi, lo := bits.Mul64(uint64(r), uint64(q))
+		lo, borrow := bits.Sub64(lo, 1, 0)
+		pq, _ := bits.Div64(hi-borrow, lo, uint64(den))
+		hi, lo = bits.Mul64(uint64(r), uint64(m))
+		lo, carry := bits.Add64(lo, 1, 0)
+		nq, _ := bits.Div64(hi+carry, lo, uint64(den))
+		p, n = int64(pq), int64(nq)
+	}
+
+	fq, ok1 := mulInt64(f, q)
+	fm, ok2 := mulInt64(f, m)
+	lnum, ok3 := addInt64(p, fq)
+	rnum, ok4 := addInt64(n, fm)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return 0, 0, 0, 0, false
+	}
+	return lnum, q, rnum, m, true
+}
+
+// modInverse64 returns the inverse of a modulo m in [0, m) for coprime a and m > 0.
+func modInverse64(a, m int64) int64 {
+	if m == 1 {
+		return 0
+	}
+	t, nt := int64(0), int64(1)
+	r, nr := m, a
+	for nr != 0 {
+		k := r / nr
+		t, nt = nt, t-k*nt
+		r, nr = nr, r-k*nr
+	}
+	if t < 0 {
+		t += m
+	}
+	return t
+}
+
+func sign64(v int64) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+// absInt64 returns |v|, it is exact for math.MinInt64.
+func absInt64(v int64) uint64 {
+	u := uint64(v)
+	if v < 0 {
+		u = -u
+	}
+	return u
+}
+
+// addInt64 returns a + b and whether it fits into int64.
+func addInt64(a, b int64) (int64, bool) {
+	s := a + b
+	return s, (s > a) == (b > 0)
+}
+
+// mulInt64 returns a * b for b >= 0 and whether it fits into int64.
+func mulInt64(a, b int64) (int64, bool) {
+	hi, lo := bits.Mul64(absInt64(a), uint64(b))
+	switch {
+	case hi != 0:
+		return 0, false
+	case a < 0:
+		return int64(-lo), lo <= 1<<63
+	}
+	return int64(lo), lo <= math.MaxInt64
+}
@@ -0,0 +1,161 @@
+package mathx
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestContinuedFraction(t *testing.T) {
+	testCases := []struct {
+		x    float64
+		n    int
+		want []int64
+	}{
+		{0, 5, []int64{0}},
+		{3.25, 10, []int64{3, 4}},
+		{-2.5, 10, []int64{-3, 2}},
+		{math.Sqrt2, 8, []int64{1, 2, 2, 2, 2, 2, 2, 2}},
+		{math.Pi, 5, []int64{3, 7, 15, 1, 292}},
+		{math.Inf(1), 5, nil},
+		{math.NaN(), 5, nil},
+		{1e300, 5, nil},
+	}
+
+	for _, tc := range testCases {
+		if got := ContinuedFraction(nil, tc.x, tc.n); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("unexpected ContinuedFraction(%v); got %v; want %v", tc.x, got, tc.want)
+		}
+	}
+
+	// Double precision pi has many more correct terms.
+	want := []int64{3, 7, 15, 1, 292, 1, 1, 1, 2, 1, 3, 1, 14, 2, 1, 1, 2, 2, 2, 2, 1, 84, 2, 1, 1, 15, 3, 13}
+	if got := ContinuedFractionDouble(nil, DoublePi, len(want)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected ContinuedFractionDouble(pi); got %v; want %v", got, want)
+	}
+}
+
+func TestBestRational(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		maxDen   int64
+		num, den int64
+	}{
+		{math.Pi, 1, 3, 1},
+		{math.Pi, 7, 22, 7},
+		{math.Pi, 100, 311, 99},
+		{math.Pi, 1000, 355, 113},
+		{-math.Pi, 1000, -355, 113},
+		{0.333, 10, 1, 3},
+		{0.333, 1000, 333, 1000},
+		{0.1, 1 << 40, 1, 10},
+		{2.5, 1, 2, 1},
+		{0, 10, 0, 1},
+		{1e18, 1000, 1e18, 1},
+		{0x1p62 + 0.5, 3, 0x1p62, 1},
+		{math.NaN(), 10, 0, 0},
+		{-1e19, 10, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		if num, den := BestRational(tc.x, tc.maxDen); num != tc.num || den != tc.den {
+			t.Fatalf("unexpected BestRational(%v, %d); got %d/%d; want %d/%d", tc.x, tc.maxDen, num, den, tc.num, tc.den)
+		}
+	}
+
+	// sqrt(2) in Double precision to a large bound.
+	if num, den := BestRationalDouble(Sqrt2(DoubleFromFloat(2)), 1e15); num != 1023286908188737 || den != 723573111879672 {
+		t.Fatalf("unexpected BestRationalDouble(sqrt2); got %d/%d", num, den)
+	}
+}
+
+func TestMediant(t *testing.T) {
+	if num, den, ok := Mediant(1, 2, 2, 3); !ok || num != 3 || den != 5 {
+		t.Fatalf("unexpected mediant; got %v/%v, %v; want 3/5", num, den, ok)
+	}
+	if _, _, ok := Mediant(math.MaxInt64, 1, 1, 1); ok {
+		t.Fatal("mediant must overflow")
+	}
+	if _, _, ok := Mediant(1, math.MaxInt64, 1, 1); ok {
+		t.Fatal("mediant must overflow")
+	}
+}
+
+func TestCmpFrac(t *testing.T) {
+	nums := []int64{0, 1, -1, 3, math.MaxInt64, math.MinInt64, math.MaxInt64 - 1, math.MinInt64 + 1}
+	dens := []int64{1, 2, 3, math.MaxInt64, math.MaxInt64 - 1}
+	for _, a := range nums {
+		for _, b := range dens {
+			for _, c := range nums {
+				for _, d := range dens {
+					want := big.NewRat(a, b).Cmp(big.NewRat(c, d))
+					if got := CmpFrac(a, b, c, d); got != want {
+						t.Fatalf("unexpected CmpFrac(%v, %v, %v, %v); got %v; want %v", a, b, c, d, got, want)
+					}
+				}
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a, c := int64(rng.Uint64()), int64(rng.Uint64())
+		b, d := int64(rng.Uint64()>>1)|1, int64(rng.Uint64()>>1)|1
+		want := big.NewRat(a, b).Cmp(big.NewRat(c, d))
+		if got := CmpFrac(a, b, c, d); got != want {
+			t.Fatalf("unexpected CmpFrac(%v, %v, %v, %v); got %v; want %v", a, b, c, d, got, want)
+		}
+	}
+}
+
+func TestFareyNeighbors(t *testing.T) {
+	const order = 12
+	for den := int64(1); den <= order; den++ {
+		for num := -2 * den; num <= 2*den; num++ {
+			lnum, lden, rnum, rden, ok := FareyNeighbors(num, den, order)
+			if !ok {
+				t.Fatalf("unexpected overflow for %v/%v", num, den)
+			}
+			wl, wr := fareyNeighborsSlow(num, den, order)
+			if big.NewRat(lnum, lden).Cmp(wl) != 0 || big.NewRat(rnum, rden).Cmp(wr) != 0 {
+				t.Fatalf("unexpected neighbors of %v/%v; got %v/%v, %v/%v; want %v, %v", num, den, lnum, lden, rnum, rden, wl, wr)
+			}
+		}
+	}
+
+	// Price ticks of 1/64 around 1000003/64 with 16-bit denominators.
+	lnum, lden, rnum, rden, ok := FareyNeighbors(1000003, 64, 1<<16)
+	if !ok || CmpFrac(lnum, lden, 1000003, 64) >= 0 || CmpFrac(rnum, rden, 1000003, 64) <= 0 {
+		t.Fatalf("unexpected neighbors; got %v/%v, %v/%v, %v", lnum, lden, rnum, rden, ok)
+	}
+	for _, lr := range [][2]int64{{lnum, lden}, {rnum, rden}} {
+		det := new(big.Int).Sub(new(big.Int).Mul(big.NewInt(1000003), big.NewInt(lr[1])), new(big.Int).Mul(big.NewInt(lr[0]), big.NewInt(64)))
+		if det.CmpAbs(big.NewInt(1)) != 0 || lr[1] <= 1<<16-64 || lr[1] > 1<<16 {
+			t.Fatalf("unexpected neighbor %v/%v", lr[0], lr[1])
+		}
+	}
+
+	if _, _, _, _, ok := FareyNeighbors(math.MaxInt64, 1, 2); ok {
+		t.Fatal("neighbors must overflow")
+	}
+}
+
+// fareyNeighborsSlow returns the neighbors of num/den by checking every denominator.
+func fareyNeighborsSlow(num, den, order int64) (*big.Rat, *big.Rat) {
+	x := big.NewRat(num, den)
+	var lo, hi *big.Rat
+	for q := int64(1); q <= order; q++ {
+		for p := -3 * q; p <= 3*q; p++ {
+			f := big.NewRat(p, q)
+			switch c := f.Cmp(x); {
+			case c < 0 && (lo == nil || f.Cmp(lo) > 0):
+				lo = f
+			case c > 0 && (hi == nil || f.Cmp(hi) < 0):
+				hi = f
+			}
+		}
+	}
+	return lo, hi
+}
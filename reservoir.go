@@ -0,0 +1,136 @@
+package mathx
+
+import (
+	"math"
+
+	"github.com/valyala/fastrand"
+)
+
+// Reservoir keeps a uniform random sample of a stream of values.
+// It implements Vitter's algorithm R with a deterministic RNG.
+type Reservoir[T any] struct {
+	size  int
+	count uint64
+	seed  uint32
+
+	vals []T
+	rng  fastrand.RNG
+}
+
+// NewReservoir returns new Reservoir that keeps at most size samples.
+func NewReservoir[T any](size int) *Reservoir[T] {
+	if size <= 0 {
+		panic("mathx: reservoir size must be positive")
+	}
+	r := &Reservoir[T]{size: size, seed: newSeed()}
+	r.Reset()
+	return r
+}
+
+// Seed sets the seed of the reservoir RNG and resets the reservoir.
+func (r *Reservoir[T]) Seed(seed uint32) {
+	r.seed = seed
+	r.Reset()
+}
+
+// Reset resets the reservoir.
+func (r *Reservoir[T]) Reset() {
+	r.count = 0
+
+	if len(r.vals) > 0 {
+		r.vals = r.vals[:0]
+	} else {
+		// Free up memory occupied by unused reservoir.
+		r.vals = nil
+	}
+
+	// Reset rng state in order to get repeatable results
+	// for the same sequence of values passed to Reservoir.Add.
+	r.rng.Seed(rngSeed(r.seed))
+}
+
+// Add the value v to the reservoir.
+func (r *Reservoir[T]) Add(v T) {
+	r.count++
+	// Appending is unbiased only while the reservoir holds every value,
+	// a weighted Merge may leave fewer samples than the size.
+	if len(r.vals) < r.size && r.count == uint64(len(r.vals))+1 {
+		r.vals = append(r.vals, v)
+	} else {
+		n := int(r.rng.Uint32n(uint32(r.count)))
+		if n < len(r.vals) {
+			r.vals[n] = v
+		}
+	}
+}
+
+// Count returns the number of values added to the reservoir.
+func (r *Reservoir[T]) Count() uint64 { return r.count }
+
+// Size returns the maximum number of samples kept by the reservoir.
+func (r *Reservoir[T]) Size() int { return r.size }
+
+// Sample returns the current samples.
+// The returned slice is valid until the next call to Add, Merge or Reset.
+func (r *Reservoir[T]) Sample() []T { return r.vals }
+
+// Merge merges x into r.
+// The result is a sample of the combined stream where each source
+// is represented proportionally to the number of values it has seen.
+// It may keep fewer than Size samples when a source has too few for its share.
+func (r *Reservoir[T]) Merge(x *Reservoir[T]) {
+	if x.count == 0 {
+		return
+	}
+	exact := r.count == uint64(len(r.vals)) && x.count == uint64(len(x.vals))
+	if exact && len(r.vals)+len(x.vals) <= r.size {
+		r.vals = append(r.vals, x.vals...)
+		r.count += x.count
+		return
+	}
+
+	a := append([]T(nil), r.vals...)
+	b := append([]T(nil), x.vals...)
+	wa := weightOf(r.count, len(a))
+	wb := weightOf(x.count, len(b))
+
+	// Keep as many samples as both sources can provide in proportion
+	// to their counts, so a small source is not over-represented.
+	total := float64(r.count + x.count)
+	n := float64(r.size)
+	if r.count > 0 {
+		n = math.Min(n, float64(len(a))*total/float64(r.count))
+	}
+	n = math.Min(n, float64(len(b))*total/float64(x.count))
+	keep := int(math.Max(1, math.Round(n)))
+
+	r.vals = r.vals[:0]
+	for len(r.vals) < keep && len(a)+len(b) > 0 {
+		pa := float64(len(a)) * wa
+		pb := float64(len(b)) * wb
+		u := float64(r.rng.Uint32()) / (1 << 32)
+
+		if u*(pa+pb) < pa {
+			r.vals, a = r.pick(r.vals, a)
+		} else {
+			r.vals, b = r.pick(r.vals, b)
+		}
+	}
+	r.count += x.count
+}
+
+// pick moves a random element of src to dst.
+func (r *Reservoir[T]) pick(dst, src []T) ([]T, []T) {
+	i := int(r.rng.Uint32n(uint32(len(src))))
+	dst = append(dst, src[i])
+	last := len(src) - 1
+	src[i] = src[last]
+	return dst, src[:last]
+}
+
+func weightOf(count uint64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return float64(count) / float64(n)
+}
@@ -0,0 +1,103 @@
+package mathx
+
+import "testing"
+
+func TestReservoirUnderflow(t *testing.T) {
+	r := NewReservoir[string](10)
+	for _, s := range []string{"a", "b", "c"} {
+		r.Add(s)
+	}
+
+	got := r.Sample()
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("unexpected sample; got %v", got)
+	}
+	if r.Count() != 3 {
+		t.Fatalf("unexpected count; got %v; want %v", r.Count(), 3)
+	}
+}
+
+func TestReservoirRepeatableResults(t *testing.T) {
+	fill := func(seed uint32) []int {
+		r := NewReservoir[int](100)
+		r.Seed(seed)
+		for i := 0; i < 10000; i++ {
+			r.Add(i)
+		}
+		return append([]int(nil), r.Sample()...)
+	}
+
+	a, b := fill(42), fill(42)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("unexpected sample at %d; got %v; want %v", i, b[i], a[i])
+		}
+	}
+
+	c := fill(43)
+	same := true
+	for i := range a {
+		same = same && a[i] == c[i]
+	}
+	if same {
+		t.Fatal("different seeds must produce different samples")
+	}
+}
+
+func TestReservoirMerge(t *testing.T) {
+	a := NewReservoir[int](100)
+	b := NewReservoir[int](100)
+	for i := 0; i < 9000; i++ {
+		a.Add(0)
+	}
+	for i := 0; i < 1000; i++ {
+		b.Add(1)
+	}
+
+	a.Merge(b)
+	if a.Count() != 10000 {
+		t.Fatalf("unexpected count; got %v; want %v", a.Count(), 10000)
+	}
+	if len(a.Sample()) != 100 {
+		t.Fatalf("unexpected sample size; got %v; want %v", len(a.Sample()), 100)
+	}
+
+	ones := 0
+	for _, v := range a.Sample() {
+		ones += v
+	}
+	if ones < 2 || ones > 25 {
+		t.Fatalf("unexpected share of merged values; got %v; want about %v", ones, 10)
+	}
+}
+
+func TestReservoirMergeSaturated(t *testing.T) {
+	a := NewReservoir[int](100)
+	b := NewReservoir[int](10)
+	for i := 0; i < 10; i++ {
+		a.Add(0)
+	}
+	for i := 0; i < 1000000; i++ {
+		b.Add(1)
+	}
+
+	a.Merge(b)
+	if a.Count() != 1000010 {
+		t.Fatalf("unexpected count; got %v; want %v", a.Count(), 1000010)
+	}
+	ones := 0
+	for _, v := range a.Sample() {
+		ones += v
+	}
+	if n := len(a.Sample()); n != 10 || ones < 9 {
+		t.Fatalf("unexpected merged sample; got %v ones of %v; want about all of 10", ones, n)
+	}
+
+	// New values replace samples instead of growing a biased reservoir.
+	for i := 0; i < 1000; i++ {
+		a.Add(2)
+	}
+	if n := len(a.Sample()); n != 10 {
+		t.Fatalf("unexpected sample size after Add; got %v; want %v", n, 10)
+	}
+}
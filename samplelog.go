@@ -0,0 +1,96 @@
+package mathx
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// SampleLogWriter appends observed values to a compact binary stream.
+//
+// Each value is stored as a uvarint of the bit-reversed XOR with
+// the previous value. A repeated value takes 1 byte and values with short
+// mantissas, such as small integers, take 1-3 bytes. Close values with long
+// mantissas, such as most decimal fractions, differ in their low bits and take
+// up to 10 bytes. Values are stored exactly, a stream can be read back with SampleLogReader.
+type SampleLogWriter struct {
+	w    io.Writer
+	prev uint64
+	buf  []byte
+}
+
+// NewSampleLogWriter returns new SampleLogWriter writing to w.
+// Use a buffered writer for w to avoid a write call per value.
+func NewSampleLogWriter(w io.Writer) *SampleLogWriter {
+	return &SampleLogWriter{w: w, buf: make([]byte, 0, binary.MaxVarintLen64)}
+}
+
+// Observe is like Write but ignores the error.
+func (s *SampleLogWriter) Observe(v float64) { _ = s.Write(v) }
+
+// Write appends v to the stream.
+func (s *SampleLogWriter) Write(v float64) error {
+	b := math.Float64bits(v)
+	s.buf = s.buf[:binary.PutUvarint(s.buf[:binary.MaxVarintLen64], bits.Reverse64(b^s.prev))]
+	if _, err := s.w.Write(s.buf); err != nil {
+		return err
+	}
+	s.prev = b
+	return nil
+}
+
+// SampleLogReader reads values written by SampleLogWriter.
+type SampleLogReader struct {
+	r    io.ByteReader
+	prev uint64
+}
+
+// NewSampleLogReader returns new SampleLogReader reading from r.
+func NewSampleLogReader(r io.Reader) *SampleLogReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &SampleLogReader{r: br}
+}
+
+// Next returns the next value.
+// The error is io.EOF at the end of the stream, io.ErrUnexpectedEOF
+// if the stream is truncated and ErrInvalidEncoding if it is malformed.
+func (s *SampleLogReader) Next() (float64, error) {
+	var x uint64
+	for i := 0; ; i++ {
+		c, err := s.r.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		if i == binary.MaxVarintLen64-1 && c > 1 {
+			return 0, ErrInvalidEncoding
+		}
+		x |= uint64(c&0x7f) << (7 * i)
+		if c < 0x80 {
+			break
+		}
+	}
+	s.prev ^= bits.Reverse64(x)
+	return math.Float64frombits(s.prev), nil
+}
+
+// ReadAll appends all remaining values to dst.
+func (s *SampleLogReader) ReadAll(dst []float64) ([]float64, error) {
+	for {
+		v, err := s.Next()
+		if err == io.EOF {
+			return dst, nil
+		}
+		if err != nil {
+			return dst, err
+		}
+		dst = append(dst, v)
+	}
+}
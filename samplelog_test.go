@@ -0,0 +1,81 @@
+package mathx
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestSampleLog(t *testing.T) {
+	values := []float64{0, 1, 1, 2, 1000, 1001.5, -3, math.Inf(1), 1e-300, 0.1}
+
+	var buf bytes.Buffer
+	w := NewSampleLogWriter(&buf)
+	for _, v := range values {
+		if err := w.Write(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := NewSampleLogReader(&buf).ReadAll(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(values) {
+		t.Fatalf("unexpected length; got %v; want %v", len(got), len(values))
+	}
+	for i := range values {
+		if got[i] != values[i] {
+			t.Fatalf("unexpected value at %d; got %v; want %v", i, got[i], values[i])
+		}
+	}
+}
+
+func TestSampleLogCompact(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewSampleLogWriter(&buf)
+	for i := 0; i < 1000; i++ {
+		w.Observe(float64(100 + i%10))
+	}
+	if n := buf.Len(); n > 3000 {
+		t.Fatalf("unexpected size; got %v; want at most %v", n, 3000)
+	}
+
+	testCases := []struct {
+		prev, v float64
+		size    int
+	}{
+		{0.0123, 0.0123, 1},
+		{100, 101, 3},
+		{1.5, 1.25, 2},
+		{0.0123, 0.0124, 9},
+	}
+	for _, tc := range testCases {
+		buf.Reset()
+		w := NewSampleLogWriter(&buf)
+		w.Observe(tc.prev)
+		n := buf.Len()
+		w.Observe(tc.v)
+		if got := buf.Len() - n; got != tc.size {
+			t.Fatalf("unexpected size of %v after %v; got %v; want %v", tc.v, tc.prev, got, tc.size)
+		}
+	}
+}
+
+func TestSampleLogMalformed(t *testing.T) {
+	r := NewSampleLogReader(bytes.NewReader([]byte{0x80}))
+	if _, err := r.Next(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("unexpected error; got %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	r = NewSampleLogReader(bytes.NewReader(bytes.Repeat([]byte{0xff}, 11)))
+	if _, err := r.Next(); err != ErrInvalidEncoding {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrInvalidEncoding)
+	}
+
+	r = NewSampleLogReader(bytes.NewReader(nil))
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("unexpected error; got %v; want %v", err, io.EOF)
+	}
+}
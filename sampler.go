@@ -0,0 +1,44 @@
+package mathx
+
+// Sampler decides whether to record a request based on the percentile
+// of its value within a Sample, for tail-based sampling:
+// values at or above the phi quantile are sampled.
+//
+// Computing a quantile is expensive, so the threshold is refreshed
+// every refresh observed values. Sampler is not safe for concurrent use.
+type Sampler struct {
+	s       Sample
+	phi     float64
+	refresh int
+
+	n         int
+	threshold float64
+}
+
+// NewSampler returns new Sampler which samples values at or above the phi quantile of s.
+// It panics if phi is not in [0, 1] or refresh is not positive.
+func NewSampler(s Sample, phi float64, refresh int) *Sampler {
+	if !(phi >= 0 && phi <= 1) {
+		panic("mathx: sampler phi must be in range [0, 1]")
+	}
+	if refresh <= 0 {
+		panic("mathx: sampler refresh must be positive")
+	}
+	return &Sampler{s: s, phi: phi, refresh: refresh, threshold: NaN}
+}
+
+// Threshold returns the current threshold, NaN before the first value.
+func (s *Sampler) Threshold() float64 { return s.threshold }
+
+// Sample observes v and reports whether it must be sampled.
+func (s *Sampler) Sample(v float64) bool {
+	s.s.Observe(v)
+	if s.n++; s.n >= s.refresh || s.threshold != s.threshold {
+		s.n = 0
+		s.threshold = s.s.Quantile(s.phi)
+	}
+	return v >= s.threshold
+}
+
+// Observe is like Sample without the decision.
+func (s *Sampler) Observe(v float64) { s.Sample(v) }
@@ -0,0 +1,29 @@
+package mathx
+
+import "testing"
+
+func TestSampler(t *testing.T) {
+	s := NewSampler(NewHistogram(), 0.99, 100)
+	if !s.Sample(1) || s.Threshold() != 1 {
+		t.Fatalf("first value must be sampled; threshold %v", s.Threshold())
+	}
+
+	var sampled int
+	for i := 0; i < 100000; i++ {
+		if s.Sample(float64(i*7919%100000) / 100) {
+			sampled++
+		}
+	}
+	if sampled < 500 || sampled > 2500 {
+		t.Fatalf("unexpected number of sampled values; got %v; want about 1%% of %v", sampled, 100000)
+	}
+	if th := s.Threshold(); th < 970 || th > 999 {
+		t.Fatalf("unexpected threshold; got %v; want about 990", th)
+	}
+	if !s.Sample(10000) {
+		t.Fatal("tail value must be sampled")
+	}
+	if s.Sample(0) {
+		t.Fatal("head value must not be sampled")
+	}
+}
@@ -0,0 +1,32 @@
+package mathx
+
+import "sync/atomic"
+
+// defaultSeed is the seed of RNGs in newly created values, accessed atomically.
+var defaultSeed uint64 = 1
+
+// WithDeterministicSeed sets the seed used by RNG-based types created afterwards:
+// Histogram, Reservoir and others. Every new instance starts from the same seed,
+// so a run that creates and feeds them in the same order is exactly reproducible.
+// The default seed is 1.
+//
+// It is safe to call concurrently, but it is meant to be called once
+// at the start of a program or a test.
+func WithDeterministicSeed(seed uint64) {
+	atomic.StoreUint64(&defaultSeed, seed)
+}
+
+// newSeed returns the 32-bit seed for a new RNG.
+func newSeed() uint32 {
+	s := atomic.LoadUint64(&defaultSeed)
+	return uint32(s) ^ uint32(s>>32)
+}
+
+// rngSeed maps seed to a valid RNG state:
+// fastrand.RNG treats zero state as a request for a time-based seed.
+func rngSeed(seed uint32) uint32 {
+	if seed == 0 {
+		return 0x9e3779b9
+	}
+	return seed
+}
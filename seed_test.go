@@ -0,0 +1,46 @@
+package mathx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWithDeterministicSeed(t *testing.T) {
+	defer WithDeterministicSeed(1)
+
+	sample := func(seed uint64) []float64 {
+		WithDeterministicSeed(seed)
+		h := NewHistogram()
+		for i := 0; i < 5000; i++ {
+			h.Update(float64(i))
+		}
+		return append([]float64(nil), h.res.Sample()...)
+	}
+
+	a, b, c := sample(42), sample(42), sample(43)
+	if !reflect.DeepEqual(a, b) {
+		t.Fatal("same seed must give the same sample")
+	}
+	if reflect.DeepEqual(a, c) {
+		t.Fatal("different seeds must give different samples")
+	}
+
+	WithDeterministicSeed(7)
+	if r := NewReservoir[int](10); r.seed != 7 {
+		t.Fatalf("unexpected reservoir seed; got %v; want %v", r.seed, 7)
+	}
+}
+
+func TestReservoirZeroSeed(t *testing.T) {
+	sample := func() []int {
+		r := NewReservoir[int](10)
+		r.Seed(0)
+		for i := 0; i < 1000; i++ {
+			r.Add(i)
+		}
+		return append([]int(nil), r.Sample()...)
+	}
+	if a, b := sample(), sample(); !reflect.DeepEqual(a, b) {
+		t.Fatalf("zero seed must be deterministic; got %v and %v", a, b)
+	}
+}
@@ -0,0 +1,35 @@
+package mathx
+
+import "sort"
+
+// CompareUint128 returns -1, 0 or +1 comparing a to b.
+// It can be used with slices.SortFunc and slices.BinarySearchFunc.
+func CompareUint128(a, b Uint128) int { return a.Cmp(b) }
+
+// CompareUint256 returns -1, 0 or +1 comparing a to b.
+// It can be used with slices.SortFunc and slices.BinarySearchFunc.
+func CompareUint256(a, b Uint256) int { return a.Cmp(b) }
+
+// SortUint128s sorts a slice of Uint128 in increasing order.
+func SortUint128s(a []Uint128) {
+	sort.Slice(a, func(i, j int) bool { return a[i].Cmp(a[j]) < 0 })
+}
+
+// SortUint256s sorts a slice of Uint256 in increasing order.
+func SortUint256s(a []Uint256) {
+	sort.Slice(a, func(i, j int) bool { return a[i].Cmp(a[j]) < 0 })
+}
+
+// SearchUint128s searches for x in a sorted slice of Uint128 and returns the index
+// as specified by sort.Search. The return value is the index to insert x
+// if x is not present (it could be len(a)).
+func SearchUint128s(a []Uint128, x Uint128) int {
+	return sort.Search(len(a), func(i int) bool { return a[i].Cmp(x) >= 0 })
+}
+
+// SearchUint256s searches for x in a sorted slice of Uint256 and returns the index
+// as specified by sort.Search. The return value is the index to insert x
+// if x is not present (it could be len(a)).
+func SearchUint256s(a []Uint256, x Uint256) int {
+	return sort.Search(len(a), func(i int) bool { return a[i].Cmp(x) >= 0 })
+}
@@ -0,0 +1,52 @@
+package mathx
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSortUint128s(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := make([]Uint128, 1000)
+	for i := range a {
+		a[i] = NewUint128(rng.Uint64()%4, rng.Uint64())
+	}
+
+	SortUint128s(a)
+	for i := 1; i < len(a); i++ {
+		if CompareUint128(a[i-1], a[i]) > 0 {
+			t.Fatalf("slice is not sorted at %d: %v > %v", i, a[i-1], a[i])
+		}
+	}
+
+	for _, i := range []int{0, 500, 999} {
+		if got := SearchUint128s(a, a[i]); a[got] != a[i] {
+			t.Fatalf("unexpected search result for %v; got index %d", a[i], got)
+		}
+	}
+	if got := SearchUint128s(a, NewUint128(4, 0)); got != len(a) {
+		t.Fatalf("unexpected search result for missing value; got %d; want %d", got, len(a))
+	}
+}
+
+func TestSortUint256s(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	a := make([]Uint256, 1000)
+	for i := range a {
+		a[i] = NewUint256(NewUint128(0, rng.Uint64()%4), NewUint128(rng.Uint64(), rng.Uint64()))
+	}
+
+	SortUint256s(a)
+	for i := 1; i < len(a); i++ {
+		if CompareUint256(a[i-1], a[i]) > 0 {
+			t.Fatalf("slice is not sorted at %d: %v > %v", i, a[i-1], a[i])
+		}
+	}
+
+	if got := SearchUint256s(a, a[10]); a[got] != a[10] {
+		t.Fatalf("unexpected search result for %v; got index %d", a[10], got)
+	}
+	if got := SearchUint256s(a, Uint256{}); got != 0 {
+		t.Fatalf("unexpected search result for zero; got %d; want %d", got, 0)
+	}
+}
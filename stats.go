@@ -0,0 +1,60 @@
+package mathx
+
+import "math"
+
+// Normalize appends min-max normalized values of xs to dst.
+// Each value is mapped to [0, 1] as (x - min) / (max - min).
+// When xs has a degenerate range (max == min) all values are mapped to 0.
+func Normalize(dst, xs []float64) []float64 {
+	if len(xs) == 0 {
+		return dst
+	}
+
+	min, max := MinMaxFloat64s(xs)
+	span := max - min
+	if span == 0 {
+		for range xs {
+			dst = append(dst, 0)
+		}
+		return dst
+	}
+
+	for _, x := range xs {
+		dst = append(dst, (x-min)/span)
+	}
+	return dst
+}
+
+// Standardize appends z-scores of xs to dst.
+// Each value is mapped to (x - mean) / stddev using the population standard deviation.
+// When xs has zero variance all values are mapped to 0.
+func Standardize(dst, xs []float64) []float64 {
+	if len(xs) == 0 {
+		return dst
+	}
+
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+
+	var sq float64
+	for _, x := range xs {
+		d := x - mean
+		sq += d * d
+	}
+	stddev := math.Sqrt(sq / float64(len(xs)))
+
+	if stddev == 0 {
+		for range xs {
+			dst = append(dst, 0)
+		}
+		return dst
+	}
+
+	for _, x := range xs {
+		dst = append(dst, (x-mean)/stddev)
+	}
+	return dst
+}
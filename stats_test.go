@@ -0,0 +1,42 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	got := Normalize(nil, []float64{2, 4, 6, 10})
+	want := []float64{0, 0.25, 0.5, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unexpected value at %d; got %v; want %v", i, got[i], want[i])
+		}
+	}
+
+	got = Normalize(got[:0], []float64{3, 3, 3})
+	for i, v := range got {
+		if v != 0 {
+			t.Fatalf("unexpected value for degenerate range at %d; got %v; want %v", i, v, 0)
+		}
+	}
+
+	if got := Normalize(nil, nil); len(got) != 0 {
+		t.Fatalf("unexpected result for empty input; got %v", got)
+	}
+}
+
+func TestStandardize(t *testing.T) {
+	got := Standardize(nil, []float64{2, 4, 4, 4, 5, 5, 7, 9})
+	want := []float64{-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-12 {
+			t.Fatalf("unexpected value at %d; got %v; want %v", i, got[i], want[i])
+		}
+	}
+
+	got = Standardize(got[:0], []float64{1.5})
+	if len(got) != 1 || got[0] != 0 {
+		t.Fatalf("unexpected result for zero variance; got %v", got)
+	}
+}
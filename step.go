@@ -0,0 +1,48 @@
+package mathx
+
+// Clamp01 returns x clamped to [0, 1].
+// NaN is returned as is.
+func Clamp01[T Float](x T) T {
+	switch {
+	case x < 0:
+		return 0
+	case x > 1:
+		return 1
+	default:
+		return x
+	}
+}
+
+// Saturate is an alias for Clamp01.
+func Saturate[T Float](x T) T { return Clamp01(x) }
+
+// Step returns 0 if x < edge and 1 otherwise.
+// NaN in any argument gives 1, like !(x < edge).
+func Step[T Float](edge, x T) T {
+	if x < edge {
+		return 0
+	}
+	return 1
+}
+
+// SmoothStep returns the Hermite interpolation 3t² - 2t³
+// of t = (x-edge0)/(edge1-edge0) clamped to [0, 1].
+// When edge0 == edge1 it is Step(edge0, x).
+func SmoothStep[T Float](edge0, edge1, x T) T {
+	if edge0 == edge1 {
+		return Step(edge0, x)
+	}
+	t := Clamp01((x - edge0) / (edge1 - edge0))
+	return t * t * (3 - 2*t)
+}
+
+// SmootherStep returns Perlin's interpolation 6t⁵ - 15t⁴ + 10t³
+// of t = (x-edge0)/(edge1-edge0) clamped to [0, 1].
+// When edge0 == edge1 it is Step(edge0, x).
+func SmootherStep[T Float](edge0, edge1, x T) T {
+	if edge0 == edge1 {
+		return Step(edge0, x)
+	}
+	t := Clamp01((x - edge0) / (edge1 - edge0))
+	return t * t * t * (t*(6*t-15) + 10)
+}
@@ -0,0 +1,58 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClamp01(t *testing.T) {
+	testCases := []struct {
+		x, want float64
+	}{
+		{-1, 0}, {0, 0}, {0.25, 0.25}, {1, 1}, {2, 1},
+		{InfNeg, 0}, {InfPos, 1},
+	}
+	for _, tc := range testCases {
+		if got := Clamp01(tc.x); got != tc.want {
+			t.Fatalf("unexpected Clamp01(%v); got %v; want %v", tc.x, got, tc.want)
+		}
+	}
+	if got := Saturate(NaN); !math.IsNaN(got) {
+		t.Fatalf("unexpected Saturate(NaN); got %v", got)
+	}
+	if got := Clamp01(float32(1.5)); got != 1 {
+		t.Fatalf("unexpected Clamp01(float32); got %v", got)
+	}
+}
+
+func TestSmoothStep(t *testing.T) {
+	testCases := []struct {
+		e0, e1, x     float64
+		smooth, other float64
+	}{
+		{0, 1, -1, 0, 0},
+		{0, 1, 0, 0, 0},
+		{0, 1, 0.5, 0.5, 0.5},
+		{0, 1, 1, 1, 1},
+		{0, 1, 2, 1, 1},
+		{0, 2, 0.5, 0.15625, 0.103515625},
+		{1, 0, 0.25, 0.84375, 0.896484375},
+		{1, 1, 0.5, 0, 0},
+		{1, 1, 1, 1, 1},
+	}
+	for _, tc := range testCases {
+		if got := SmoothStep(tc.e0, tc.e1, tc.x); got != tc.smooth {
+			t.Fatalf("unexpected SmoothStep(%v, %v, %v); got %v; want %v", tc.e0, tc.e1, tc.x, got, tc.smooth)
+		}
+		if got := SmootherStep(tc.e0, tc.e1, tc.x); got != tc.other {
+			t.Fatalf("unexpected SmootherStep(%v, %v, %v); got %v; want %v", tc.e0, tc.e1, tc.x, got, tc.other)
+		}
+	}
+
+	if got := Step(0.5, 0.4); got != 0 {
+		t.Fatalf("unexpected Step; got %v; want %v", got, 0)
+	}
+	if got := Step(0.5, 0.5); got != 1 {
+		t.Fatalf("unexpected Step; got %v; want %v", got, 1)
+	}
+}
@@ -0,0 +1,64 @@
+package mathx
+
+import "sync"
+
+// SummaryReport is a snapshot of a Summary.
+// Quantiles, Mean, Min and Max are NaN for an empty summary.
+type SummaryReport struct {
+	Count               uint64
+	Sum, Mean, Min, Max float64
+	P50, P90, P99, P999 float64
+}
+
+// Summary bundles the count, sum and quantiles of observed values.
+// Quantiles are estimated by a Histogram, the count is exact
+// and the sum is compensated, see Histogram.Sum.
+// Summary is safe for concurrent use.
+type Summary struct {
+	mu  sync.Mutex
+	h   *Histogram
+	tmp []float64
+}
+
+// NewSummary returns new Summary.
+func NewSummary() *Summary {
+	return &Summary{h: NewHistogram()}
+}
+
+// Observe adds v to the summary.
+func (s *Summary) Observe(v float64) {
+	s.mu.Lock()
+	s.h.Update(v)
+	s.mu.Unlock()
+}
+
+// Quantile returns the quantile value for the given phi.
+func (s *Summary) Quantile(phi float64) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Quantile(phi)
+}
+
+// Reset resets the summary.
+func (s *Summary) Reset() {
+	s.mu.Lock()
+	s.h.Reset()
+	s.mu.Unlock()
+}
+
+var summaryPhis = []float64{0, 1, 0.5, 0.9, 0.99, 0.999}
+
+// Report returns a snapshot of the summary.
+func (s *Summary) Report() SummaryReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := SummaryReport{Count: s.h.Count(), Sum: s.h.Sum(), Mean: NaN}
+	if r.Count > 0 {
+		r.Mean = r.Sum / float64(r.Count)
+	}
+	s.tmp = s.h.Quantiles(s.tmp[:0], summaryPhis)
+	q := s.tmp
+	r.Min, r.Max, r.P50, r.P90, r.P99, r.P999 = q[0], q[1], q[2], q[3], q[4], q[5]
+	return r
+}
@@ -0,0 +1,44 @@
+package mathx
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	s := NewSummary()
+	r := s.Report()
+	if r.Count != 0 || r.Sum != 0 || !math.IsNaN(r.Mean) || !math.IsNaN(r.P50) || !math.IsNaN(r.Min) {
+		t.Fatalf("unexpected empty report; got %+v", r)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < 1000; i += 4 {
+				s.Observe(float64(i + 1))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	r = s.Report()
+	if r.Count != 1000 || r.Sum != 500500 || r.Mean != 500.5 || r.Min != 1 || r.Max != 1000 {
+		t.Fatalf("unexpected report; got %+v", r)
+	}
+	if r.P50 != 501 || r.P90 != 900 || r.P99 != 990 || r.P999 != 999 {
+		t.Fatalf("unexpected quantiles; got %+v", r)
+	}
+	if q := s.Quantile(0.5); q != r.P50 {
+		t.Fatalf("unexpected Quantile; got %v; want %v", q, r.P50)
+	}
+
+	var _ Sample = s
+	s.Reset()
+	if r := s.Report(); r.Count != 0 || r.Sum != 0 {
+		t.Fatalf("unexpected report after Reset; got %+v", r)
+	}
+}
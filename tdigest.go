@@ -0,0 +1,327 @@
+package mathx
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// Centroid of a TDigest.
+type Centroid struct {
+	Mean   float64
+	Weight float64
+}
+
+// TDigest is a merging t-digest for quantile estimation.
+// Larger compression gives more centroids and better accuracy.
+//
+// See: Dunning, T., Ertl, O. Computing Extremely Accurate Quantiles Using t-Digests. https://arxiv.org/abs/1902.04023
+type TDigest struct {
+	compression float64
+	min, max    float64
+	total       float64
+	centroids   []Centroid // sorted by mean
+	buf         []Centroid // unmerged
+}
+
+// NewTDigest returns new TDigest with the given compression, 100 is a common choice.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression >= 1) {
+		panic("mathx: tdigest compression must be at least 1")
+	}
+	t := &TDigest{compression: compression}
+	t.Reset()
+	return t
+}
+
+// Compression of the digest.
+func (t *TDigest) Compression() float64 { return t.compression }
+
+// Count returns the total weight of added values.
+func (t *TDigest) Count() float64 { return t.total }
+
+// Reset resets the digest.
+func (t *TDigest) Reset() {
+	t.min, t.max = InfPos, InfNeg
+	t.total = 0
+	t.centroids = t.centroids[:0]
+	t.buf = t.buf[:0]
+}
+
+// Add the value x with weight 1.
+func (t *TDigest) Add(x float64) { t.AddWeighted(x, 1) }
+
+// AddWeighted adds the value x with weight w.
+// NaN values and non-positive weights are ignored.
+func (t *TDigest) AddWeighted(x, w float64) {
+	if math.IsNaN(x) || !(w > 0) {
+		return
+	}
+	if x < t.min {
+		t.min = x
+	}
+	if x > t.max {
+		t.max = x
+	}
+	t.total += w
+	t.buf = append(t.buf, Centroid{Mean: x, Weight: w})
+	if len(t.buf) >= 4*int(t.compression)+16 {
+		t.compress()
+	}
+}
+
+// Merge adds all values of x into t.
+func (t *TDigest) Merge(x *TDigest) {
+	x.compress()
+	if x.total == 0 {
+		return
+	}
+	if x.min < t.min {
+		t.min = x.min
+	}
+	if x.max > t.max {
+		t.max = x.max
+	}
+	t.total += x.total
+	t.buf = append(t.buf, x.centroids...)
+	t.compress()
+}
+
+// Centroids appends the centroids sorted by mean to dst.
+func (t *TDigest) Centroids(dst []Centroid) []Centroid {
+	t.compress()
+	return append(dst, t.centroids...)
+}
+
+// Quantile returns the estimated quantile value for the given phi.
+// It returns NaN if the digest is empty.
+func (t *TDigest) Quantile(phi float64) float64 {
+	t.compress()
+	cs := t.centroids
+	switch {
+	case len(cs) == 0 || math.IsNaN(phi):
+		return NaN
+	case phi <= 0:
+		return t.min
+	case phi >= 1:
+		return t.max
+	case len(cs) == 1:
+		return cs[0].Mean
+	}
+
+	// Interpolate between centroid centers, and between
+	// the extremes and the first and last centroids.
+	index := phi * t.total
+	if half := cs[0].Weight / 2; index < half {
+		return t.min + index/half*(cs[0].Mean-t.min)
+	}
+	cum := cs[0].Weight / 2
+	for i := 0; i < len(cs)-1; i++ {
+		dw := (cs[i].Weight + cs[i+1].Weight) / 2
+		if cum+dw > index {
+			return cs[i].Mean + (index-cum)/dw*(cs[i+1].Mean-cs[i].Mean)
+		}
+		cum += dw
+	}
+	last := cs[len(cs)-1]
+	return last.Mean + math.Min((index-cum)/(last.Weight/2), 1)*(t.max-last.Mean)
+}
+
+// compress merges buffered values into centroids using the k1 scale function.
+func (t *TDigest) compress() {
+	if len(t.buf) == 0 {
+		return
+	}
+	all := append(t.buf, t.centroids...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Mean < all[j].Mean })
+
+	out := t.centroids[:0]
+	cur := all[0]
+	var wSoFar float64
+	limit := t.total * t.kInv(t.k(0)+1)
+	for _, c := range all[1:] {
+		if wSoFar+cur.Weight+c.Weight <= limit {
+			cur.Weight += c.Weight
+			cur.Mean += (c.Mean - cur.Mean) * c.Weight / cur.Weight
+			continue
+		}
+		wSoFar += cur.Weight
+		out = append(out, cur)
+		cur = c
+		limit = t.total * t.kInv(t.k(wSoFar/t.total)+1)
+	}
+	t.centroids = append(out, cur)
+	t.buf = all[:0]
+}
+
+func (t *TDigest) k(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+func (t *TDigest) kInv(k float64) float64 {
+	if k >= t.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/t.compression) + 1) / 2
+}
+
+// Encoding codes used by the t-digest reference implementation.
+const (
+	tdigestVerbose = 1
+	tdigestSmall   = 2
+)
+
+// AppendMergingDigest appends t in the verbose MergingDigest.asBytes layout
+// of the t-digest reference implementation to dst.
+func (t *TDigest) AppendMergingDigest(dst []byte) []byte {
+	t.compress()
+	dst = appendUint32BE(dst, tdigestVerbose)
+	dst = appendFloat64BE(dst, t.min)
+	dst = appendFloat64BE(dst, t.max)
+	dst = appendFloat64BE(dst, t.compression)
+	dst = appendUint32BE(dst, uint32(len(t.centroids)))
+	for _, c := range t.centroids {
+		dst = appendFloat64BE(dst, c.Weight)
+		dst = appendFloat64BE(dst, c.Mean)
+	}
+	return dst
+}
+
+// AppendMergingDigestSmall appends t in the small MergingDigest.asSmallBytes layout
+// of the t-digest reference implementation to dst. Means and weights are stored as float32.
+func (t *TDigest) AppendMergingDigestSmall(dst []byte) []byte {
+	t.compress()
+	n := len(t.centroids)
+	size := 2 * int(math.Ceil(t.compression))
+	if size < n {
+		size = n
+	}
+	dst = appendUint32BE(dst, tdigestSmall)
+	dst = appendFloat64BE(dst, t.min)
+	dst = appendFloat64BE(dst, t.max)
+	dst = appendUint32BE(dst, math.Float32bits(float32(t.compression)))
+	dst = append(dst, byte(size>>8), byte(size))
+	dst = append(dst, byte(5*size>>8), byte(5*size))
+	dst = append(dst, byte(n>>8), byte(n))
+	for _, c := range t.centroids {
+		dst = appendUint32BE(dst, math.Float32bits(float32(c.Weight)))
+		dst = appendUint32BE(dst, math.Float32bits(float32(c.Mean)))
+	}
+	return dst
+}
+
+// AppendAVLTreeDigest appends t in the verbose AVLTreeDigest.asBytes layout
+// of the t-digest reference implementation to dst. Weights are rounded to integers.
+func (t *TDigest) AppendAVLTreeDigest(dst []byte) []byte {
+	t.compress()
+	dst = appendUint32BE(dst, tdigestVerbose)
+	dst = appendFloat64BE(dst, t.min)
+	dst = appendFloat64BE(dst, t.max)
+	dst = appendFloat64BE(dst, t.compression)
+	dst = appendUint32BE(dst, uint32(len(t.centroids)))
+	for _, c := range t.centroids {
+		dst = appendFloat64BE(dst, c.Mean)
+	}
+	for _, c := range t.centroids {
+		dst = appendUint32BE(dst, uint32(math.Round(c.Weight)))
+	}
+	return dst
+}
+
+// ParseMergingDigest decodes a digest in the verbose or small MergingDigest layout.
+func ParseMergingDigest(b []byte) (*TDigest, error) {
+	if len(b) < 4 {
+		return nil, ErrInvalidEncoding
+	}
+	hdr, code := b, binary.BigEndian.Uint32(b)
+
+	var t *TDigest
+	var n int
+	switch {
+	case code == tdigestVerbose && len(b) >= 32:
+		t = &TDigest{compression: float64frombitsBE(b[20:])}
+		n = int(binary.BigEndian.Uint32(b[28:]))
+		b = b[32:]
+		if n < 0 || len(b) != 16*n {
+			return nil, ErrInvalidEncoding
+		}
+	case code == tdigestSmall && len(b) >= 30:
+		t = &TDigest{compression: float64(math.Float32frombits(binary.BigEndian.Uint32(b[20:])))}
+		n = int(binary.BigEndian.Uint16(b[28:]))
+		b = b[30:]
+		if len(b) != 8*n {
+			return nil, ErrInvalidEncoding
+		}
+	default:
+		return nil, ErrInvalidEncoding
+	}
+
+	cs := make([]Centroid, n)
+	for i := range cs {
+		if code == tdigestVerbose {
+			cs[i] = Centroid{Weight: float64frombitsBE(b[16*i:]), Mean: float64frombitsBE(b[16*i+8:])}
+		} else {
+			cs[i] = Centroid{
+				Weight: float64(math.Float32frombits(binary.BigEndian.Uint32(b[8*i:]))),
+				Mean:   float64(math.Float32frombits(binary.BigEndian.Uint32(b[8*i+4:]))),
+			}
+		}
+	}
+	return t.init(hdr, cs)
+}
+
+// ParseAVLTreeDigest decodes a digest in the verbose AVLTreeDigest layout.
+func ParseAVLTreeDigest(b []byte) (*TDigest, error) {
+	if len(b) < 32 || binary.BigEndian.Uint32(b) != tdigestVerbose {
+		return nil, ErrInvalidEncoding
+	}
+	t := &TDigest{compression: float64frombitsBE(b[20:])}
+	n := int(binary.BigEndian.Uint32(b[28:]))
+	data := b[32:]
+	if n < 0 || len(data) != 12*n {
+		return nil, ErrInvalidEncoding
+	}
+
+	cs := make([]Centroid, n)
+	for i := range cs {
+		cs[i] = Centroid{
+			Mean:   float64frombitsBE(data[8*i:]),
+			Weight: float64(binary.BigEndian.Uint32(data[8*n+4*i:])),
+		}
+	}
+	return t.init(b, cs)
+}
+
+// init finishes decoding with the min and max from the header hdr and the centroids.
+func (t *TDigest) init(hdr []byte, cs []Centroid) (*TDigest, error) {
+	if !(t.compression >= 1) {
+		return nil, ErrInvalidEncoding
+	}
+	t.Reset()
+	for _, c := range cs {
+		if math.IsNaN(c.Mean) || !(c.Weight > 0) {
+			return nil, ErrInvalidEncoding
+		}
+		t.total += c.Weight
+		t.buf = append(t.buf, c)
+	}
+	if len(cs) > 0 {
+		t.min, t.max = float64frombitsBE(hdr[4:]), float64frombitsBE(hdr[12:])
+	}
+	t.compress()
+	return t, nil
+}
+
+func appendUint32BE(dst []byte, v uint32) []byte {
+	return append(dst, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendFloat64BE(dst []byte, f float64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], math.Float64bits(f))
+	return append(dst, b[:]...)
+}
+
+func float64frombitsBE(b []byte) float64 {
+	return math.Float64frombits(binary.BigEndian.Uint64(b))
+}
@@ -0,0 +1,103 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTDigest(t *testing.T) {
+	td := NewTDigest(100)
+	for i := 0; i < 10000; i++ {
+		td.Add(float64((i * 7919) % 10000))
+	}
+
+	if got := td.Count(); got != 10000 {
+		t.Fatalf("unexpected count; got %v; want %v", got, 10000)
+	}
+	for _, phi := range []float64{0.01, 0.1, 0.5, 0.9, 0.99, 0.999} {
+		got, want := td.Quantile(phi), phi*10000
+		if math.Abs(got-want) > 0.005*10000 {
+			t.Fatalf("unexpected quantile %v; got %v; want %v", phi, got, want)
+		}
+	}
+	if got := td.Quantile(0); got != 0 {
+		t.Fatalf("unexpected min; got %v; want %v", got, 0)
+	}
+	if got := td.Quantile(1); got != 9999 {
+		t.Fatalf("unexpected max; got %v; want %v", got, 9999)
+	}
+	if n := len(td.Centroids(nil)); n > 200 {
+		t.Fatalf("too many centroids: %v", n)
+	}
+	if got := NewTDigest(100).Quantile(0.5); !math.IsNaN(got) {
+		t.Fatalf("unexpected quantile of empty digest; got %v", got)
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	a, b := NewTDigest(100), NewTDigest(100)
+	for i := 0; i < 5000; i++ {
+		a.Add(float64(i))
+		b.Add(float64(i + 5000))
+	}
+	a.Merge(b)
+
+	if got := a.Count(); got != 10000 {
+		t.Fatalf("unexpected count; got %v; want %v", got, 10000)
+	}
+	if got := a.Quantile(0.5); math.Abs(got-5000) > 50 {
+		t.Fatalf("unexpected median; got %v; want %v", got, 5000)
+	}
+	if got := a.Quantile(1); got != 9999 {
+		t.Fatalf("unexpected max; got %v; want %v", got, 9999)
+	}
+}
+
+func TestTDigestCodecs(t *testing.T) {
+	td := NewTDigest(50)
+	for i := 0; i < 1000; i++ {
+		td.Add(float64(i))
+	}
+	want := td.Centroids(nil)
+
+	testCases := []struct {
+		name   string
+		data   []byte
+		parse  func([]byte) (*TDigest, error)
+		approx bool
+	}{
+		{"merging", td.AppendMergingDigest(nil), ParseMergingDigest, false},
+		{"small", td.AppendMergingDigestSmall(nil), ParseMergingDigest, true},
+		{"avltree", td.AppendAVLTreeDigest(nil), ParseAVLTreeDigest, true},
+	}
+	for _, tc := range testCases {
+		got, err := tc.parse(tc.data)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got.Compression() != 50 || got.Quantile(0) != 0 || got.Quantile(1) != 999 {
+			t.Fatalf("%s: unexpected header", tc.name)
+		}
+		if math.Abs(got.Count()-1000) > 1 {
+			t.Fatalf("%s: unexpected count; got %v; want %v", tc.name, got.Count(), 1000)
+		}
+		if q := got.Quantile(0.5); math.Abs(q-td.Quantile(0.5)) > 1 {
+			t.Fatalf("%s: unexpected median; got %v; want %v", tc.name, q, td.Quantile(0.5))
+		}
+
+		cs := got.Centroids(nil)
+		if !tc.approx && len(cs) != len(want) {
+			t.Fatalf("%s: unexpected centroids; got %v; want %v", tc.name, len(cs), len(want))
+		}
+
+		if _, err := tc.parse(tc.data[:len(tc.data)-1]); err != ErrInvalidEncoding {
+			t.Fatalf("%s: unexpected error; got %v; want %v", tc.name, err, ErrInvalidEncoding)
+		}
+	}
+
+	// Header of MergingDigest verbose encoding: code, min, max, compression, size.
+	b := td.AppendMergingDigest(nil)
+	if len(b) != 32+16*len(want) || b[3] != 1 {
+		t.Fatalf("unexpected layout: % x", b[:32])
+	}
+}
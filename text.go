@@ -0,0 +1,218 @@
+package mathx
+
+import "strings"
+
+const textDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// SetString sets u to the value of s in the given base and returns u and a success flag.
+// Like math/big, base must be 0 or in [2, 62]. For base 0 the prefix selects the base:
+// 0b or 0B for 2, 0o, 0O or 0 for 8, 0x or 0X for 16 and 10 otherwise,
+// and underscores may separate digits. For bases up to 36 letters are case-insensitive,
+// for larger bases lowercase letters are 10-35 and uppercase are 36-61.
+// The value of u is undefined on failure.
+func (u *Uint128) SetString(s string, base int) (*Uint128, bool) {
+	v, err := parseUint128(s, base)
+	if err != nil {
+		return nil, false
+	}
+	*u = v
+	return u, true
+}
+
+// Text returns the representation of u in the given base in [2, 62].
+// Letters are lowercase for bases up to 36.
+func (u Uint128) Text(base int) string {
+	return string(u.Append(nil, base))
+}
+
+// Append appends the representation of u in the given base in [2, 62] to dst.
+func (u Uint128) Append(dst []byte, base int) []byte {
+	if base < 2 || base > len(textDigits) {
+		panic("mathx: base must be in [2, 62]")
+	}
+	var buf [128]byte
+	i := len(buf)
+	for {
+		var r uint64
+		u, r = u.QuoRem64(uint64(base))
+		i--
+		buf[i] = textDigits[r]
+		if u.IsZero() {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// SetString sets u to the value of s in the given base and returns u and a success flag.
+// See Uint128.SetString for the syntax.
+// The value of u is undefined on failure.
+func (u *Uint256) SetString(s string, base int) (*Uint256, bool) {
+	v, err := parseUint256(s, base)
+	if err != nil {
+		return nil, false
+	}
+	*u = v
+	return u, true
+}
+
+// Text returns the representation of u in the given base in [2, 62].
+// Letters are lowercase for bases up to 36.
+func (u Uint256) Text(base int) string {
+	return string(u.Append(nil, base))
+}
+
+// Append appends the representation of u in the given base in [2, 62] to dst.
+func (u Uint256) Append(dst []byte, base int) []byte {
+	if base < 2 || base > len(textDigits) {
+		panic("mathx: base must be in [2, 62]")
+	}
+	var buf [256]byte
+	i := len(buf)
+	for {
+		var r uint64
+		u, r = u.QuoRem64(uint64(base))
+		i--
+		buf[i] = textDigits[r]
+		if u.IsZero() {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// parseUint128 parses s like SetString and returns a *SyntaxError or ErrOverflow on failure.
+func parseUint128(s string, base int) (Uint128, error) {
+	digits, off, base, err := textPrefix(s, base)
+	if err != nil {
+		return Uint128{}, err
+	}
+	return uint128Digits(digits, off, base)
+}
+
+// parseUint256 parses s like SetString and returns a *SyntaxError or ErrOverflow on failure.
+func parseUint256(s string, base int) (Uint256, error) {
+	digits, off, base, err := textPrefix(s, base)
+	if err != nil {
+		return Uint256{}, err
+	}
+	return uint256Digits(digits, off, base)
+}
+
+// uint128Digits returns the value of the digits s at offset off, see textDigitsOf.
+func uint128Digits(s string, off, base int) (Uint128, error) {
+	var u Uint128
+	err := textDigitsOf(s, off, base, func(d uint64) bool {
+		var c uint64
+		u, c = u.mulAdd64(uint64(base), d)
+		return c == 0
+	})
+	if err != nil {
+		return Uint128{}, err
+	}
+	return u, nil
+}
+
+// uint256Digits returns the value of the digits s at offset off, see textDigitsOf.
+func uint256Digits(s string, off, base int) (Uint256, error) {
+	var u Uint256
+	err := textDigitsOf(s, off, base, func(d uint64) bool {
+		var c uint64
+		u, c = u.mulAdd64(uint64(base), d)
+		return c == 0
+	})
+	if err != nil {
+		return Uint256{}, err
+	}
+	return u, nil
+}
+
+// textDecHex is like textPrefix for decimal text or hex text with a 0x or 0X prefix.
+// Leading zeros are decimal and underscores are not allowed.
+func textDecHex(s string) (string, int, int, error) {
+	if i := strings.IndexByte(s, '_'); i >= 0 {
+		return "", 0, 0, &SyntaxError{Offset: i}
+	}
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:], 2, 16, nil
+	}
+	return s, 0, 10, nil
+}
+
+// textPrefix strips a base prefix of s for base 0 and validates underscores.
+// It returns the digits, their offset in s and the base.
+// Underscores are kept in the digits and skipped by textDigitsOf.
+func textPrefix(s string, base int) (string, int, int, error) {
+	switch {
+	case base != 0 && (base < 2 || base > len(textDigits)), s == "":
+		return "", 0, 0, &SyntaxError{Offset: 0}
+	case base != 0:
+		if i := strings.IndexByte(s, '_'); i >= 0 {
+			return "", 0, 0, &SyntaxError{Offset: i}
+		}
+		return s, 0, base, nil
+	}
+
+	prefixed := len(s) >= 2 && s[0] == '0'
+	off := 0
+	base = 10
+	if prefixed {
+		switch s[1] {
+		case 'b', 'B':
+			off, base = 2, 2
+		case 'o', 'O':
+			off, base = 2, 8
+		case 'x', 'X':
+			off, base = 2, 16
+		default:
+			off, base = 1, 8
+		}
+	}
+	digits := s[off:]
+
+	// Underscores may follow a prefix or separate digits.
+	for i := 0; i < len(digits); i++ {
+		if digits[i] != '_' {
+			continue
+		}
+		if (i == 0 && !prefixed) || (i > 0 && digits[i-1] == '_') || i == len(digits)-1 {
+			return "", 0, 0, &SyntaxError{Offset: off + i}
+		}
+	}
+	return digits, off, base, nil
+}
+
+// textDigitsOf calls add for each digit of s in the given base, most significant first.
+// Underscores are skipped, they must be validated by textPrefix.
+// The offset of s in the input is off. It returns ErrOverflow when add returns false.
+func textDigitsOf(s string, off, base int, add func(d uint64) bool) error {
+	if s == "" {
+		return &SyntaxError{Offset: off}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		var d int
+		switch {
+		case '0' <= c && c <= '9':
+			d = int(c - '0')
+		case 'a' <= c && c <= 'z':
+			d = int(c-'a') + 10
+		case 'A' <= c && c <= 'Z':
+			d = int(c-'A') + 10
+			if base > 36 {
+				d += 26
+			}
+		case c == '_':
+			continue
+		default:
+			return &SyntaxError{Offset: off + i}
+		}
+		if d >= base {
+			return &SyntaxError{Offset: off + i}
+		}
+		if !add(uint64(d)) {
+			return ErrOverflow
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package mathx
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestUint128SetString(t *testing.T) {
+	testCases := []struct {
+		s    string
+		base int
+		want string // decimal
+	}{
+		{"0", 0, "0"},
+		{"123", 0, "123"},
+		{"0x1f", 0, "31"},
+		{"0XFF", 0, "255"},
+		{"0b101", 0, "5"},
+		{"0o17", 0, "15"},
+		{"017", 0, "15"},
+		{"1_000_000", 0, "1000000"},
+		{"0x_ff_ff", 0, "65535"},
+		{"ff", 16, "255"},
+		{"FF", 16, "255"},
+		{"zz", 36, "1295"},
+		{"Z", 62, "61"},
+		{"z", 62, "35"},
+		{"340282366920938463463374607431768211455", 10, "340282366920938463463374607431768211455"},
+		{"0xffffffffffffffffffffffffffffffff", 0, "340282366920938463463374607431768211455"},
+	}
+	for _, tc := range testCases {
+		var u Uint128
+		if _, ok := u.SetString(tc.s, tc.base); !ok {
+			t.Fatalf("unexpected failure for %q in base %d", tc.s, tc.base)
+		}
+		if got := u.String(); got != tc.want {
+			t.Fatalf("unexpected value for %q; got %v; want %v", tc.s, got, tc.want)
+		}
+	}
+
+	for _, s := range []string{"", "0x", "_1", "1_", "1__2", "12a", "-1", "+1", "0b2", "1_000"} {
+		base := 0
+		if s == "1_000" {
+			base = 10
+		}
+		var u Uint128
+		if _, ok := u.SetString(s, base); ok {
+			t.Fatalf("unexpected success for %q", s)
+		}
+	}
+
+	if _, err := Uint128FromString("340282366920938463463374607431768211456"); err != ErrOverflow {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrOverflow)
+	}
+
+	syntaxCases := []struct {
+		s      string
+		offset int
+	}{
+		{"", 0},
+		{"12x", 2},
+		{"0x", 2},
+		{"0x1g", 3},
+		{"_1", 0},
+		{"1_", 1},
+		{"1__2", 2},
+		{"0b1_2", 4},
+		{"089", 1},
+	}
+	for _, tc := range syntaxCases {
+		u, err := parseUint128(tc.s, 0)
+		var synErr *SyntaxError
+		if !errors.As(err, &synErr) || synErr.Offset != tc.offset || !errors.Is(err, ErrSyntax) {
+			t.Fatalf("unexpected error for %q; got %v; want offset %d", tc.s, err, tc.offset)
+		}
+		if !u.IsZero() {
+			t.Fatalf("unexpected value on error for %q; got %v; want 0", tc.s, u)
+		}
+	}
+	if msg := (&SyntaxError{Offset: 2}).Error(); msg != "mathx: invalid syntax at offset 2" {
+		t.Fatalf("unexpected message; got %q", msg)
+	}
+}
+
+func TestUint128FromString(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want uint64
+	}{
+		{"0", 0},
+		{"0100", 100},
+		{"0109", 109},
+		{"255", 255},
+		{"0x1f", 31},
+		{"0XFF", 255},
+	}
+	for _, tc := range testCases {
+		if got, err := Uint128FromString(tc.s); err != nil || got != Uint128FromUint64(tc.want) {
+			t.Fatalf("unexpected value for %q; got %v, %v; want %v", tc.s, got, err, tc.want)
+		}
+	}
+
+	syntaxCases := []struct {
+		s      string
+		offset int
+	}{
+		{"", 0},
+		{"0x", 2},
+		{"0b1", 1},
+		{"0o7", 1},
+		{"1_000", 1},
+		{"10a", 2},
+		{"0x1g", 3},
+	}
+	for _, tc := range syntaxCases {
+		u, err := Uint128FromString(tc.s)
+		var synErr *SyntaxError
+		if !errors.As(err, &synErr) || synErr.Offset != tc.offset {
+			t.Fatalf("unexpected error for %q; got %v; want offset %d", tc.s, err, tc.offset)
+		}
+		if !u.IsZero() {
+			t.Fatalf("unexpected value on error for %q; got %v; want 0", tc.s, u)
+		}
+	}
+	if u, err := Uint128FromString("340282366920938463463374607431768211456"); err != ErrOverflow || !u.IsZero() {
+		t.Fatalf("unexpected overflow result; got %v, %v; want 0, %v", u, err, ErrOverflow)
+	}
+}
+
+func TestUint128Text(t *testing.T) {
+	u := NewUint128(0x0123456789abcdef, 0xfedcba9876543210)
+	for _, base := range []int{2, 8, 10, 16, 36, 62} {
+		got := u.Text(base)
+		if base <= 36 {
+			if want := u.Big().Text(base); got != want {
+				t.Fatalf("unexpected Text(%d); got %v; want %v", base, got, want)
+			}
+		}
+		var back Uint128
+		if _, ok := back.SetString(got, base); !ok || back != u {
+			t.Fatalf("unexpected roundtrip in base %d; got %v", base, back)
+		}
+	}
+
+	want := new(big.Int).SetUint64(61)
+	if got := Uint128FromUint64(61).Text(62); got != want.Text(62) {
+		t.Fatalf("unexpected Text(62); got %v; want %v", got, want.Text(62))
+	}
+	if got := (Uint128{}).Text(2); got != "0" {
+		t.Fatalf("unexpected Text of zero; got %v", got)
+	}
+}
+
+func TestUint256SetString(t *testing.T) {
+	max := MaxUint256.Big()
+	testCases := []struct {
+		s    string
+		want string // decimal
+	}{
+		{"0", "0"},
+		{"123", "123"},
+		{"0x1f", "31"},
+		{"0100", "100"},
+		{"0XFF", "255"},
+		{"0x" + max.Text(16), max.String()},
+		{max.String(), max.String()},
+	}
+	for _, tc := range testCases {
+		u, err := Uint256FromString(tc.s)
+		if err != nil {
+			t.Fatalf("unexpected error for %q; got %v", tc.s, err)
+		}
+		if got := u.String(); got != tc.want {
+			t.Fatalf("unexpected value for %q; got %v; want %v", tc.s, got, tc.want)
+		}
+	}
+
+	over := new(big.Int).Add(max, big.NewInt(1))
+	for _, s := range []string{over.String(), "0x" + over.Text(16)} {
+		if _, err := Uint256FromString(s); err != ErrOverflow {
+			t.Fatalf("unexpected error for %q; got %v; want %v", s, err, ErrOverflow)
+		}
+	}
+	var synErr *SyntaxError
+	if _, err := Uint256FromString("0x12g"); !errors.As(err, &synErr) || synErr.Offset != 4 {
+		t.Fatalf("unexpected error; got %v; want offset 4", err)
+	}
+	for _, s := range []string{"0b1", "1_000", "0109x"} {
+		if v, err := Uint256FromString(s); !errors.Is(err, ErrSyntax) || !v.IsZero() {
+			t.Fatalf("unexpected result for %q; got %v, %v; want 0, %v", s, v, err, ErrSyntax)
+		}
+	}
+	var u Uint256
+	if _, ok := u.SetString("ff", 16); !ok || u != Uint256FromUint64(255) {
+		t.Fatalf("unexpected SetString in base 16; got %v, %v", u, ok)
+	}
+}
+
+func TestUint256Text(t *testing.T) {
+	u := NewUint256(NewUint128(0x0123456789abcdef, 0xfedcba9876543210), NewUint128(0x1122334455667788, 0x99aabbccddeeff00))
+	for _, base := range []int{2, 8, 10, 16, 36, 62} {
+		got := u.Text(base)
+		if base <= 36 {
+			if want := u.Big().Text(base); got != want {
+				t.Fatalf("unexpected Text(%d); got %v; want %v", base, got, want)
+			}
+		}
+		var back Uint256
+		if _, ok := back.SetString(got, base); !ok || back != u {
+			t.Fatalf("unexpected roundtrip in base %d; got %v", base, back)
+		}
+	}
+	if got := MaxUint256.Text(2); len(got) != 256 {
+		t.Fatalf("unexpected Text(2) length of max; got %v", len(got))
+	}
+	if got := (Uint256{}).Text(10); got != "0" {
+		t.Fatalf("unexpected Text of zero; got %v", got)
+	}
+}
@@ -0,0 +1,30 @@
+package mathx
+
+import "time"
+
+// Timer measures elapsed time and records it into an Observer.
+// Durations are recorded in seconds.
+type Timer struct {
+	o     Observer
+	start time.Time
+}
+
+// StartTimer returns a new Timer started at the current time.
+func StartTimer(o Observer) Timer {
+	return Timer{o: o, start: time.Now()}
+}
+
+// ObserveDuration records the time elapsed since the timer was started
+// and returns it.
+func (t Timer) ObserveDuration() time.Duration {
+	d := time.Since(t.start)
+	t.o.Observe(d.Seconds())
+	return d
+}
+
+// Measure calls fn and records its duration into o.
+func Measure(o Observer, fn func()) time.Duration {
+	t := StartTimer(o)
+	fn()
+	return t.ObserveDuration()
+}
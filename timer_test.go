@@ -0,0 +1,26 @@
+package mathx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimer(t *testing.T) {
+	h := NewHistogram()
+
+	d := Measure(h, func() { time.Sleep(time.Millisecond) })
+	if d < time.Millisecond {
+		t.Fatalf("unexpected duration; got %v; want at least %v", d, time.Millisecond)
+	}
+
+	q := h.Quantile(1)
+	if q != d.Seconds() {
+		t.Fatalf("unexpected recorded value; got %v; want %v", q, d.Seconds())
+	}
+
+	timer := StartTimer(h)
+	timer.ObserveDuration()
+	if h.res.Count() != 2 {
+		t.Fatalf("unexpected count; got %v; want %v", h.res.Count(), 2)
+	}
+}
@@ -0,0 +1,167 @@
+package mathx
+
+import "sort"
+
+// TopK tracks the k most frequent items in a stream.
+// It implements the Space-Saving algorithm: every reported count
+// overestimates the true count by at most the reported error.
+//
+// See: Metwally, A., Agrawal, D., El Abbadi, A. Efficient computation of frequent and top-k elements in data streams. https://doi.org/10.1007/978-3-540-30570-5_27
+type TopK[K comparable] struct {
+	k     int
+	heap  []TopKItem[K]
+	index map[K]int
+}
+
+// TopKItem is an item tracked by TopK.
+// The true count of the item is in range [Count-Err, Count].
+type TopKItem[K comparable] struct {
+	Item  K
+	Count uint64
+	Err   uint64
+}
+
+// NewTopK returns new TopK which tracks k items.
+func NewTopK[K comparable](k int) *TopK[K] {
+	if k <= 0 {
+		panic("mathx: top-k size must be positive")
+	}
+	return &TopK[K]{
+		k:     k,
+		heap:  make([]TopKItem[K], 0, k),
+		index: make(map[K]int, k),
+	}
+}
+
+// K returns the number of tracked items.
+func (t *TopK[K]) K() int { return t.k }
+
+// Reset resets the tracker.
+func (t *TopK[K]) Reset() {
+	t.heap = t.heap[:0]
+	for k := range t.index {
+		delete(t.index, k)
+	}
+}
+
+// Add n occurrences of the item.
+func (t *TopK[K]) Add(item K, n uint64) {
+	if i, ok := t.index[item]; ok {
+		t.heap[i].Count += n
+		t.down(i)
+		return
+	}
+
+	if len(t.heap) < t.k {
+		t.heap = append(t.heap, TopKItem[K]{Item: item, Count: n})
+		t.index[item] = len(t.heap) - 1
+		t.up(len(t.heap) - 1)
+		return
+	}
+
+	// Replace the least frequent item, it's count becomes the error.
+	min := t.heap[0]
+	delete(t.index, min.Item)
+	t.heap[0] = TopKItem[K]{Item: item, Count: min.Count + n, Err: min.Count}
+	t.index[item] = 0
+	t.down(0)
+}
+
+// Items returns tracked items sorted by count in descending order.
+func (t *TopK[K]) Items() []TopKItem[K] {
+	items := append([]TopKItem[K](nil), t.heap...)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Count > items[j].Count
+	})
+	return items
+}
+
+// Merge merges x into t.
+// Both trackers must track the same number of items.
+//
+// See: Agarwal, P. et al. Mergeable summaries. https://doi.org/10.1145/2500128
+func (t *TopK[K]) Merge(x *TopK[K]) error {
+	if t.k != x.k {
+		return ErrMismatch
+	}
+
+	tmin, xmin := t.minCount(), x.minCount()
+	merged := make([]TopKItem[K], 0, len(t.heap)+len(x.heap))
+
+	for _, it := range t.heap {
+		if j, ok := x.index[it.Item]; ok {
+			it.Count += x.heap[j].Count
+			it.Err += x.heap[j].Err
+		} else {
+			it.Count += xmin
+			it.Err += xmin
+		}
+		merged = append(merged, it)
+	}
+	for _, it := range x.heap {
+		if _, ok := t.index[it.Item]; ok {
+			continue
+		}
+		it.Count += tmin
+		it.Err += tmin
+		merged = append(merged, it)
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Count > merged[j].Count
+	})
+	if len(merged) > t.k {
+		merged = merged[:t.k]
+	}
+
+	t.Reset()
+	for _, it := range merged {
+		t.heap = append(t.heap, it)
+		t.index[it.Item] = len(t.heap) - 1
+		t.up(len(t.heap) - 1)
+	}
+	return nil
+}
+
+// minCount returns the count an untracked item may have at most.
+func (t *TopK[K]) minCount() uint64 {
+	if len(t.heap) < t.k {
+		return 0
+	}
+	return t.heap[0].Count
+}
+
+func (t *TopK[K]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if t.heap[p].Count <= t.heap[i].Count {
+			break
+		}
+		t.swap(i, p)
+		i = p
+	}
+}
+
+func (t *TopK[K]) down(i int) {
+	n := len(t.heap)
+	for {
+		min := i
+		if l := 2*i + 1; l < n && t.heap[l].Count < t.heap[min].Count {
+			min = l
+		}
+		if r := 2*i + 2; r < n && t.heap[r].Count < t.heap[min].Count {
+			min = r
+		}
+		if min == i {
+			return
+		}
+		t.swap(i, min)
+		i = min
+	}
+}
+
+func (t *TopK[K]) swap(i, j int) {
+	t.heap[i], t.heap[j] = t.heap[j], t.heap[i]
+	t.index[t.heap[i].Item] = i
+	t.index[t.heap[j].Item] = j
+}
@@ -0,0 +1,56 @@
+package mathx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	tk := NewTopK[string](10)
+	for i := 0; i < 1000; i++ {
+		tk.Add("/api/a", 1)
+		if i%2 == 0 {
+			tk.Add("/api/b", 1)
+		}
+		if i%4 == 0 {
+			tk.Add("/api/c", 1)
+		}
+		if i%4 == 1 {
+			tk.Add(fmt.Sprintf("/noise/%d", i), 1)
+		}
+	}
+
+	items := tk.Items()
+	want := []string{"/api/a", "/api/b", "/api/c"}
+	for i, it := range items[:len(want)] {
+		if it.Item != want[i] {
+			t.Fatalf("unexpected item at %d; got %v; want %v", i, it.Item, want[i])
+		}
+		if it.Count < it.Err {
+			t.Fatalf("error must not exceed count; got %+v", it)
+		}
+	}
+	if items[0].Count-items[0].Err > 1000 || items[0].Count < 1000 {
+		t.Fatalf("true count must be within bounds; got %+v", items[0])
+	}
+}
+
+func TestTopKMerge(t *testing.T) {
+	a := NewTopK[int](2)
+	b := NewTopK[int](2)
+	a.Add(1, 10)
+	a.Add(2, 5)
+	b.Add(2, 8)
+	b.Add(3, 1)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	items := a.Items()
+	if len(items) != 2 || items[0].Item != 2 || items[0].Count != 13 || items[1].Item != 1 {
+		t.Fatalf("unexpected items after merge; got %+v", items)
+	}
+	if err := a.Merge(NewTopK[int](3)); err != ErrMismatch {
+		t.Fatalf("unexpected error; got %v; want %v", err, ErrMismatch)
+	}
+}
@@ -1,7 +1,6 @@
 package mathx
 
 import (
-	"fmt"
 	"math/big"
 	"math/bits"
 )
@@ -20,24 +19,40 @@ func Uint128FromUint64(v uint64) Uint128 {
 	return NewUint128(0, v)
 }
 
+// Uint128FromString returns the value of the decimal s, or of the hex s with a 0x or 0X prefix.
+// Leading zeros are decimal, use SetString with base 0 for other prefixes.
+// It returns a *SyntaxError or ErrOverflow on failure.
 func Uint128FromString(s string) (Uint128, error) {
-	var u Uint128
-	_, err := fmt.Sscan(s, &u)
-	return u, err
+	digits, off, base, err := textDecHex(s)
+	if err != nil {
+		return Uint128{}, err
+	}
+	return uint128Digits(digits, off, base)
 }
 
 func (u Uint128) Parts() (uint64, uint64) { return u.hi, u.lo }
 func (u Uint128) IsZero() bool            { return u.hi|u.lo == 0 }
 func (u Uint128) Equals(x Uint128) bool   { return u == x }
 
+// Cmp returns -1 if u < x, 0 if u == x and +1 if u > x.
+// It is computed from subtraction borrows without branches.
 func (u Uint128) Cmp(x Uint128) int {
+	_, b := bits.Sub64(u.lo, x.lo, 0)
+	_, lt := bits.Sub64(u.hi, x.hi, b)
+	_, b = bits.Sub64(x.lo, u.lo, 0)
+	_, gt := bits.Sub64(x.hi, u.hi, b)
+	return int(gt) - int(lt)
+}
+
+// Cmp64 returns -1 if u < x, 0 if u == x and +1 if u > x.
+func (u Uint128) Cmp64(x uint64) int {
 	switch {
-	case u == x:
-		return 0
-	case u.hi < x.hi || (u.hi == x.hi && u.lo < x.lo):
+	case u.hi != 0 || u.lo > x:
+		return 1
+	case u.lo < x:
 		return -1
 	default:
-		return 1
+		return 0
 	}
 }
 
@@ -57,6 +72,12 @@ func (u Uint128) Add(x Uint128) Uint128 {
 	return Uint128{hi: hi, lo: lo}
 }
 
+// Add64 returns u+x wrapped around at 2^128.
+func (u Uint128) Add64(x uint64) Uint128 {
+	lo, carry := bits.Add64(u.lo, x, 0)
+	return Uint128{hi: u.hi + carry, lo: lo}
+}
+
 func (u Uint128) AddCarry(x Uint128, carry uint64) (Uint128, uint64) {
 	lo, c := bits.Add64(u.lo, x.lo, carry)
 	hi, c := bits.Add64(u.hi, x.hi, c)
@@ -69,6 +90,12 @@ func (u Uint128) Sub(x Uint128) Uint128 {
 	return Uint128{hi: hi, lo: lo}
 }
 
+// Sub64 returns u-x wrapped around at 2^128.
+func (u Uint128) Sub64(x uint64) Uint128 {
+	lo, borrow := bits.Sub64(u.lo, x, 0)
+	return Uint128{hi: u.hi - borrow, lo: lo}
+}
+
 func (u Uint128) SubBorrow(x Uint128, borrow uint64) (Uint128, uint64) {
 	lo, b := bits.Sub64(u.lo, x.lo, borrow)
 	hi, b := bits.Sub64(u.hi, x.hi, b)
@@ -81,6 +108,12 @@ func (u Uint128) Mul(x Uint128) Uint128 {
 	return Uint128{hi: hi, lo: lo}
 }
 
+// Mul64 returns u*x wrapped around at 2^128.
+func (u Uint128) Mul64(x uint64) Uint128 {
+	hi, lo := bits.Mul64(u.lo, x)
+	return Uint128{hi: hi + u.hi*x, lo: lo}
+}
+
 // multiply 128-bit unsigned integers and return high and lower product
 func (a Uint128) MulFull(b Uint128) (Uint128, Uint128) {
 	var lo, m1, m2, hi Uint128
@@ -103,6 +136,7 @@ func (u Uint128) Xor(x Uint128) Uint128 { return Uint128{hi: u.hi ^ x.hi, lo: u.
 func (u Uint128) Or(x Uint128) Uint128  { return Uint128{hi: u.hi | x.hi, lo: u.lo | x.lo} }
 func (u Uint128) Not() Uint128          { return Uint128{hi: ^u.hi, lo: ^u.lo} }
 
+// Lsh returns u << n, shifts by n >= 128 give 0.
 func (u Uint128) Lsh(n uint) Uint128 {
 	if n > 64 {
 		return Uint128{hi: u.lo << (n - 64), lo: 0}
@@ -113,6 +147,7 @@ func (u Uint128) Lsh(n uint) Uint128 {
 	}
 }
 
+// Rsh returns u >> n, shifts by n >= 128 give 0.
 func (u Uint128) Rsh(n uint) Uint128 {
 	if n > 64 {
 		return Uint128{hi: 0, lo: u.hi >> (n - 64)}
@@ -123,16 +158,43 @@ func (u Uint128) Rsh(n uint) Uint128 {
 	}
 }
 
-func (u Uint128) Big() *big.Int {
-	i := new(big.Int).SetUint64(u.hi)
-	i = i.Lsh(i, 64)
-	i = i.Xor(i, new(big.Int).SetUint64(u.lo))
-	return i
+func (u Uint128) Big() *big.Int { return u.SetBig(new(big.Int)) }
+
+func (u Uint128) String() string { return u.Text(10) }
+
+// QuoRem64 returns u / d and u % d.
+// It is faster than a full-width division. It panics for d == 0 (division by zero).
+func (u Uint128) QuoRem64(d uint64) (Uint128, uint64) {
+	if u.hi < d {
+		lo, r := bits.Div64(u.hi, u.lo, d)
+		return Uint128{lo: lo}, r
+	}
+	hi, r := bits.Div64(0, u.hi, d)
+	lo, r := bits.Div64(r, u.lo, d)
+	return Uint128{hi: hi, lo: lo}, r
+}
+
+// mulAdd64 returns u * m + a and the carry out of the high word.
+func (u Uint128) mulAdd64(m, a uint64) (Uint128, uint64) {
+	h0, lo := bits.Mul64(u.lo, m)
+	h1, mid := bits.Mul64(u.hi, m)
+	lo, c := bits.Add64(lo, a, 0)
+	hi, c := bits.Add64(mid, h0, c)
+	return Uint128{hi: hi, lo: lo}, h1 + c
+}
+
+// Min returns the smaller of u and x.
+func (u Uint128) Min(x Uint128) Uint128 {
+	if x.Cmp(u) < 0 {
+		return x
+	}
+	return u
 }
 
-func (u Uint128) String() string {
-	if u.IsZero() {
-		return "0"
+// Max returns the larger of u and x.
+func (u Uint128) Max(x Uint128) Uint128 {
+	if x.Cmp(u) > 0 {
+		return x
 	}
-	return u.Big().String()
+	return u
 }
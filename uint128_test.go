@@ -0,0 +1,25 @@
+package mathx
+
+import "testing"
+
+func TestUint128Mixed64(t *testing.T) {
+	values := []Uint128{{}, {lo: 1}, {lo: 1<<64 - 1}, {hi: 1}, {hi: 1<<64 - 1, lo: 5}, MaxUint128}
+	operands := []uint64{0, 1, 5, 1 << 63, 1<<64 - 1}
+	for _, u := range values {
+		for _, x := range operands {
+			wide := Uint128FromUint64(x)
+			if got, want := u.Add64(x), u.Add(wide); got != want {
+				t.Fatalf("unexpected %v.Add64(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Sub64(x), u.Sub(wide); got != want {
+				t.Fatalf("unexpected %v.Sub64(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Mul64(x), u.Mul(wide); got != want {
+				t.Fatalf("unexpected %v.Mul64(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Cmp64(x), u.Cmp(wide); got != want {
+				t.Fatalf("unexpected %v.Cmp64(%v); got %v; want %v", u, x, got, want)
+			}
+		}
+	}
+}
@@ -2,6 +2,7 @@ package mathx
 
 import (
 	"math/big"
+	"math/bits"
 )
 
 // Uint256 represents a uint256 using 2 uint64.
@@ -13,15 +14,49 @@ type Uint256 struct {
 func NewUint256(hi, lo Uint128) Uint256  { return Uint256{hi: hi, lo: lo} }
 func Uint256FromUint64(v uint64) Uint256 { return NewUint256(Uint128{}, NewUint128(0, v)) }
 
+// Uint256FromString returns the value of the decimal s, or of the hex s with a 0x or 0X prefix.
+// Leading zeros are decimal, use SetString with base 0 for other prefixes.
+// It returns a *SyntaxError or ErrOverflow on failure.
+func Uint256FromString(s string) (Uint256, error) {
+	digits, off, base, err := textDecHex(s)
+	if err != nil {
+		return Uint256{}, err
+	}
+	return uint256Digits(digits, off, base)
+}
+
 func (u Uint256) Parts() (Uint128, Uint128) { return u.hi, u.lo }
-func (u Uint256) IsZero() bool              { return u.hi.IsZero() && u.lo.IsZero() }
+func (u Uint256) IsZero() bool              { return u.hi.hi|u.hi.lo|u.lo.hi|u.lo.lo == 0 }
 func (u Uint256) Equals(x Uint256) bool     { return u.hi.Equals(x.hi) && u.lo.Equals(x.lo) }
 
+// Cmp returns -1 if u < x, 0 if u == x and +1 if u > x.
+// It is computed from subtraction borrows without branches.
 func (u Uint256) Cmp(x Uint256) int {
-	if h := u.hi.Cmp(x.hi); h != 0 {
-		return h
+	_, b := bits.Sub64(u.lo.lo, x.lo.lo, 0)
+	_, b = bits.Sub64(u.lo.hi, x.lo.hi, b)
+	_, b = bits.Sub64(u.hi.lo, x.hi.lo, b)
+	_, lt := bits.Sub64(u.hi.hi, x.hi.hi, b)
+	_, b = bits.Sub64(x.lo.lo, u.lo.lo, 0)
+	_, b = bits.Sub64(x.lo.hi, u.lo.hi, b)
+	_, b = bits.Sub64(x.hi.lo, u.hi.lo, b)
+	_, gt := bits.Sub64(x.hi.hi, u.hi.hi, b)
+	return int(gt) - int(lt)
+}
+
+// Cmp128 returns -1 if u < x, 0 if u == x and +1 if u > x.
+func (u Uint256) Cmp128(x Uint128) int {
+	if !u.hi.IsZero() {
+		return 1
 	}
-	return u.lo.Cmp(x.lo)
+	return u.lo.Cmp(x)
+}
+
+// Cmp64 returns -1 if u < x, 0 if u == x and +1 if u > x.
+func (u Uint256) Cmp64(x uint64) int {
+	if !u.hi.IsZero() {
+		return 1
+	}
+	return u.lo.Cmp64(x)
 }
 
 func (u Uint256) Inc() Uint256 { return u.Add(Uint256{lo: Uint128{lo: 1}}) }
@@ -33,6 +68,15 @@ func (u Uint256) Add(x Uint256) Uint256 {
 	return s
 }
 
+// Add128 returns u+x wrapped around at 2^256.
+func (u Uint256) Add128(x Uint128) Uint256 {
+	lo, c := u.lo.AddCarry(x, 0)
+	return Uint256{hi: u.hi.Add64(c), lo: lo}
+}
+
+// Add64 returns u+x wrapped around at 2^256.
+func (u Uint256) Add64(x uint64) Uint256 { return u.Add128(Uint128{lo: x}) }
+
 func (u Uint256) AddCarry(x Uint256, carry uint64) (Uint256, uint64) {
 	lo, c := u.lo.AddCarry(x.lo, carry)
 	hi, c := u.hi.AddCarry(x.hi, c)
@@ -44,6 +88,15 @@ func (u Uint256) Sub(x Uint256) Uint256 {
 	return d
 }
 
+// Sub128 returns u-x wrapped around at 2^256.
+func (u Uint256) Sub128(x Uint128) Uint256 {
+	lo, b := u.lo.SubBorrow(x, 0)
+	return Uint256{hi: u.hi.Sub64(b), lo: lo}
+}
+
+// Sub64 returns u-x wrapped around at 2^256.
+func (u Uint256) Sub64(x uint64) Uint256 { return u.Sub128(Uint128{lo: x}) }
+
 func (u Uint256) SubBorrow(x Uint256, borrow uint64) (Uint256, uint64) {
 	lo, b := u.lo.SubBorrow(x.lo, borrow)
 	hi, b := u.hi.SubBorrow(x.hi, b)
@@ -57,6 +110,18 @@ func (u Uint256) Mul(v Uint256) Uint256 {
 	return Uint256{lo: lo, hi: hi}
 }
 
+// Mul128 returns u*x wrapped around at 2^256.
+func (u Uint256) Mul128(x Uint128) Uint256 {
+	hi, lo := u.lo.MulFull(x)
+	return Uint256{hi: hi.Add(u.hi.Mul(x)), lo: lo}
+}
+
+// Mul64 returns u*x wrapped around at 2^256.
+func (u Uint256) Mul64(x uint64) Uint256 {
+	p, _ := u.mulAdd64(x, 0)
+	return p
+}
+
 func (u Uint256) MulFull(x Uint256) (Uint256, Uint256) {
 	var lo, hi Uint256
 	lo.hi, lo.lo = u.lo.MulFull(x.lo)
@@ -98,16 +163,41 @@ func (u Uint256) Rsh(n uint) Uint256 {
 	}
 }
 
-func (u Uint256) Big() *big.Int {
-	i := u.hi.Big()
-	i = i.Lsh(i, 128)
-	i = i.Xor(i, u.lo.Big())
-	return i
+func (u Uint256) Big() *big.Int { return u.SetBig(new(big.Int)) }
+
+func (u Uint256) String() string { return u.Text(10) }
+
+// QuoRem64 returns u / d and u % d.
+// It is faster than a full-width division. It panics for d == 0 (division by zero).
+func (u Uint256) QuoRem64(d uint64) (Uint256, uint64) {
+	var q Uint256
+	var r uint64
+	q.hi.hi, r = bits.Div64(0, u.hi.hi, d)
+	q.hi.lo, r = bits.Div64(r, u.hi.lo, d)
+	q.lo.hi, r = bits.Div64(r, u.lo.hi, d)
+	q.lo.lo, r = bits.Div64(r, u.lo.lo, d)
+	return q, r
+}
+
+// mulAdd64 returns u * m + a and the carry out of the high word.
+func (u Uint256) mulAdd64(m, a uint64) (Uint256, uint64) {
+	lo, c := u.lo.mulAdd64(m, a)
+	hi, c := u.hi.mulAdd64(m, c)
+	return Uint256{hi: hi, lo: lo}, c
+}
+
+// Min returns the smaller of u and x.
+func (u Uint256) Min(x Uint256) Uint256 {
+	if x.Cmp(u) < 0 {
+		return x
+	}
+	return u
 }
 
-func (u Uint256) String() string {
-	if u.IsZero() {
-		return "0"
+// Max returns the larger of u and x.
+func (u Uint256) Max(x Uint256) Uint256 {
+	if x.Cmp(u) > 0 {
+		return x
 	}
-	return u.Big().String()
+	return u
 }
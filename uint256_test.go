@@ -0,0 +1,47 @@
+package mathx
+
+import "testing"
+
+func TestUint256Mixed(t *testing.T) {
+	values := []Uint256{
+		{},
+		Uint256FromUint64(5),
+		NewUint256(Uint128{}, MaxUint128),
+		NewUint256(NewUint128(0, 1), NewUint128(3, 7)),
+		MaxUint256,
+	}
+	operands := []Uint128{{}, {lo: 1}, {lo: 1<<64 - 1}, {hi: 1, lo: 5}, MaxUint128}
+	for _, u := range values {
+		for _, x := range operands {
+			wide := NewUint256(Uint128{}, x)
+			if got, want := u.Add128(x), u.Add(wide); got != want {
+				t.Fatalf("unexpected %v.Add128(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Sub128(x), u.Sub(wide); got != want {
+				t.Fatalf("unexpected %v.Sub128(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Mul128(x), u.Mul(wide); got != want {
+				t.Fatalf("unexpected %v.Mul128(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Cmp128(x), u.Cmp(wide); got != want {
+				t.Fatalf("unexpected %v.Cmp128(%v); got %v; want %v", u, x, got, want)
+			}
+
+			if x.hi != 0 {
+				continue
+			}
+			if got, want := u.Add64(x.lo), u.Add(wide); got != want {
+				t.Fatalf("unexpected %v.Add64(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Sub64(x.lo), u.Sub(wide); got != want {
+				t.Fatalf("unexpected %v.Sub64(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Mul64(x.lo), u.Mul(wide); got != want {
+				t.Fatalf("unexpected %v.Mul64(%v); got %v; want %v", u, x, got, want)
+			}
+			if got, want := u.Cmp64(x.lo), u.Cmp(wide); got != want {
+				t.Fatalf("unexpected %v.Cmp64(%v); got %v; want %v", u, x, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package mathx
+
+// UUID returns the canonical form of u as a UUID, like 01890a5d-ac96-774b-bcce-b302099a8057.
+// The value is the big-endian UUID bytes, see Bytes and Uint128FromBytes,
+// so comparing values orders UUIDs like their bytes, by time for UUIDv7.
+func (u Uint128) UUID() string {
+	return string(u.AppendUUID(nil))
+}
+
+// AppendUUID appends the canonical UUID form of u to dst.
+func (u Uint128) AppendUUID(dst []byte) []byte {
+	const hex = "0123456789abcdef"
+	b := u.Bytes()
+	for i, c := range b {
+		if i == 4 || i == 6 || i == 8 || i == 10 {
+			dst = append(dst, '-')
+		}
+		dst = append(dst, hex[c>>4], hex[c&0xf])
+	}
+	return dst
+}
+
+// Uint128FromUUID returns the value of the UUID in canonical form s, hex digits may be in any case.
+// It returns a *SyntaxError if s is malformed.
+func Uint128FromUUID(s string) (Uint128, error) {
+	var u Uint128
+	for i := 0; i < 36; i++ {
+		if i == len(s) {
+			return Uint128{}, &SyntaxError{Offset: i}
+		}
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if s[i] != '-' {
+				return Uint128{}, &SyntaxError{Offset: i}
+			}
+			continue
+		}
+		var d uint64
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			d = uint64(c - '0')
+		case 'a' <= c && c <= 'f':
+			d = uint64(c-'a') + 10
+		case 'A' <= c && c <= 'F':
+			d = uint64(c-'A') + 10
+		default:
+			return Uint128{}, &SyntaxError{Offset: i}
+		}
+		u = Uint128{hi: u.hi<<4 | u.lo>>60, lo: u.lo<<4 | d}
+	}
+	if len(s) > 36 {
+		return Uint128{}, &SyntaxError{Offset: 36}
+	}
+	return u, nil
+}
+
+// UUIDVersion returns the version field of u as a UUID, e.g. 4 for random and 7 for time-ordered UUIDs.
+func (u Uint128) UUIDVersion() int { return int(u.hi >> 12 & 0xf) }
+
+// UUIDVariant returns the variant field of u as a UUID:
+// 0b0 for NCS, 0b10 for RFC 9562, 0b110 for Microsoft and 0b111 for future use.
+func (u Uint128) UUIDVariant() int {
+	switch v := u.lo >> 61; {
+	case v < 0b100:
+		return 0b0
+	case v < 0b110:
+		return 0b10
+	default:
+		return int(v)
+	}
+}
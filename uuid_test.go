@@ -0,0 +1,57 @@
+package mathx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUUID(t *testing.T) {
+	testCases := []struct {
+		s                string
+		u                Uint128
+		version, variant int
+	}{
+		{"00000000-0000-0000-0000-000000000000", Uint128{}, 0, 0b0},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", MaxUint128, 15, 0b111},
+		{"01890a5d-ac96-774b-bcce-b302099a8057", NewUint128(0x01890a5dac96774b, 0xbcceb302099a8057), 7, 0b10},
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bf6", NewUint128(0xf81d4fae7dec11d0, 0xa76500a0c91e6bf6), 1, 0b10},
+		{"c232ab00-9414-11ec-d3c8-00aa0062d5d5", NewUint128(0xc232ab00941411ec, 0xd3c800aa0062d5d5), 1, 0b110},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.u.UUID(); got != tc.s {
+			t.Fatalf("unexpected UUID; got %q; want %q", got, tc.s)
+		}
+		if got, err := Uint128FromUUID(tc.s); err != nil || got != tc.u {
+			t.Fatalf("unexpected Uint128FromUUID(%q); got %#x, %v; want %#x", tc.s, got, err, tc.u)
+		}
+		if got := tc.u.UUIDVersion(); got != tc.version {
+			t.Fatalf("unexpected UUIDVersion of %q; got %v; want %v", tc.s, got, tc.version)
+		}
+		if got := tc.u.UUIDVariant(); got != tc.variant {
+			t.Fatalf("unexpected UUIDVariant of %q; got %b; want %b", tc.s, got, tc.variant)
+		}
+	}
+
+	if got, err := Uint128FromUUID("01890A5D-AC96-774B-BCCE-B302099A8057"); err != nil || got != NewUint128(0x01890a5dac96774b, 0xbcceb302099a8057) {
+		t.Fatalf("unexpected uppercase UUID; got %#x, %v", got, err)
+	}
+	syntaxCases := []struct {
+		s      string
+		offset int
+	}{
+		{"", 0},
+		{"01890a5d-ac96-774b-bcce-b302099a805", 35},
+		{"01890a5dac96-774b-bcce-b302099a80577", 8},
+		{"01890a5d-ac96-774b-bcce-b302099a805g", 35},
+		{"{1890a5d-ac96-774b-bcce-b302099a8057", 0},
+		{"01890a5d-ac96-774b-bcce-b302099a80570", 36},
+	}
+	for _, tc := range syntaxCases {
+		_, err := Uint128FromUUID(tc.s)
+		var synErr *SyntaxError
+		if !errors.As(err, &synErr) || synErr.Offset != tc.offset || !errors.Is(err, ErrSyntax) {
+			t.Fatalf("unexpected error for %q; got %v; want offset %d", tc.s, err, tc.offset)
+		}
+	}
+}
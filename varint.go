@@ -0,0 +1,72 @@
+package mathx
+
+// Maximum lengths of varint-encoded wide integers.
+const (
+	MaxVarintLen128 = 19
+	MaxVarintLen256 = 37
+)
+
+// AppendUvarint128 appends the LEB128 varint encoding of u to dst.
+// The encoding is compatible with encoding/binary for values that fit into uint64.
+func AppendUvarint128(dst []byte, u Uint128) []byte {
+	for u.hi != 0 || u.lo >= 0x80 {
+		dst = append(dst, byte(u.lo)|0x80)
+		u = u.Rsh(7)
+	}
+	return append(dst, byte(u.lo))
+}
+
+// Uvarint128 decodes a Uint128 from b and returns that value and the number of bytes read.
+// If an error occurred, the value is 0 and the number of bytes n is <= 0 meaning:
+//
+//	n == 0: buf too small
+//	n  < 0: value larger than 128 bits (overflow) and -n is the number of bytes read
+func Uvarint128(b []byte) (Uint128, int) {
+	var u Uint128
+	var s uint
+	for i, c := range b {
+		if i == MaxVarintLen128 {
+			return Uint128{}, -(i + 1) // overflow
+		}
+		if c < 0x80 {
+			if i == MaxVarintLen128-1 && c > 3 {
+				return Uint128{}, -(i + 1) // overflow
+			}
+			return u.Or(Uint128FromUint64(uint64(c)).Lsh(s)), i + 1
+		}
+		u = u.Or(Uint128FromUint64(uint64(c & 0x7f)).Lsh(s))
+		s += 7
+	}
+	return Uint128{}, 0
+}
+
+// AppendUvarint256 appends the LEB128 varint encoding of u to dst.
+// The encoding is compatible with encoding/binary for values that fit into uint64.
+func AppendUvarint256(dst []byte, u Uint256) []byte {
+	for !u.hi.IsZero() || u.lo.hi != 0 || u.lo.lo >= 0x80 {
+		dst = append(dst, byte(u.lo.lo)|0x80)
+		u = u.Rsh(7)
+	}
+	return append(dst, byte(u.lo.lo))
+}
+
+// Uvarint256 decodes a Uint256 from b and returns that value and the number of bytes read.
+// See Uvarint128 for the meaning of n.
+func Uvarint256(b []byte) (Uint256, int) {
+	var u Uint256
+	var s uint
+	for i, c := range b {
+		if i == MaxVarintLen256 {
+			return Uint256{}, -(i + 1) // overflow
+		}
+		if c < 0x80 {
+			if i == MaxVarintLen256-1 && c > 15 {
+				return Uint256{}, -(i + 1) // overflow
+			}
+			return u.Or(Uint256FromUint64(uint64(c)).Lsh(s)), i + 1
+		}
+		u = u.Or(Uint256FromUint64(uint64(c & 0x7f)).Lsh(s))
+		s += 7
+	}
+	return Uint256{}, 0
+}
@@ -0,0 +1,69 @@
+package mathx
+
+import (
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestUvarint128(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	values := []Uint128{
+		{},
+		Uint128FromUint64(127),
+		Uint128FromUint64(128),
+		Uint128FromUint64(math.MaxUint64),
+		NewUint128(math.MaxUint64, math.MaxUint64),
+	}
+	for i := 0; i < 1000; i++ {
+		values = append(values, NewUint128(rng.Uint64()>>uint(rng.Intn(64)), rng.Uint64()>>uint(rng.Intn(64))))
+	}
+
+	for _, u := range values {
+		b := AppendUvarint128(nil, u)
+		if len(b) > MaxVarintLen128 {
+			t.Fatalf("encoding is too long for %v; got %d bytes", u, len(b))
+		}
+
+		got, n := Uvarint128(b)
+		if n != len(b) || got != u {
+			t.Fatalf("unexpected value; got %v (n=%d); want %v (n=%d)", got, n, u, len(b))
+		}
+		if _, n := Uvarint128(b[:len(b)-1]); n != 0 {
+			t.Fatalf("unexpected n for truncated input; got %d; want %d", n, 0)
+		}
+	}
+
+	// Compatible with encoding/binary.
+	b := make([]byte, binary.MaxVarintLen64)
+	b = b[:binary.PutUvarint(b, 1<<63+12345)]
+	if got, _ := Uvarint128(b); got != Uint128FromUint64(1<<63+12345) {
+		t.Fatalf("unexpected value; got %v", got)
+	}
+
+	over := append(AppendUvarint128(nil, NewUint128(math.MaxUint64, math.MaxUint64))[:18], 0x04)
+	if _, n := Uvarint128(over); n != -19 {
+		t.Fatalf("unexpected n for overflow; got %d; want %d", n, -19)
+	}
+}
+
+func TestUvarint256(t *testing.T) {
+	max := NewUint128(math.MaxUint64, math.MaxUint64)
+	for _, u := range []Uint256{{}, Uint256FromUint64(300), NewUint256(NewUint128(1, 2), NewUint128(3, 4)), NewUint256(max, max)} {
+		b := AppendUvarint256(nil, u)
+		if len(b) > MaxVarintLen256 {
+			t.Fatalf("encoding is too long for %v; got %d bytes", u, len(b))
+		}
+
+		got, n := Uvarint256(b)
+		if n != len(b) || got != u {
+			t.Fatalf("unexpected value; got %v (n=%d); want %v (n=%d)", got, n, u, len(b))
+		}
+	}
+
+	over := append(AppendUvarint256(nil, NewUint256(max, max))[:36], 0x10)
+	if _, n := Uvarint256(over); n != -37 {
+		t.Fatalf("unexpected n for overflow; got %d; want %d", n, -37)
+	}
+}
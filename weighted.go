@@ -0,0 +1,22 @@
+package mathx
+
+// WeightedSumExact returns the sum of values[i] * weights[i] as Double.
+// Each product is computed exactly by splitting the weight into 32-bit halves,
+// so weights above 2^53 lose no precision, and products are accumulated
+// in double-double arithmetic.
+// It panics if values and weights have different lengths.
+func WeightedSumExact(values []float64, weights []uint64) Double {
+	if len(values) != len(weights) {
+		panic("mathx: values and weights length mismatch")
+	}
+
+	var sum Double
+	for i, v := range values {
+		w := weights[i]
+		hi := float64(w>>32) * (1 << 32)
+		lo := float64(w & (1<<32 - 1))
+		sum = add22(sum, twoProd(v, hi))
+		sum = add22(sum, twoProd(v, lo))
+	}
+	return sum
+}
@@ -0,0 +1,32 @@
+package mathx
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestWeightedSumExact(t *testing.T) {
+	values := []float64{0.1, 1e10, -3.5, 1e-8, 7}
+	weights := []uint64{1<<64 - 1, 3, 1<<53 + 1, 123456789, 0}
+
+	want := new(big.Float).SetPrec(1000)
+	for i, v := range values {
+		p := new(big.Float).SetPrec(1000).SetFloat64(v)
+		p.Mul(p, new(big.Float).SetUint64(weights[i]))
+		want.Add(want, p)
+	}
+
+	got := WeightedSumExact(values, weights)
+	sum := new(big.Float).SetPrec(1000).SetFloat64(got.hi)
+	sum.Add(sum, new(big.Float).SetFloat64(got.lo))
+
+	diff := new(big.Float).Sub(sum, want)
+	diff.Quo(diff, want)
+	if f, _ := diff.Float64(); f > 1e-30 || f < -1e-30 {
+		t.Fatalf("unexpected WeightedSumExact; got %v; want %v", sum, want)
+	}
+
+	if got := WeightedSumExact(nil, nil); got.ToFloat64() != 0 {
+		t.Fatalf("unexpected empty sum; got %v", got)
+	}
+}
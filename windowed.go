@@ -0,0 +1,75 @@
+package mathx
+
+// WindowedHistogram keeps a Histogram per time window in a ring.
+// The caller advances windows with Rotate, usually from a ticker.
+type WindowedHistogram struct {
+	hs  []*Histogram
+	cur int
+	n   int // number of windows in use
+}
+
+// NewWindowedHistogram returns new WindowedHistogram with the given number of windows.
+func NewWindowedHistogram(windows int) *WindowedHistogram {
+	if windows <= 0 {
+		panic("mathx: windows must be positive")
+	}
+	w := &WindowedHistogram{
+		hs: make([]*Histogram, windows),
+		n:  1,
+	}
+	for i := range w.hs {
+		w.hs[i] = NewHistogram()
+	}
+	return w
+}
+
+// Windows returns the number of windows.
+func (w *WindowedHistogram) Windows() int { return len(w.hs) }
+
+// Update the current window with v.
+func (w *WindowedHistogram) Update(v float64) { w.hs[w.cur].Update(v) }
+
+// Observe is an alias for Update.
+func (w *WindowedHistogram) Observe(v float64) { w.Update(v) }
+
+// Rotate starts a new window, dropping the oldest one when the ring is full.
+func (w *WindowedHistogram) Rotate() {
+	w.cur = (w.cur + 1) % len(w.hs)
+	w.hs[w.cur].Reset()
+	if w.n < len(w.hs) {
+		w.n++
+	}
+}
+
+// Reset resets all windows.
+func (w *WindowedHistogram) Reset() {
+	for _, h := range w.hs {
+		h.Reset()
+	}
+	w.cur, w.n = 0, 1
+}
+
+// Quantile returns the quantile value for the given phi over all windows.
+func (w *WindowedHistogram) Quantile(phi float64) float64 {
+	return MergeHistograms(w.windows()).Quantile(phi)
+}
+
+// QuantileSeries returns the quantile value for the given phi per window,
+// from the oldest to the current one. Empty windows give NaN.
+func (w *WindowedHistogram) QuantileSeries(phi float64) []float64 {
+	hs := w.windows()
+	series := make([]float64, len(hs))
+	for i, h := range hs {
+		series[i] = h.Quantile(phi)
+	}
+	return series
+}
+
+// windows returns windows in use from the oldest to the current one.
+func (w *WindowedHistogram) windows() []*Histogram {
+	hs := make([]*Histogram, 0, w.n)
+	for i := w.n - 1; i >= 0; i-- {
+		hs = append(hs, w.hs[(w.cur-i+len(w.hs))%len(w.hs)])
+	}
+	return hs
+}
@@ -0,0 +1,42 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWindowedHistogram(t *testing.T) {
+	w := NewWindowedHistogram(3)
+
+	for i := 1; i <= 5; i++ {
+		if i > 1 {
+			w.Rotate()
+		}
+		if i == 4 {
+			continue // leave a window empty
+		}
+		for j := 0; j < 100; j++ {
+			w.Update(float64(i*1000 + j))
+		}
+	}
+
+	got := w.QuantileSeries(1)
+	want := []float64{3099, NaN, 5099}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected series length; got %v; want %v", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] && !(math.IsNaN(got[i]) && math.IsNaN(want[i])) {
+			t.Fatalf("unexpected series; got %v; want %v", got, want)
+		}
+	}
+
+	if q := w.Quantile(0); q != 3000 {
+		t.Fatalf("unexpected quantile; got %v; want %v", q, 3000)
+	}
+
+	w.Reset()
+	if got := w.QuantileSeries(0.5); len(got) != 1 || !math.IsNaN(got[0]) {
+		t.Fatalf("unexpected series after reset: %v", got)
+	}
+}